@@ -10,6 +10,7 @@ import (
 	"payslip-generator-service/pkg/fiber"
 	"payslip-generator-service/pkg/logger"
 	"payslip-generator-service/pkg/middleware"
+	"payslip-generator-service/pkg/storage"
 	"payslip-generator-service/pkg/validator"
 	"runtime"
 	"sync"
@@ -50,6 +51,10 @@ func main() {
 	log.Infof("database connected, host: %s", conf.Postgres.Host)
 	defer db.Close()
 
+	// Storage backend of the uploaded files
+	fileStorage := storage.NewStorage(conf, log)
+	log.Info("initialized storage")
+
 	// Initialize fiber application
 	fiberApp := fiber.NewFiber(conf, log)
 	log.Infof("initialized fiber server")
@@ -65,6 +70,7 @@ func main() {
 		Log:       log,
 		Config:    conf,
 		Validator: newValidator,
+		Storage:   fileStorage,
 	})
 
 	log.Info("setup exception middleware")
@@ -1,10 +1,15 @@
 package config
 
 type Config struct {
-	App      appConfig
-	Security securityConfig
-	Logger   loggerConfig
-	Postgres postgresConfig
+	App           appConfig
+	Security      securityConfig
+	Logger        loggerConfig
+	Postgres      postgresConfig
+	Accounting    accountingConfig
+	Attendance    attendanceConfig
+	Overtime      overtimeConfig
+	Reimbursement reimbursementConfig
+	Storage       storageConfig
 }
 
 type appConfig struct {
@@ -14,11 +19,16 @@ type appConfig struct {
 	Host         string
 	Port         int
 	Env          string
+	TimeZone     string
 	Debug        bool
 	ReadTimeout  int
 	WriteTimeout int
 	SSL          bool
 	Prefork      bool
+	BodyLimit    int
+	// ProxyHeader holds the client IP set by the reverse proxy, it is only read on requests from TrustedProxies
+	ProxyHeader    string
+	TrustedProxies []string
 }
 
 type securityConfig struct {
@@ -91,3 +101,103 @@ type cookieConfig struct {
 	MaxAge   int
 	Key      string
 }
+
+type accountingConfig struct {
+	Accounts    accountMappingConfig
+	Departments map[string]accountMappingConfig
+}
+
+type accountMappingConfig struct {
+	SalaryExpense        string
+	OvertimeExpense      string
+	ReimbursementExpense string
+	TaxPayable           string
+	BpjsPayable          string
+	NetSalaryPayable     string
+}
+
+type attendanceConfig struct {
+	DefaultShiftEnd string
+	BreakAllowance  int
+	Deduction       attendanceDeductionConfig
+	Import          attendanceImportConfig
+	Location        attendanceLocationConfig
+}
+
+type attendanceDeductionConfig struct {
+	Enabled               bool
+	LateGracePeriod       int
+	EarlyLeaveGracePeriod int
+}
+
+type attendanceImportConfig struct {
+	MaxFileSize        int
+	Delimiter          string
+	DeviceUserIDColumn string
+	TimestampColumn    string
+	DirectionColumn    string
+	TimestampLayout    string
+	InValues           []string
+	OutValues          []string
+}
+
+type attendanceLocationConfig struct {
+	Policy  string
+	Offices []officeConfig
+}
+
+type overtimeConfig struct {
+	Mode              string
+	ExcessClaimPolicy string
+	Caps              overtimeCapsConfig
+	Toil              toilConfig
+}
+
+type overtimeCapsConfig struct {
+	Daily   int
+	Weekly  int
+	Monthly int
+}
+
+type toilConfig struct {
+	MaxBalance int
+	ExpiryDays int
+}
+
+type reimbursementConfig struct {
+	Receipt receiptConfig
+}
+
+type receiptConfig struct {
+	MaxFileSize  int
+	MaxFiles     int
+	AllowedTypes []string
+}
+
+type storageConfig struct {
+	Driver string
+	Local  localStorageConfig
+	S3     s3StorageConfig
+}
+
+type localStorageConfig struct {
+	Root string
+}
+
+type s3StorageConfig struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	PathStyle bool
+	Timeout   int
+}
+
+type officeConfig struct {
+	Name         string
+	Latitude     float64
+	Longitude    float64
+	Radius       float64
+	AllowedCIDRs []string
+}
@@ -9,6 +9,7 @@ import (
 	"payslip-generator-service/internal/usecase"
 	"payslip-generator-service/internal/utils"
 	"payslip-generator-service/pkg/logger"
+	"payslip-generator-service/pkg/storage"
 	"payslip-generator-service/pkg/validator"
 
 	"github.com/gofiber/fiber/v2"
@@ -22,6 +23,7 @@ type BootstrapConfig struct {
 	Config    *config.Config
 	DB        *gorm.DB
 	Validator *validator.Validator
+	Storage   storage.Storage
 }
 
 func Bootstrap(config *BootstrapConfig) {
@@ -34,19 +36,57 @@ func Bootstrap(config *BootstrapConfig) {
 	// init repositories
 	userRepository := repository.NewEmployeeRepository(config.Log)
 	reimbursementRepository := repository.NewReimbursementRepository(config.Log)
+	reimbursementReviewRepository := repository.NewReimbursementReviewRepository(config.Log)
+	reimbursementReceiptRepository := repository.NewReimbursementReceiptRepository(config.Log)
 	attendanceRepository := repository.NewAttendanceRepository(config.Log)
+	attendanceBreakRepository := repository.NewAttendanceBreakRepository(config.Log)
+	attendanceRevisionRepository := repository.NewAttendanceRevisionRepository(config.Log)
+	attendanceCorrectionRepository := repository.NewAttendanceCorrectionRequestRepository(config.Log)
 	overtimeRepository := repository.NewOvertimeRepository(config.Log)
+	overtimePlanRepository := repository.NewOvertimePlanRepository(config.Log)
+	overtimeEligibilityRepository := repository.NewOvertimeEligibilityRepository(config.Log)
+	toilEntryRepository := repository.NewToilEntryRepository(config.Log)
 	payrollRepository := repository.NewPayrollPeriodRepository(config.Log)
+	payslipTotalRepository := repository.NewPayslipTotalRepository(config.Log)
+	workScheduleRepository := repository.NewWorkScheduleRepository(config.Log)
 
 	// init use cases
 	authUseCase := usecase.NewAuthUseCase(config.DB, contextLogger, config.Config, jwtUtil, userRepository)
-	employeeUseCase := usecase.NewEmployeeUseCase(config.DB, contextLogger, userRepository)
-	reimbursementUseCase := usecase.NewReimbursementUseCase(config.DB, contextLogger, reimbursementRepository)
-	attendanceUseCase := usecase.NewAttendanceUseCase(config.DB, contextLogger, attendanceRepository)
-	overtimeUseCase := usecase.NewOvertimeUseCase(config.DB, contextLogger, overtimeRepository, attendanceRepository)
+	employeeUseCase := usecase.NewEmployeeUseCase(config.DB, contextLogger, userRepository, overtimeEligibilityRepository, payrollRepository)
+	reimbursementUseCase := usecase.NewReimbursementUseCase(
+		config.DB, contextLogger, config.Config, config.Storage,
+		reimbursementRepository,
+		payrollRepository,
+		reimbursementReviewRepository,
+		userRepository,
+		reimbursementReceiptRepository,
+	)
+	attendanceUseCase := usecase.NewAttendanceUseCase(
+		config.DB, contextLogger, config.Config,
+		attendanceRepository,
+		attendanceBreakRepository,
+		attendanceRevisionRepository,
+		attendanceCorrectionRepository,
+		userRepository,
+		workScheduleRepository,
+		payrollRepository,
+	)
+	overtimeUseCase := usecase.NewOvertimeUseCase(
+		config.DB, contextLogger, config.Config,
+		overtimeRepository,
+		overtimePlanRepository,
+		attendanceRepository,
+		userRepository,
+		payrollRepository,
+		overtimeEligibilityRepository,
+		toilEntryRepository,
+		attendanceUseCase,
+	)
+	workScheduleUseCase := usecase.NewWorkScheduleUseCase(config.DB, contextLogger, workScheduleRepository, userRepository)
 	payrollUseCase := usecase.NewPayrollUseCase(
-		config.DB, contextLogger,
+		config.DB, contextLogger, config.Config,
 		payrollRepository,
+		payslipTotalRepository,
 		attendanceUseCase,
 		overtimeUseCase,
 		reimbursementUseCase,
@@ -59,9 +99,11 @@ func Bootstrap(config *BootstrapConfig) {
 	attendanceHandler := handler.NewAttendanceHandler(attendanceUseCase, contextLogger, config.Validator)
 	overtimeHandler := handler.NewOvertimeHandler(overtimeUseCase, contextLogger, config.Validator)
 	payrollHandler := handler.NewPayrollHandler(payrollUseCase, contextLogger, config.Validator)
+	workScheduleHandler := handler.NewWorkScheduleHandler(workScheduleUseCase, contextLogger, config.Validator)
+	employeeHandler := handler.NewEmployeeHandler(employeeUseCase, contextLogger, config.Validator)
 
 	// init middleware
-	authMiddleware := middleware.NewAuthMiddleware(employeeUseCase, jwtUtil)
+	authMiddleware := middleware.NewAuthMiddleware(employeeUseCase, jwtUtil, config.Config)
 
 	// init routes
 	appRoute := route.NewRoute(
@@ -71,6 +113,8 @@ func Bootstrap(config *BootstrapConfig) {
 		attendanceHandler,
 		overtimeHandler,
 		payrollHandler,
+		workScheduleHandler,
+		employeeHandler,
 	)
 
 	// setup routes
@@ -1,13 +1,27 @@
 package entity
 
 import (
+	"sort"
 	"time"
 
 	"payslip-generator-service/pkg/database/gorm"
+	"payslip-generator-service/pkg/timezone"
 
 	"github.com/oklog/ulid/v2"
 )
 
+// AttendanceLocationStatus represents whether an attendance was recorded from an office
+type AttendanceLocationStatus string
+
+const (
+	// AttendanceLocationStatusUnverified marks an attendance that was not checked, no office is configured
+	AttendanceLocationStatusUnverified AttendanceLocationStatus = "unverified"
+	// AttendanceLocationStatusOffice marks an attendance within an office geofence or from an office network
+	AttendanceLocationStatusOffice AttendanceLocationStatus = "office"
+	// AttendanceLocationStatusRemote marks an attendance outside every office
+	AttendanceLocationStatusRemote AttendanceLocationStatus = "remote"
+)
+
 // Attendance represents an employee's attendance record
 // swagger:model Attendance
 type Attendance struct {
@@ -19,9 +33,37 @@ type Attendance struct {
 	// example: "2024-01-15T08:00:00Z"
 	StartTime time.Time `json:"start_time" gorm:"column:start_time;type:timestamp with time zone;not null"`
 
-	// End time of the work shift
+	// End time of the work shift, empty while the employee is still checked in
 	// example: "2024-01-15T17:00:00Z"
-	EndTime time.Time `json:"end_time" gorm:"column:end_time;type:timestamp with time zone;not null"`
+	EndTime *time.Time `json:"end_time" gorm:"column:end_time;type:timestamp with time zone"`
+
+	// Whether the end time was set automatically because the employee forgot to check out
+	// example: false
+	AutoCheckedOut bool `json:"auto_checked_out" gorm:"column:auto_checked_out;type:boolean;not null;default:false"`
+
+	// Calendar day of the shift in the employee's time zone
+	// example: "2024-01-15T00:00:00Z"
+	WorkDate time.Time `json:"work_date" gorm:"column:work_date;type:date;not null"`
+
+	// Latitude the employee checked in from
+	// example: -6.2254
+	Latitude *float64 `json:"latitude" gorm:"column:latitude;type:double precision"`
+
+	// Longitude the employee checked in from
+	// example: 106.7997
+	Longitude *float64 `json:"longitude" gorm:"column:longitude;type:double precision"`
+
+	// IP address the employee checked in from
+	// example: "203.0.113.10"
+	IPAddress *string `json:"ip_address" gorm:"column:ip_address;size:45"`
+
+	// Whether the check-in was within an office geofence or from an office network
+	// example: "office"
+	LocationStatus AttendanceLocationStatus `json:"location_status" gorm:"column:location_status;type:attendance_location_status;not null;default:unverified"`
+
+	// Name of the office the check-in was matched to
+	// example: "Jakarta HQ"
+	Office *string `json:"office" gorm:"column:office;size:100"`
 
 	// Timestamp when the attendance record was created
 	// example: "2024-01-15T08:00:00Z"
@@ -39,7 +81,19 @@ type Attendance struct {
 	// example: "01HXYZ123456789ABCDEFGHIJK"
 	UpdatedBy *gorm.ULID `json:"updated_by" gorm:"column:updated_by;type:ulid"`
 
+	// Timestamp when the attendance record was voided by an admin
+	// example: "2024-01-16T08:00:00Z"
+	VoidedAt *time.Time `json:"voided_at,omitempty" gorm:"column:voided_at;type:timestamp with time zone"`
+
+	// ID of the admin who voided the attendance record
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	VoidedBy *gorm.ULID `json:"voided_by,omitempty" gorm:"column:voided_by;type:ulid"`
+
 	// Relations
+	// Breaks taken within the attendance day
+	Breaks []AttendanceBreak `json:"breaks,omitempty" gorm:"foreignKey:AttendanceID"`
+	// Corrections made to the attendance record by admins
+	Revisions []AttendanceRevision `json:"revisions,omitempty" gorm:"foreignKey:AttendanceID"`
 	// Employee who created the attendance record
 	Creator *Employee `json:"creator,omitempty" gorm:"foreignKey:CreatedBy"`
 	// Employee who last updated the attendance record
@@ -51,28 +105,58 @@ type Attendance struct {
 type CreateAttendanceProps struct {
 	// Start time of the work shift
 	StartTime time.Time
-	// End time of the work shift
-	EndTime time.Time
+	// End time of the work shift, nil to open a checked-in session
+	EndTime *time.Time
+	// Time zone of the employee, the shift day is taken in it; nil keeps the zone of StartTime
+	Location *time.Location
 	// ID of the employee creating the attendance record
 	CreatedBy gorm.ULID
 }
 
+// AttendanceOrigin represents where an attendance was recorded from
+// swagger:model AttendanceOrigin
+type AttendanceOrigin struct {
+	// Latitude the employee checked in from
+	Latitude *float64
+	// Longitude the employee checked in from
+	Longitude *float64
+	// IP address the employee checked in from
+	IPAddress *string
+	// Whether the check-in was within an office
+	Status AttendanceLocationStatus
+	// Name of the office the check-in was matched to
+	Office *string
+}
+
 func NewAttendance(props *CreateAttendanceProps) *Attendance {
-	return &Attendance{
-		ID:        gorm.ULID(ulid.Make()),
-		StartTime: props.StartTime,
-		EndTime:   props.EndTime,
-		CreatedAt: time.Now(),
-		CreatedBy: props.CreatedBy,
+	attendance := &Attendance{
+		ID:             gorm.ULID(ulid.Make()),
+		StartTime:      props.StartTime,
+		EndTime:        props.EndTime,
+		LocationStatus: AttendanceLocationStatusUnverified,
+		CreatedAt:      time.Now(),
+		CreatedBy:      props.CreatedBy,
+	}
+
+	location := props.Location
+	if location == nil {
+		location = props.StartTime.Location()
 	}
+	attendance.In(location)
+	attendance.WorkDate = timezone.DateOf(attendance.StartTime, location)
+
+	return attendance
 }
 
 func (a *Attendance) TableName() string {
 	return "attendance"
 }
 
-// GetDuration returns the duration of the attendance
+// GetDuration returns the duration of the attendance, zero while the session is open
 func (a *Attendance) GetDuration() time.Duration {
+	if a.EndTime == nil {
+		return 0
+	}
 	return a.EndTime.Sub(a.StartTime)
 }
 
@@ -81,39 +165,202 @@ func (a *Attendance) GetDurationInHours() float64 {
 	return a.GetDuration().Hours()
 }
 
-func (a *Attendance) IsToday() bool {
-	return a.StartTime.Year() == time.Now().Year() &&
-		a.StartTime.YearDay() == time.Now().YearDay() &&
-		a.EndTime.Year() == time.Now().Year() &&
-		a.EndTime.YearDay() == time.Now().YearDay()
+// GetUnpaidBreakDuration returns the total duration of the finished unpaid breaks
+func (a *Attendance) GetUnpaidBreakDuration() time.Duration {
+	var total time.Duration
+	for _, b := range a.Breaks {
+		if !b.Paid {
+			total += b.GetDuration()
+		}
+	}
+	return total
+}
+
+// GetNetDuration returns the duration of the attendance without its unpaid breaks, zero while the session is open
+func (a *Attendance) GetNetDuration() time.Duration {
+	return max(a.GetDuration()-a.GetUnpaidBreakDuration(), 0)
+}
+
+// GetNetDurationInHours returns the net duration in hours
+func (a *Attendance) GetNetDurationInHours() float64 {
+	return a.GetNetDuration().Hours()
+}
+
+// OpenBreak returns the break the employee is on, nil when not on a break
+func (a *Attendance) OpenBreak() *AttendanceBreak {
+	for i := range a.Breaks {
+		if a.Breaks[i].IsOpen() {
+			return &a.Breaks[i]
+		}
+	}
+	return nil
+}
+
+// HasValidBreaks checks that every finished break does not end before it starts, lies within the attendance
+// and does not overlap another break
+func (a *Attendance) HasValidBreaks() bool {
+	breaks := make([]AttendanceBreak, len(a.Breaks))
+	copy(breaks, a.Breaks)
+	sort.Slice(breaks, func(i, j int) bool {
+		return breaks[i].StartTime.Before(breaks[j].StartTime)
+	})
+
+	for i, b := range breaks {
+		if b.StartTime.Before(a.StartTime) {
+			return false
+		}
+		if b.EndTime == nil {
+			// only the last break may still be in progress, and only while the attendance is open
+			if !a.IsOpen() || i != len(breaks)-1 {
+				return false
+			}
+			continue
+		}
+		if b.EndTime.Before(b.StartTime) || (a.EndTime != nil && b.EndTime.After(*a.EndTime)) {
+			return false
+		}
+		if i+1 < len(breaks) && breaks[i+1].StartTime.Before(*b.EndTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// In converts the start and end time to the location, day checks are then evaluated in it
+func (a *Attendance) In(location *time.Location) *Attendance {
+	a.StartTime = a.StartTime.In(location)
+	if a.EndTime != nil {
+		endTime := a.EndTime.In(location)
+		a.EndTime = &endTime
+	}
+	return a
+}
+
+// IsOnDate checks if the shift day is the calendar day of the given time in its location
+func (a *Attendance) IsOnDate(t time.Time) bool {
+	return a.WorkDate.Format(time.DateOnly) == t.Format(time.DateOnly)
 }
 
 // IsSameDay checks if the attendance spans the same day
 func (a *Attendance) IsSameDay() bool {
+	if a.EndTime == nil {
+		return true
+	}
 	return a.StartTime.Year() == a.EndTime.Year() &&
 		a.StartTime.YearDay() == a.EndTime.YearDay()
 }
 
-// IsWeekday checks if the attendance is on a weekday
-func (a *Attendance) IsWeekday() bool {
-	startWeekday := a.StartTime.Weekday()
-	endWeekday := a.EndTime.Weekday()
+// IsWorkDay checks if the attendance starts on a work day of the schedule
+func (a *Attendance) IsWorkDay(schedule *WorkSchedule) bool {
+	return schedule.IsWorkDay(a.StartTime)
+}
+
+// IsWithinShiftSpan checks if the attendance ends on the day it started,
+// or on the next day within 24 hours for a cross-midnight shift
+func (a *Attendance) IsWithinShiftSpan(schedule *WorkSchedule) bool {
+	if a.EndTime == nil {
+		return true
+	}
+	if !schedule.IsCrossMidnight() {
+		return a.IsSameDay()
+	}
+
+	lastDay := schedule.LastShiftDay(a.StartTime)
+	return a.EndTime.Before(lastDay.AddDate(0, 0, 1)) && a.GetDuration() <= 24*time.Hour
+}
+
+// IsCurrentShift checks if the attendance belongs to the shift in progress at the given time:
+// started today, or yesterday for a cross-midnight shift
+func (a *Attendance) IsCurrentShift(schedule *WorkSchedule, now time.Time) bool {
+	now = now.In(a.StartTime.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return !a.StartTime.Before(today.AddDate(0, 0, -1)) &&
+		!schedule.LastShiftDay(a.StartTime).Before(today) &&
+		a.StartTime.Before(today.AddDate(0, 0, 1))
+}
+
+// IsStale checks if the attendance is still open past the last day its shift may end on
+func (a *Attendance) IsStale(schedule *WorkSchedule, now time.Time) bool {
+	now = now.In(a.StartTime.Location())
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return a.IsOpen() && schedule.LastShiftDay(a.StartTime).Before(day)
+}
+
+// LateBy returns how long after the scheduled shift start the attendance started
+func (a *Attendance) LateBy(schedule *WorkSchedule) time.Duration {
+	return max(a.StartTime.Sub(schedule.ShiftStartAt(a.StartTime)), 0)
+}
+
+// LeftEarlyBy returns how long before the scheduled shift end the attendance ended, zero while the session is open
+func (a *Attendance) LeftEarlyBy(schedule *WorkSchedule) time.Duration {
+	if a.EndTime == nil {
+		return 0
+	}
+	return max(schedule.ShiftEndAt(a.StartTime).Sub(*a.EndTime), 0)
+}
+
+// OvertimeBy returns the net time worked beyond the shift of the schedule, the break allowance being part of the shift
+func (a *Attendance) OvertimeBy(schedule *WorkSchedule, breakAllowance time.Duration) time.Duration {
+	return max(a.GetNetDuration()-max(schedule.ShiftDuration()-breakAllowance, 0), 0)
+}
 
-	// Sunday = 0, Saturday = 6
-	return startWeekday != time.Sunday && startWeekday != time.Saturday &&
-		endWeekday != time.Sunday && endWeekday != time.Saturday
+// Locate records where the attendance was recorded from
+func (a *Attendance) Locate(origin *AttendanceOrigin) {
+	a.Latitude = origin.Latitude
+	a.Longitude = origin.Longitude
+	a.IPAddress = origin.IPAddress
+	a.LocationStatus = origin.Status
+	a.Office = origin.Office
 }
 
 // endTime must be greater than startTime
 func (a *Attendance) IsEndTimeGreaterThanStartTime() bool {
-	return a.EndTime.After(a.StartTime)
+	return a.EndTime == nil || a.EndTime.After(a.StartTime)
+}
+
+// IsOpen checks if the employee has checked in but not checked out yet
+func (a *Attendance) IsOpen() bool {
+	return a.EndTime == nil
+}
+
+// CheckOut closes the attendance session and the break the employee is on, auto marks a check-out done by the system
+func (a *Attendance) CheckOut(endTime time.Time, auto bool, updatedBy gorm.ULID) {
+	now := time.Now()
+	if b := a.OpenBreak(); b != nil {
+		b.End(endTime, updatedBy)
+	}
+	a.EndTime = &endTime
+	a.AutoCheckedOut = auto
+	a.UpdatedAt = &now
+	a.UpdatedBy = &updatedBy
+}
+
+// Void marks the attendance as voided so it is no longer counted
+func (a *Attendance) Void(voidedBy gorm.ULID) {
+	now := time.Now()
+	a.VoidedAt = &now
+	a.VoidedBy = &voidedBy
+	a.UpdatedAt = &now
+	a.UpdatedBy = &voidedBy
+}
+
+// IsVoided checks if the attendance was voided
+func (a *Attendance) IsVoided() bool {
+	return a.VoidedAt != nil
+}
+
+// IsCorrected checks if the attendance was corrected by an admin
+func (a *Attendance) IsCorrected() bool {
+	return len(a.Revisions) > 0
 }
 
-// Update updates the attendance with new times
+// Update updates the attendance with new times, the shift day is taken in the location of startTime
 func (a *Attendance) Update(startTime, endTime time.Time, updatedBy gorm.ULID) {
 	now := time.Now()
 	a.StartTime = startTime
-	a.EndTime = endTime
+	a.EndTime = &endTime
+	a.WorkDate = timezone.DateOf(startTime, startTime.Location())
+	a.AutoCheckedOut = false
 	a.UpdatedAt = &now
 	updatedByULID := gorm.ULID(updatedBy)
 	a.UpdatedBy = &updatedByULID
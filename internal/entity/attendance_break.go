@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"time"
+
+	"payslip-generator-service/pkg/database/gorm"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// AttendanceBreak represents a break taken within an attendance day, such as a lunch break or the gap of a split shift
+// swagger:model AttendanceBreak
+type AttendanceBreak struct {
+	// Unique identifier for the break
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ID gorm.ULID `json:"id" gorm:"column:id;type:ulid;primaryKey"`
+
+	// ID of the attendance the break was taken in
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	AttendanceID gorm.ULID `json:"attendance_id" gorm:"column:attendance_id;type:ulid;not null"`
+
+	// Start time of the break
+	// example: "2024-01-15T12:00:00Z"
+	StartTime time.Time `json:"start_time" gorm:"column:start_time;type:timestamp with time zone;not null"`
+
+	// End time of the break, empty while the employee is on the break
+	// example: "2024-01-15T13:00:00Z"
+	EndTime *time.Time `json:"end_time" gorm:"column:end_time;type:timestamp with time zone"`
+
+	// Whether the break counts as worked time, unpaid breaks are excluded from the net worked hours
+	// example: false
+	Paid bool `json:"paid" gorm:"column:paid;type:boolean;not null;default:false"`
+
+	// Timestamp when the break was created
+	// example: "2024-01-15T12:00:00Z"
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP"`
+
+	// ID of the employee who created the break
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	CreatedBy gorm.ULID `json:"created_by" gorm:"column:created_by;type:ulid;not null"`
+
+	// Timestamp when the break was last updated
+	// example: "2024-01-15T13:00:00Z"
+	UpdatedAt *time.Time `json:"updated_at" gorm:"column:updated_at;type:timestamp with time zone"`
+
+	// ID of the employee who last updated the break
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	UpdatedBy *gorm.ULID `json:"updated_by" gorm:"column:updated_by;type:ulid"`
+}
+
+// CreateAttendanceBreakProps represents the properties needed to create a new attendance break
+// swagger:model CreateAttendanceBreakProps
+type CreateAttendanceBreakProps struct {
+	// ID of the attendance the break is taken in
+	AttendanceID gorm.ULID
+	// Start time of the break
+	StartTime time.Time
+	// End time of the break, nil to start a break in progress
+	EndTime *time.Time
+	// Whether the break counts as worked time
+	Paid bool
+	// ID of the employee creating the break
+	CreatedBy gorm.ULID
+}
+
+func NewAttendanceBreak(props *CreateAttendanceBreakProps) *AttendanceBreak {
+	return &AttendanceBreak{
+		ID:           gorm.ULID(ulid.Make()),
+		AttendanceID: props.AttendanceID,
+		StartTime:    props.StartTime,
+		EndTime:      props.EndTime,
+		Paid:         props.Paid,
+		CreatedAt:    time.Now(),
+		CreatedBy:    props.CreatedBy,
+	}
+}
+
+func (b *AttendanceBreak) TableName() string {
+	return "attendance_break"
+}
+
+// GetDuration returns the duration of the break, zero while the break is in progress
+func (b *AttendanceBreak) GetDuration() time.Duration {
+	if b.EndTime == nil {
+		return 0
+	}
+	return b.EndTime.Sub(b.StartTime)
+}
+
+// IsOpen checks if the employee is still on the break
+func (b *AttendanceBreak) IsOpen() bool {
+	return b.EndTime == nil
+}
+
+// End closes the break
+func (b *AttendanceBreak) End(endTime time.Time, updatedBy gorm.ULID) {
+	now := time.Now()
+	b.EndTime = &endTime
+	b.UpdatedAt = &now
+	b.UpdatedBy = &updatedBy
+}
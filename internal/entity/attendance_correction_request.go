@@ -0,0 +1,142 @@
+package entity
+
+import (
+	"time"
+
+	"payslip-generator-service/pkg/database/gorm"
+	"payslip-generator-service/pkg/timezone"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// AttendanceCorrectionRequestStatus represents the review state of a correction request
+type AttendanceCorrectionRequestStatus string
+
+const (
+	AttendanceCorrectionRequestStatusPending  AttendanceCorrectionRequestStatus = "pending"
+	AttendanceCorrectionRequestStatusApproved AttendanceCorrectionRequestStatus = "approved"
+	AttendanceCorrectionRequestStatusRejected AttendanceCorrectionRequestStatus = "rejected"
+)
+
+// AttendanceCorrectionRequest represents an employee's request to fix a missed or wrong attendance day
+// swagger:model AttendanceCorrectionRequest
+type AttendanceCorrectionRequest struct {
+	// Unique identifier for the correction request
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ID gorm.ULID `json:"id" gorm:"column:id;type:ulid;primaryKey"`
+
+	// Attendance record to amend, empty when the day was missed
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	AttendanceID *gorm.ULID `json:"attendance_id" gorm:"column:attendance_id;type:ulid"`
+
+	// Proposed start time
+	// example: "2024-01-15T08:00:00Z"
+	StartTime time.Time `json:"start_time" gorm:"column:start_time;type:timestamp with time zone;not null"`
+
+	// Proposed end time
+	// example: "2024-01-15T17:00:00Z"
+	EndTime time.Time `json:"end_time" gorm:"column:end_time;type:timestamp with time zone;not null"`
+
+	// Calendar day of the proposed shift in the employee's time zone
+	// example: "2024-01-15T00:00:00Z"
+	WorkDate time.Time `json:"work_date" gorm:"column:work_date;type:date;not null"`
+
+	// Reason given by the employee
+	// example: "Forgot to check in after the client visit"
+	Reason string `json:"reason" gorm:"column:reason;type:text;not null"`
+
+	// Review state of the request
+	// example: "pending"
+	Status AttendanceCorrectionRequestStatus `json:"status" gorm:"column:status;type:attendance_correction_request_status;not null;default:pending"`
+
+	// Comment left by the reviewing admin
+	// example: "Confirmed with the team lead"
+	ReviewComment *string `json:"review_comment" gorm:"column:review_comment;type:text"`
+
+	// Timestamp when the request was approved or rejected
+	// example: "2024-01-16T08:00:00Z"
+	ReviewedAt *time.Time `json:"reviewed_at" gorm:"column:reviewed_at;type:timestamp with time zone"`
+
+	// ID of the admin who approved or rejected the request
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ReviewedBy *gorm.ULID `json:"reviewed_by" gorm:"column:reviewed_by;type:ulid"`
+
+	// Timestamp when the request was created
+	// example: "2024-01-15T08:00:00Z"
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP"`
+
+	// ID of the employee who created the request
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	CreatedBy gorm.ULID `json:"created_by" gorm:"column:created_by;type:ulid;not null"`
+
+	// Timestamp when the request was last updated
+	// example: "2024-01-15T08:00:00Z"
+	UpdatedAt *time.Time `json:"updated_at" gorm:"column:updated_at;type:timestamp with time zone"`
+
+	// ID of the employee who last updated the request
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	UpdatedBy *gorm.ULID `json:"updated_by" gorm:"column:updated_by;type:ulid"`
+
+	// Relations
+	// Employee who created the request
+	Creator *Employee `json:"creator,omitempty" gorm:"foreignKey:CreatedBy"`
+}
+
+// CreateAttendanceCorrectionRequestProps represents the properties needed to create a new correction request
+// swagger:model CreateAttendanceCorrectionRequestProps
+type CreateAttendanceCorrectionRequestProps struct {
+	// Attendance record to amend, nil when the day was missed
+	AttendanceID *gorm.ULID
+	// Proposed start time, the shift day is taken in its location
+	StartTime time.Time
+	// Proposed end time
+	EndTime time.Time
+	// Reason given by the employee
+	Reason string
+	// ID of the employee creating the request
+	CreatedBy gorm.ULID
+}
+
+func NewAttendanceCorrectionRequest(props *CreateAttendanceCorrectionRequestProps) *AttendanceCorrectionRequest {
+	return &AttendanceCorrectionRequest{
+		ID:           gorm.ULID(ulid.Make()),
+		AttendanceID: props.AttendanceID,
+		StartTime:    props.StartTime,
+		EndTime:      props.EndTime,
+		WorkDate:     timezone.DateOf(props.StartTime, props.StartTime.Location()),
+		Reason:       props.Reason,
+		Status:       AttendanceCorrectionRequestStatusPending,
+		CreatedAt:    time.Now(),
+		CreatedBy:    props.CreatedBy,
+	}
+}
+
+func (r *AttendanceCorrectionRequest) TableName() string {
+	return "attendance_correction_request"
+}
+
+// IsPending checks if the request is still waiting for review
+func (r *AttendanceCorrectionRequest) IsPending() bool {
+	return r.Status == AttendanceCorrectionRequestStatusPending
+}
+
+// Approve marks the request as approved, attendanceID is the record created or amended from it
+func (r *AttendanceCorrectionRequest) Approve(attendanceID gorm.ULID, reviewedBy gorm.ULID, comment *string) {
+	r.AttendanceID = &attendanceID
+	r.review(AttendanceCorrectionRequestStatusApproved, reviewedBy, comment)
+}
+
+// Reject marks the request as rejected
+func (r *AttendanceCorrectionRequest) Reject(reviewedBy gorm.ULID, comment *string) {
+	r.review(AttendanceCorrectionRequestStatusRejected, reviewedBy, comment)
+}
+
+func (r *AttendanceCorrectionRequest) review(status AttendanceCorrectionRequestStatus, reviewedBy gorm.ULID, comment *string) {
+	now := time.Now()
+	r.Status = status
+	r.ReviewComment = comment
+	r.ReviewedAt = &now
+	r.ReviewedBy = &reviewedBy
+	r.UpdatedAt = &now
+	r.UpdatedBy = &reviewedBy
+}
@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"time"
+
+	"payslip-generator-service/pkg/database/gorm"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// AttendanceRevisionAction represents the kind of correction made to an attendance record
+type AttendanceRevisionAction string
+
+const (
+	AttendanceRevisionActionCreate AttendanceRevisionAction = "create"
+	AttendanceRevisionActionAmend  AttendanceRevisionAction = "amend"
+	AttendanceRevisionActionVoid   AttendanceRevisionAction = "void"
+)
+
+// AttendanceRevision represents a correction made to an attendance record, keeping its original values
+// swagger:model AttendanceRevision
+type AttendanceRevision struct {
+	// Unique identifier for the revision
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ID gorm.ULID `json:"id" gorm:"column:id;type:ulid;primaryKey"`
+
+	// ID of the corrected attendance record
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	AttendanceID gorm.ULID `json:"attendance_id" gorm:"column:attendance_id;type:ulid;not null"`
+
+	// Kind of correction
+	// example: "amend"
+	Action AttendanceRevisionAction `json:"action" gorm:"column:action;type:attendance_revision_action;not null"`
+
+	// Start time before the correction
+	// example: "2024-01-15T09:30:00Z"
+	PreviousStartTime *time.Time `json:"previous_start_time" gorm:"column:previous_start_time;type:timestamp with time zone"`
+
+	// End time before the correction
+	// example: "2024-01-15T17:00:00Z"
+	PreviousEndTime *time.Time `json:"previous_end_time" gorm:"column:previous_end_time;type:timestamp with time zone"`
+
+	// Start time after the correction
+	// example: "2024-01-15T08:00:00Z"
+	NewStartTime *time.Time `json:"new_start_time" gorm:"column:new_start_time;type:timestamp with time zone"`
+
+	// End time after the correction
+	// example: "2024-01-15T17:00:00Z"
+	NewEndTime *time.Time `json:"new_end_time" gorm:"column:new_end_time;type:timestamp with time zone"`
+
+	// Reason given for the correction
+	// example: "Fingerprint reader was offline in the morning"
+	Reason string `json:"reason" gorm:"column:reason;type:text;not null"`
+
+	// ID of the employee correction request the correction was approved from
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	CorrectionRequestID *gorm.ULID `json:"correction_request_id,omitempty" gorm:"column:correction_request_id;type:ulid"`
+
+	// Timestamp when the correction was made
+	// example: "2024-01-16T08:00:00Z"
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP"`
+
+	// ID of the admin who made the correction
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	CreatedBy gorm.ULID `json:"created_by" gorm:"column:created_by;type:ulid;not null"`
+}
+
+// CreateAttendanceRevisionProps represents the properties needed to create a new attendance revision
+// swagger:model CreateAttendanceRevisionProps
+type CreateAttendanceRevisionProps struct {
+	// Corrected attendance record, holding its values after the correction
+	Attendance *Attendance
+	// Kind of correction
+	Action AttendanceRevisionAction
+	// Start time before the correction, nil when the attendance was created
+	PreviousStartTime *time.Time
+	// End time before the correction
+	PreviousEndTime *time.Time
+	// Reason given for the correction
+	Reason string
+	// ID of the admin making the correction
+	CreatedBy gorm.ULID
+	// ID of the employee correction request being approved, if any
+	CorrectionRequestID *gorm.ULID
+}
+
+func NewAttendanceRevision(props *CreateAttendanceRevisionProps) *AttendanceRevision {
+	revision := &AttendanceRevision{
+		ID:                  gorm.ULID(ulid.Make()),
+		AttendanceID:        props.Attendance.ID,
+		Action:              props.Action,
+		PreviousStartTime:   props.PreviousStartTime,
+		PreviousEndTime:     props.PreviousEndTime,
+		Reason:              props.Reason,
+		CorrectionRequestID: props.CorrectionRequestID,
+		CreatedAt:           time.Now(),
+		CreatedBy:           props.CreatedBy,
+	}
+
+	if props.Action != AttendanceRevisionActionVoid {
+		startTime := props.Attendance.StartTime
+		revision.NewStartTime = &startTime
+		revision.NewEndTime = props.Attendance.EndTime
+	}
+
+	return revision
+}
+
+func (r *AttendanceRevision) TableName() string {
+	return "attendance_revision"
+}
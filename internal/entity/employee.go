@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"payslip-generator-service/pkg/database/gorm"
+	"payslip-generator-service/pkg/timezone"
 
 	"github.com/oklog/ulid/v2"
 )
@@ -30,6 +31,26 @@ type Employee struct {
 	// example: false
 	IsAdmin bool `json:"is_admin" gorm:"column:is_admin;type:boolean;not null;default:false"`
 
+	// Department the employee belongs to, used for accounting dimensions
+	// example: "engineering"
+	Department *string `json:"department" gorm:"column:department;size:100"`
+
+	// Work schedule the employee follows, empty for the default Monday to Friday day shift
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	WorkScheduleID *gorm.ULID `json:"work_schedule_id" gorm:"column:work_schedule_id;type:ulid"`
+
+	// IANA time zone the employee works in, empty for the configured default
+	// example: "Asia/Makassar"
+	Timezone *string `json:"timezone" gorm:"column:timezone;size:64"`
+
+	// User ID the employee is enrolled with on biometric or timeclock terminals (unique)
+	// example: "1024"
+	DeviceUserID *string `json:"device_user_id" gorm:"column:device_user_id;size:64"`
+
+	// Manager of the employee, allowed to approve or reject the employee's overtime
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ManagerID *gorm.ULID `json:"manager_id" gorm:"column:manager_id;type:ulid"`
+
 	// Timestamp when the employee was created
 	// example: "2024-01-15T08:00:00Z"
 	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP"`
@@ -66,3 +87,45 @@ func NewEmployee(props *CreateEmployeeProps) *Employee {
 func (e *Employee) TableName() string {
 	return "employee"
 }
+
+// GetDepartment returns the employee's department or an empty string when unassigned
+func (e *Employee) GetDepartment() string {
+	if e.Department == nil {
+		return ""
+	}
+	return *e.Department
+}
+
+// GetLocation returns the employee's time zone, falling back to the default time zone name
+func (e *Employee) GetLocation(defaultTimeZone string) *time.Location {
+	if e.Timezone != nil {
+		return timezone.LoadOrDefault(*e.Timezone, defaultTimeZone)
+	}
+	return timezone.LoadOrDefault(defaultTimeZone)
+}
+
+// SetDeviceUserID sets the user ID the employee is enrolled with on terminals, nil unlinks the employee
+func (e *Employee) SetDeviceUserID(deviceUserID *string) {
+	now := time.Now()
+	e.DeviceUserID = deviceUserID
+	e.UpdatedAt = &now
+}
+
+// SetManager sets the manager of the employee, nil removes the manager
+func (e *Employee) SetManager(managerID *gorm.ULID) {
+	now := time.Now()
+	e.ManagerID = managerID
+	e.UpdatedAt = &now
+}
+
+// IsManagedBy checks if the employee reports to the given manager
+func (e *Employee) IsManagedBy(managerID gorm.ULID) bool {
+	return e.ManagerID != nil && *e.ManagerID == managerID
+}
+
+// SetTimezone sets the employee's time zone, nil restores the default
+func (e *Employee) SetTimezone(name *string) {
+	now := time.Now()
+	e.Timezone = name
+	e.UpdatedAt = &now
+}
@@ -8,6 +8,24 @@ import (
 	"github.com/oklog/ulid/v2"
 )
 
+// OvertimeStatus represents the approval state of an overtime record
+type OvertimeStatus string
+
+const (
+	OvertimeStatusPending   OvertimeStatus = "pending"
+	OvertimeStatusApproved  OvertimeStatus = "approved"
+	OvertimeStatusRejected  OvertimeStatus = "rejected"
+	OvertimeStatusCancelled OvertimeStatus = "cancelled"
+)
+
+// OvertimeCompensation represents how an overtime record is compensated
+type OvertimeCompensation string
+
+const (
+	OvertimeCompensationCash OvertimeCompensation = "cash"
+	OvertimeCompensationToil OvertimeCompensation = "toil"
+)
+
 // Overtime represents an employee's overtime record
 // swagger:model Overtime
 type Overtime struct {
@@ -19,10 +37,54 @@ type Overtime struct {
 	// example: "2024-01-15T00:00:00Z"
 	Date time.Time `json:"date" gorm:"column:date;type:date;not null"`
 
-	// Total hours of overtime (1-3 hours maximum)
+	// Total hours of overtime (1-3 hours maximum), the actual hours for overtime confirmed from a plan
 	// example: 2
 	TotalHours int `json:"total_hours" gorm:"column:total_hours;type:integer;not null"`
 
+	// Overtime plan the overtime was confirmed from
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	PlanID *gorm.ULID `json:"plan_id,omitempty" gorm:"column:plan_id;type:ulid"`
+
+	// Hours planned in advance, the lesser of the planned and actual hours is paid
+	// example: 3
+	PlannedHours *int `json:"planned_hours,omitempty" gorm:"column:planned_hours;type:integer"`
+
+	// Hours paid as set by an admin, replacing the lesser of the planned and actual hours
+	// example: 2
+	OverrideHours *int `json:"override_hours,omitempty" gorm:"column:override_hours;type:integer"`
+
+	// Reason given by the admin for overriding the paid hours
+	// example: "Release ran late, approved by the CTO"
+	OverrideComment *string `json:"override_comment,omitempty" gorm:"column:override_comment;type:text"`
+
+	// Timestamp when the paid hours were overridden
+	// example: "2024-01-21T08:00:00Z"
+	OverriddenAt *time.Time `json:"overridden_at,omitempty" gorm:"column:overridden_at;type:timestamp with time zone"`
+
+	// ID of the admin who overrode the paid hours
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	OverriddenBy *gorm.ULID `json:"overridden_by,omitempty" gorm:"column:overridden_by;type:ulid"`
+
+	// How the overtime is compensated, banked overtime is taken as time off in lieu instead of being paid
+	// example: "cash"
+	Compensation OvertimeCompensation `json:"compensation" gorm:"column:compensation;type:overtime_compensation;not null;default:cash"`
+
+	// Approval state, only approved overtime is paid
+	// example: "approved"
+	Status OvertimeStatus `json:"status" gorm:"column:status;type:overtime_status;not null;default:pending"`
+
+	// Comment left by the approver
+	// example: "Release support approved by the team lead"
+	ReviewComment *string `json:"review_comment" gorm:"column:review_comment;type:text"`
+
+	// Timestamp when the overtime was approved or rejected
+	// example: "2024-01-16T08:00:00Z"
+	ReviewedAt *time.Time `json:"reviewed_at" gorm:"column:reviewed_at;type:timestamp with time zone"`
+
+	// ID of the admin or manager who approved or rejected the overtime
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ReviewedBy *gorm.ULID `json:"reviewed_by" gorm:"column:reviewed_by;type:ulid"`
+
 	// Timestamp when the overtime record was created
 	// example: "2024-01-15T08:00:00Z"
 	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP"`
@@ -53,17 +115,43 @@ type CreateOvertimeProps struct {
 	Date time.Time
 	// Total hours of overtime
 	TotalHours int
+	// How the overtime is compensated, empty for cash payment
+	Compensation OvertimeCompensation
 	// ID of the employee creating the overtime record
 	CreatedBy gorm.ULID
 }
 
+// MaxOvertimeHours is the most hours of overtime recorded for a day
+const MaxOvertimeHours = 3
+
 func NewOvertime(props *CreateOvertimeProps) *Overtime {
+	compensation := props.Compensation
+	if compensation == "" {
+		compensation = OvertimeCompensationCash
+	}
+
 	return &Overtime{
-		ID:         gorm.ULID(ulid.Make()),
-		Date:       props.Date,
-		TotalHours: props.TotalHours,
-		CreatedAt:  time.Now(),
-		CreatedBy:  props.CreatedBy,
+		ID:           gorm.ULID(ulid.Make()),
+		Date:         props.Date,
+		TotalHours:   props.TotalHours,
+		Compensation: compensation,
+		Status:       OvertimeStatusPending,
+		CreatedAt:    time.Now(),
+		CreatedBy:    props.CreatedBy,
+	}
+}
+
+// NewObservedOvertime returns the approved overtime derived from the hours worked beyond the shift of an attendance.
+// It is not stored and carries the ID of the attendance
+func NewObservedOvertime(attendance *Attendance, hours int) Overtime {
+	return Overtime{
+		ID:           attendance.ID,
+		Date:         attendance.WorkDate,
+		TotalHours:   min(hours, MaxOvertimeHours),
+		Compensation: OvertimeCompensationCash,
+		Status:       OvertimeStatusApproved,
+		CreatedAt:    attendance.CreatedAt,
+		CreatedBy:    attendance.CreatedBy,
 	}
 }
 
@@ -73,13 +161,12 @@ func (o *Overtime) TableName() string {
 
 // IsValidDuration checks if the overtime duration is within valid range (1-3 hours)
 func (o *Overtime) IsValidDuration() bool {
-	return o.TotalHours >= 1 && o.TotalHours <= 3
+	return o.TotalHours >= 1 && o.TotalHours <= MaxOvertimeHours
 }
 
-// IsToday checks if the overtime is for today
-func (o *Overtime) IsToday() bool {
-	return o.Date.Year() == time.Now().Year() &&
-		o.Date.YearDay() == time.Now().YearDay()
+// IsToday checks if the overtime is for today in the given location
+func (o *Overtime) IsToday(location *time.Location) bool {
+	return o.Date.Format(time.DateOnly) == time.Now().In(location).Format(time.DateOnly)
 }
 
 // IsWeekday checks if the overtime is on a weekday
@@ -98,3 +185,74 @@ func (o *Overtime) Update(date time.Time, totalHours int, updatedBy gorm.ULID) {
 	updatedByULID := gorm.ULID(updatedBy)
 	o.UpdatedBy = &updatedByULID
 }
+
+// IsPlanned checks if the overtime was confirmed from a plan
+func (o *Overtime) IsPlanned() bool {
+	return o.PlanID != nil
+}
+
+// PaidHours returns the hours of overtime paid: the hours set by an admin, the lesser of the planned
+// and actual hours for overtime confirmed from a plan, the total hours otherwise
+func (o *Overtime) PaidHours() int {
+	if o.OverrideHours != nil {
+		return *o.OverrideHours
+	}
+	if o.PlannedHours != nil {
+		return min(*o.PlannedHours, o.TotalHours)
+	}
+	return o.TotalHours
+}
+
+// OverridePaidHours sets the hours paid for the overtime, replacing the lesser of the planned and actual hours
+func (o *Overtime) OverridePaidHours(hours int, comment string, overriddenBy gorm.ULID) {
+	now := time.Now()
+	o.OverrideHours = &hours
+	o.OverrideComment = &comment
+	o.OverriddenAt = &now
+	o.OverriddenBy = &overriddenBy
+	o.UpdatedAt = &now
+	o.UpdatedBy = &overriddenBy
+}
+
+// IsBanked checks if the overtime is banked as time off in lieu instead of being paid
+func (o *Overtime) IsBanked() bool {
+	return o.Compensation == OvertimeCompensationToil
+}
+
+// IsPending checks if the overtime is still waiting for approval
+func (o *Overtime) IsPending() bool {
+	return o.Status == OvertimeStatusPending
+}
+
+// IsApproved checks if the overtime was approved to be paid
+func (o *Overtime) IsApproved() bool {
+	return o.Status == OvertimeStatusApproved
+}
+
+// Approve marks the overtime as approved to be paid
+func (o *Overtime) Approve(reviewedBy gorm.ULID, comment *string) {
+	o.review(OvertimeStatusApproved, reviewedBy, comment)
+}
+
+// Reject marks the overtime as rejected
+func (o *Overtime) Reject(reviewedBy gorm.ULID, comment *string) {
+	o.review(OvertimeStatusRejected, reviewedBy, comment)
+}
+
+// Cancel marks the overtime as withdrawn by the employee
+func (o *Overtime) Cancel(cancelledBy gorm.ULID) {
+	now := time.Now()
+	o.Status = OvertimeStatusCancelled
+	o.UpdatedAt = &now
+	o.UpdatedBy = &cancelledBy
+}
+
+func (o *Overtime) review(status OvertimeStatus, reviewedBy gorm.ULID, comment *string) {
+	now := time.Now()
+	o.Status = status
+	o.ReviewComment = comment
+	o.ReviewedAt = &now
+	o.ReviewedBy = &reviewedBy
+	o.UpdatedAt = &now
+	o.UpdatedBy = &reviewedBy
+}
@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"time"
+
+	"payslip-generator-service/pkg/database/gorm"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// OvertimeEligibility represents a change of the overtime entitlement of an employee from a day on
+// swagger:model OvertimeEligibility
+type OvertimeEligibility struct {
+	// Unique identifier for the change
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ID gorm.ULID `json:"id" gorm:"column:id;type:ulid;primaryKey"`
+
+	// ID of the employee
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	EmployeeID gorm.ULID `json:"employee_id" gorm:"column:employee_id;type:ulid;not null"`
+
+	// Whether the employee may claim and is paid overtime from the effective date on
+	// example: false
+	Eligible bool `json:"eligible" gorm:"column:eligible;type:boolean;not null"`
+
+	// First day the entitlement applies to, it lasts until the next change
+	// example: "2024-01-15T00:00:00Z"
+	EffectiveDate time.Time `json:"effective_date" gorm:"column:effective_date;type:date;not null"`
+
+	// Reason given for the change
+	// example: "Promoted to engineering manager"
+	Reason string `json:"reason" gorm:"column:reason;type:text;not null"`
+
+	// Timestamp when the change was recorded
+	// example: "2024-01-10T08:00:00Z"
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP"`
+
+	// ID of the admin who recorded the change
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	CreatedBy gorm.ULID `json:"created_by" gorm:"column:created_by;type:ulid;not null"`
+}
+
+// CreateOvertimeEligibilityProps represents the properties needed to create a new overtime eligibility change
+// swagger:model CreateOvertimeEligibilityProps
+type CreateOvertimeEligibilityProps struct {
+	// ID of the employee
+	EmployeeID gorm.ULID
+	// Whether the employee is entitled to overtime
+	Eligible bool
+	// First day the entitlement applies to
+	EffectiveDate time.Time
+	// Reason given for the change
+	Reason string
+	// ID of the admin recording the change
+	CreatedBy gorm.ULID
+}
+
+func NewOvertimeEligibility(props *CreateOvertimeEligibilityProps) *OvertimeEligibility {
+	return &OvertimeEligibility{
+		ID:            gorm.ULID(ulid.Make()),
+		EmployeeID:    props.EmployeeID,
+		Eligible:      props.Eligible,
+		EffectiveDate: props.EffectiveDate,
+		Reason:        props.Reason,
+		CreatedAt:     time.Now(),
+		CreatedBy:     props.CreatedBy,
+	}
+}
+
+func (e *OvertimeEligibility) TableName() string {
+	return "overtime_eligibility"
+}
+
+// OvertimeEligibilities is the overtime entitlement history of an employee, ordered by effective date
+type OvertimeEligibilities []OvertimeEligibility
+
+// IsEligibleOn checks if the employee is entitled to overtime on the date, an employee is entitled until
+// a change says otherwise
+func (h OvertimeEligibilities) IsEligibleOn(date time.Time) bool {
+	day := date.Format(time.DateOnly)
+
+	eligible := true
+	for _, e := range h {
+		if e.EffectiveDate.Format(time.DateOnly) > day {
+			break
+		}
+		eligible = e.Eligible
+	}
+	return eligible
+}
@@ -0,0 +1,180 @@
+package entity
+
+import (
+	"time"
+
+	"payslip-generator-service/pkg/database/gorm"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// OvertimePlanStatus represents the approval state of an overtime plan
+type OvertimePlanStatus string
+
+const (
+	OvertimePlanStatusPending   OvertimePlanStatus = "pending"
+	OvertimePlanStatusApproved  OvertimePlanStatus = "approved"
+	OvertimePlanStatusRejected  OvertimePlanStatus = "rejected"
+	OvertimePlanStatusCancelled OvertimePlanStatus = "cancelled"
+	// OvertimePlanStatusConfirmed marks a plan whose actual hours were confirmed as an overtime record
+	OvertimePlanStatusConfirmed OvertimePlanStatus = "confirmed"
+)
+
+// OvertimePlan represents overtime requested in advance, confirmed with the actual hours once worked
+// swagger:model OvertimePlan
+type OvertimePlan struct {
+	// Unique identifier for the overtime plan
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ID gorm.ULID `json:"id" gorm:"column:id;type:ulid;primaryKey"`
+
+	// Date the overtime is planned on
+	// example: "2024-01-20T00:00:00Z"
+	Date time.Time `json:"date" gorm:"column:date;type:date;not null"`
+
+	// Planned hours of overtime (1-3 hours maximum)
+	// example: 3
+	PlannedHours int `json:"planned_hours" gorm:"column:planned_hours;type:integer;not null"`
+
+	// Reason for the overtime
+	// example: "Production release on Saturday"
+	Reason string `json:"reason" gorm:"column:reason;type:text;not null"`
+
+	// Approval state of the plan
+	// example: "approved"
+	Status OvertimePlanStatus `json:"status" gorm:"column:status;type:overtime_plan_status;not null;default:pending"`
+
+	// Comment left by the approver
+	// example: "Approved for the release"
+	ReviewComment *string `json:"review_comment" gorm:"column:review_comment;type:text"`
+
+	// Timestamp when the plan was approved or rejected
+	// example: "2024-01-16T08:00:00Z"
+	ReviewedAt *time.Time `json:"reviewed_at" gorm:"column:reviewed_at;type:timestamp with time zone"`
+
+	// ID of the admin or manager who approved or rejected the plan
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ReviewedBy *gorm.ULID `json:"reviewed_by" gorm:"column:reviewed_by;type:ulid"`
+
+	// Timestamp when the plan was created
+	// example: "2024-01-15T08:00:00Z"
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP"`
+
+	// ID of the employee who created the plan
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	CreatedBy gorm.ULID `json:"created_by" gorm:"column:created_by;type:ulid;not null"`
+
+	// Timestamp when the plan was last updated
+	// example: "2024-01-15T08:00:00Z"
+	UpdatedAt *time.Time `json:"updated_at" gorm:"column:updated_at;type:timestamp with time zone"`
+
+	// ID of the employee who last updated the plan
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	UpdatedBy *gorm.ULID `json:"updated_by" gorm:"column:updated_by;type:ulid"`
+}
+
+// CreateOvertimePlanProps represents the properties needed to create a new overtime plan
+// swagger:model CreateOvertimePlanProps
+type CreateOvertimePlanProps struct {
+	// Date the overtime is planned on
+	Date time.Time
+	// Planned hours of overtime
+	PlannedHours int
+	// Reason for the overtime
+	Reason string
+	// ID of the employee creating the plan
+	CreatedBy gorm.ULID
+}
+
+func NewOvertimePlan(props *CreateOvertimePlanProps) *OvertimePlan {
+	return &OvertimePlan{
+		ID:           gorm.ULID(ulid.Make()),
+		Date:         props.Date,
+		PlannedHours: props.PlannedHours,
+		Reason:       props.Reason,
+		Status:       OvertimePlanStatusPending,
+		CreatedAt:    time.Now(),
+		CreatedBy:    props.CreatedBy,
+	}
+}
+
+func (p *OvertimePlan) TableName() string {
+	return "overtime_plan"
+}
+
+// IsValidDuration checks if the planned duration is within valid range (1-3 hours)
+func (p *OvertimePlan) IsValidDuration() bool {
+	return p.PlannedHours >= 1 && p.PlannedHours <= 3
+}
+
+// IsPending checks if the plan is still waiting for approval
+func (p *OvertimePlan) IsPending() bool {
+	return p.Status == OvertimePlanStatusPending
+}
+
+// IsApproved checks if the plan was approved and its actual hours are not confirmed yet
+func (p *OvertimePlan) IsApproved() bool {
+	return p.Status == OvertimePlanStatusApproved
+}
+
+// IsPast checks if the planned date is before today in the given location
+func (p *OvertimePlan) IsPast(location *time.Location) bool {
+	return p.Date.Format(time.DateOnly) < time.Now().In(location).Format(time.DateOnly)
+}
+
+// IsDue checks if the planned date is today or has passed in the given location, the actual hours can then be confirmed
+func (p *OvertimePlan) IsDue(location *time.Location) bool {
+	return p.Date.Format(time.DateOnly) <= time.Now().In(location).Format(time.DateOnly)
+}
+
+// Approve marks the plan as approved
+func (p *OvertimePlan) Approve(reviewedBy gorm.ULID, comment *string) {
+	p.review(OvertimePlanStatusApproved, reviewedBy, comment)
+}
+
+// Reject marks the plan as rejected
+func (p *OvertimePlan) Reject(reviewedBy gorm.ULID, comment *string) {
+	p.review(OvertimePlanStatusRejected, reviewedBy, comment)
+}
+
+// Cancel marks the plan as withdrawn by the employee
+func (p *OvertimePlan) Cancel(cancelledBy gorm.ULID) {
+	p.update(OvertimePlanStatusCancelled, cancelledBy)
+}
+
+// Confirm marks the plan as confirmed and returns the overtime record of the actual hours worked,
+// approved by the approval of the plan
+func (p *OvertimePlan) Confirm(actualHours int, compensation OvertimeCompensation, confirmedBy gorm.ULID) *Overtime {
+	p.update(OvertimePlanStatusConfirmed, confirmedBy)
+
+	overtime := NewOvertime(&CreateOvertimeProps{
+		Date:         p.Date,
+		TotalHours:   actualHours,
+		Compensation: compensation,
+		CreatedBy:    p.CreatedBy,
+	})
+	planID := p.ID
+	plannedHours := p.PlannedHours
+	overtime.PlanID = &planID
+	overtime.PlannedHours = &plannedHours
+	overtime.Status = OvertimeStatusApproved
+	overtime.ReviewComment = p.ReviewComment
+	overtime.ReviewedAt = p.ReviewedAt
+	overtime.ReviewedBy = p.ReviewedBy
+
+	return overtime
+}
+
+func (p *OvertimePlan) review(status OvertimePlanStatus, reviewedBy gorm.ULID, comment *string) {
+	now := time.Now()
+	p.ReviewComment = comment
+	p.ReviewedAt = &now
+	p.ReviewedBy = &reviewedBy
+	p.update(status, reviewedBy)
+}
+
+func (p *OvertimePlan) update(status OvertimePlanStatus, updatedBy gorm.ULID) {
+	now := time.Now()
+	p.Status = status
+	p.UpdatedAt = &now
+	p.UpdatedBy = &updatedBy
+}
@@ -31,6 +31,20 @@ type PayrollPeriod struct {
 	// example: "01HXYZ123456789ABCDEFGHIJK"
 	ProcessedBy *gorm.ULID `json:"processed_by" gorm:"column:processed_by;type:ulid"`
 
+	// Whether late arrival, early leave and excess breaks were deducted when the payroll was processed
+	// example: true
+	AttendanceDeduction bool `json:"attendance_deduction" gorm:"column:attendance_deduction;type:boolean;not null;default:false"`
+
+	// Whether overtime was only paid on attendance days reaching the net working time of the shift when the payroll
+	// was processed
+	// example: true
+	OvertimeNetHours bool `json:"overtime_net_hours" gorm:"column:overtime_net_hours;type:boolean;not null;default:false"`
+
+	// Whether the reimbursements approved since the previous period was processed were paid when the payroll was
+	// processed, the ones approved from the start date until the processing day otherwise
+	// example: true
+	ReimbursementWindow bool `json:"reimbursement_window" gorm:"column:reimbursement_window;type:boolean;not null;default:false"`
+
 	// Timestamp when the payroll period was created
 	// example: "2024-01-01T08:00:00Z"
 	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP"`
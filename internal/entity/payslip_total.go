@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"time"
+
+	"payslip-generator-service/pkg/database/gorm"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// PayslipTotal represents the totals of an employee's payslip, stored when the payroll period is processed so the
+// payslip history does not have to regenerate every payslip
+// swagger:model PayslipTotal
+type PayslipTotal struct {
+	// Unique identifier for the payslip total
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ID gorm.ULID `json:"id" gorm:"column:id;type:ulid;primaryKey"`
+
+	// ID of the processed payroll period
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	PayrollPeriodID gorm.ULID `json:"payroll_period_id" gorm:"column:payroll_period_id;type:ulid;not null"`
+
+	// ID of the employee the payslip belongs to
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	EmployeeID gorm.ULID `json:"employee_id" gorm:"column:employee_id;type:ulid;not null"`
+
+	// Department of the employee when the period was processed
+	// example: "engineering"
+	Department *string `json:"department" gorm:"column:department;size:100"`
+
+	// Base salary of the employee when the period was processed
+	// example: 5000000
+	BasicSalary int `json:"basic_salary" gorm:"column:basic_salary;type:integer;not null"`
+
+	// Salary for the period after deductions
+	// example: 4500000
+	Salary int `json:"salary" gorm:"column:salary;type:integer;not null"`
+
+	// Overtime pay for the period
+	// example: 250000
+	OvertimeAmount int `json:"overtime_amount" gorm:"column:overtime_amount;type:integer;not null"`
+
+	// Reimbursements paid with the period
+	// example: 150000
+	ReimbursementAmount int `json:"reimbursement_amount" gorm:"column:reimbursement_amount;type:integer;not null"`
+
+	// Final take-home pay
+	// example: 4900000
+	TakeHomePay int `json:"take_home_pay" gorm:"column:take_home_pay;type:integer;not null"`
+
+	// JSON encoded payslip issued when the period was processed, empty for totals stored before payslips were kept
+	Payslip *string `json:"-" gorm:"column:payslip;type:jsonb"`
+
+	// Timestamp when the totals were stored
+	// example: "2024-01-31T23:59:59Z"
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP"`
+}
+
+// CreatePayslipTotalProps represents the properties needed to create a new payslip total
+// swagger:model CreatePayslipTotalProps
+type CreatePayslipTotalProps struct {
+	// ID of the processed payroll period
+	PayrollPeriodID gorm.ULID
+	// ID of the employee the payslip belongs to
+	EmployeeID gorm.ULID
+	// Department of the employee when the period is processed
+	Department *string
+	// Base salary of the employee
+	BasicSalary int
+	// Salary for the period after deductions
+	Salary int
+	// Overtime pay for the period
+	OvertimeAmount int
+	// Reimbursements paid with the period
+	ReimbursementAmount int
+	// Final take-home pay
+	TakeHomePay int
+	// JSON encoded payslip
+	Payslip *string
+}
+
+func NewPayslipTotal(props *CreatePayslipTotalProps) *PayslipTotal {
+	return &PayslipTotal{
+		ID:                  gorm.ULID(ulid.Make()),
+		PayrollPeriodID:     props.PayrollPeriodID,
+		EmployeeID:          props.EmployeeID,
+		Department:          props.Department,
+		BasicSalary:         props.BasicSalary,
+		Salary:              props.Salary,
+		OvertimeAmount:      props.OvertimeAmount,
+		ReimbursementAmount: props.ReimbursementAmount,
+		TakeHomePay:         props.TakeHomePay,
+		Payslip:             props.Payslip,
+		CreatedAt:           time.Now(),
+	}
+}
+
+func (p *PayslipTotal) TableName() string {
+	return "payslip_total"
+}
+
+// GetDepartment returns the department of the employee when the period was processed, or an empty string when
+// unassigned
+func (p *PayslipTotal) GetDepartment() string {
+	if p.Department == nil {
+		return ""
+	}
+	return *p.Department
+}
+
+// GrossPay returns the salary for the period plus overtime pay
+func (p *PayslipTotal) GrossPay() int {
+	return p.Salary + p.OvertimeAmount
+}
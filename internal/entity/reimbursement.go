@@ -8,6 +8,24 @@ import (
 	"github.com/oklog/ulid/v2"
 )
 
+// ReimbursementStatus represents the approval state of a reimbursement record
+type ReimbursementStatus string
+
+const (
+	ReimbursementStatusPending  ReimbursementStatus = "pending"
+	ReimbursementStatusApproved ReimbursementStatus = "approved"
+	ReimbursementStatusRejected ReimbursementStatus = "rejected"
+)
+
+// ReimbursementStage represents the approval stage a reimbursement record is at, the employee's manager decides
+// first and finance last
+type ReimbursementStage string
+
+const (
+	ReimbursementStageManager ReimbursementStage = "manager"
+	ReimbursementStageFinance ReimbursementStage = "finance"
+)
+
 // Reimbursement represents an employee's reimbursement record
 // swagger:model Reimbursement
 type Reimbursement struct {
@@ -23,6 +41,38 @@ type Reimbursement struct {
 	// example: "Transportation expenses for client meeting"
 	Description string `json:"description" gorm:"column:description;type:text;not null"`
 
+	// Approval state, only approved reimbursements are paid
+	// example: "pending"
+	Status ReimbursementStatus `json:"status" gorm:"column:status;type:reimbursement_status;not null;default:pending"`
+
+	// Stage waiting for a decision while pending, the stage that decided last otherwise
+	// example: "manager"
+	Stage ReimbursementStage `json:"stage" gorm:"column:stage;type:reimbursement_stage;not null;default:manager"`
+
+	// Amount approved so far, each stage may approve less than the previous one
+	// example: 120000
+	ApprovedAmount *int `json:"approved_amount" gorm:"column:approved_amount;type:integer"`
+
+	// Timestamp when finance approved the reimbursement, it is paid in the payroll period of this day
+	// example: "2024-01-17T08:00:00Z"
+	ApprovedAt *time.Time `json:"approved_at" gorm:"column:approved_at;type:timestamp with time zone"`
+
+	// Reason given for rejecting the reimbursement
+	// example: "Receipt does not match the amount"
+	RejectionReason *string `json:"rejection_reason" gorm:"column:rejection_reason;type:text"`
+
+	// Number of times the reimbursement was submitted, starting at 1 and increased by each resubmission
+	// example: 1
+	Submission int `json:"submission" gorm:"column:submission;type:integer;not null;default:1"`
+
+	// Timestamp of the latest decision
+	// example: "2024-01-16T08:00:00Z"
+	ReviewedAt *time.Time `json:"reviewed_at" gorm:"column:reviewed_at;type:timestamp with time zone"`
+
+	// ID of the manager or admin who made the latest decision
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ReviewedBy *gorm.ULID `json:"reviewed_by" gorm:"column:reviewed_by;type:ulid"`
+
 	// Timestamp when the reimbursement record was created
 	// example: "2024-01-15T08:00:00Z"
 	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP"`
@@ -62,6 +112,9 @@ func NewReimbursement(props *CreateReimbursementProps) *Reimbursement {
 		ID:          gorm.ULID(ulid.Make()),
 		Amount:      props.Amount,
 		Description: props.Description,
+		Status:      ReimbursementStatusPending,
+		Stage:       ReimbursementStageManager,
+		Submission:  1,
 		CreatedAt:   time.Now(),
 		CreatedBy:   gorm.ULID(props.CreatedBy),
 	}
@@ -70,3 +123,73 @@ func NewReimbursement(props *CreateReimbursementProps) *Reimbursement {
 func (r *Reimbursement) TableName() string {
 	return "reimbursement"
 }
+
+// IsPending checks if the reimbursement is still waiting for a decision
+func (r *Reimbursement) IsPending() bool {
+	return r.Status == ReimbursementStatusPending
+}
+
+// IsRejected checks if the reimbursement was rejected
+func (r *Reimbursement) IsRejected() bool {
+	return r.Status == ReimbursementStatusRejected
+}
+
+// ApprovableAmount returns the most the current stage may approve: the amount approved by the previous stage,
+// the amount claimed otherwise
+func (r *Reimbursement) ApprovableAmount() int {
+	if r.ApprovedAmount != nil {
+		return *r.ApprovedAmount
+	}
+	return r.Amount
+}
+
+// PaidAmount returns the amount paid for the reimbursement, nothing until finance approved it
+func (r *Reimbursement) PaidAmount() int {
+	if r.Status != ReimbursementStatusApproved || r.ApprovedAmount == nil {
+		return 0
+	}
+	return *r.ApprovedAmount
+}
+
+// Approve approves amount of the reimbursement at its current stage. A manager approval passes the
+// reimbursement on to finance, a finance approval makes it payable
+func (r *Reimbursement) Approve(amount int, reviewedBy gorm.ULID) {
+	r.review(reviewedBy)
+	r.ApprovedAmount = &amount
+	if r.Stage == ReimbursementStageManager {
+		r.Stage = ReimbursementStageFinance
+		return
+	}
+	r.Status = ReimbursementStatusApproved
+	r.ApprovedAt = r.ReviewedAt
+}
+
+// Reject rejects the reimbursement at its current stage
+func (r *Reimbursement) Reject(reason string, reviewedBy gorm.ULID) {
+	r.review(reviewedBy)
+	r.Status = ReimbursementStatusRejected
+	r.RejectionReason = &reason
+}
+
+// Resubmit submits a rejected reimbursement again with a corrected amount and description, starting over from
+// the manager stage
+func (r *Reimbursement) Resubmit(amount int, description string, resubmittedBy gorm.ULID) {
+	now := time.Now()
+	r.Amount = amount
+	r.Description = description
+	r.Status = ReimbursementStatusPending
+	r.Stage = ReimbursementStageManager
+	r.ApprovedAmount = nil
+	r.RejectionReason = nil
+	r.Submission++
+	r.UpdatedAt = &now
+	r.UpdatedBy = &resubmittedBy
+}
+
+func (r *Reimbursement) review(reviewedBy gorm.ULID) {
+	now := time.Now()
+	r.ReviewedAt = &now
+	r.ReviewedBy = &reviewedBy
+	r.UpdatedAt = &now
+	r.UpdatedBy = &reviewedBy
+}
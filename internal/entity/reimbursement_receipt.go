@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"payslip-generator-service/pkg/database/gorm"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// ReimbursementReceipt represents a receipt image or PDF attached to a reimbursement record
+// swagger:model ReimbursementReceipt
+type ReimbursementReceipt struct {
+	// Unique identifier for the receipt
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ID gorm.ULID `json:"id" gorm:"column:id;type:ulid;primaryKey"`
+
+	// ID of the reimbursement record the receipt is attached to
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ReimbursementID gorm.ULID `json:"reimbursement_id" gorm:"column:reimbursement_id;type:ulid;not null"`
+
+	// Name of the uploaded file
+	// example: "taxi-receipt.jpg"
+	FileName string `json:"file_name" gorm:"column:file_name;type:text;not null"`
+
+	// Content type detected from the file content
+	// example: "image/jpeg"
+	ContentType string `json:"content_type" gorm:"column:content_type;type:text;not null"`
+
+	// Size of the file in bytes
+	// example: 183502
+	Size int `json:"size" gorm:"column:size;type:integer;not null"`
+
+	// Key the file is stored under, not exposed
+	StorageKey string `json:"-" gorm:"column:storage_key;type:text;not null"`
+
+	// Timestamp when the receipt was uploaded
+	// example: "2024-01-15T08:00:00Z"
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP"`
+
+	// ID of the employee who uploaded the receipt
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	CreatedBy gorm.ULID `json:"created_by" gorm:"column:created_by;type:ulid;not null"`
+}
+
+// CreateReimbursementReceiptProps represents the properties needed to create a new reimbursement receipt
+// swagger:model CreateReimbursementReceiptProps
+type CreateReimbursementReceiptProps struct {
+	// ID of the reimbursement record the receipt is attached to
+	ReimbursementID gorm.ULID
+	// Name of the uploaded file
+	FileName string
+	// Content type detected from the file content
+	ContentType string
+	// Size of the file in bytes
+	Size int
+	// ID of the employee uploading the receipt
+	CreatedBy gorm.ULID
+}
+
+// NewReimbursementReceipt creates a receipt stored under a key derived from its IDs, never from the file name
+func NewReimbursementReceipt(props *CreateReimbursementReceiptProps) *ReimbursementReceipt {
+	id := gorm.ULID(ulid.Make())
+	return &ReimbursementReceipt{
+		ID:              id,
+		ReimbursementID: props.ReimbursementID,
+		FileName:        props.FileName,
+		ContentType:     props.ContentType,
+		Size:            props.Size,
+		StorageKey:      fmt.Sprintf("reimbursement/%s/%s", props.ReimbursementID, id),
+		CreatedAt:       time.Now(),
+		CreatedBy:       props.CreatedBy,
+	}
+}
+
+func (r *ReimbursementReceipt) TableName() string {
+	return "reimbursement_receipt"
+}
@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"time"
+
+	"payslip-generator-service/pkg/database/gorm"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// ReimbursementDecision represents the decision made on a reimbursement at one stage
+type ReimbursementDecision string
+
+const (
+	ReimbursementDecisionApproved ReimbursementDecision = "approved"
+	ReimbursementDecisionRejected ReimbursementDecision = "rejected"
+)
+
+// ReimbursementReview represents a decision made on a reimbursement record, keeping the history across stages
+// and resubmissions
+// swagger:model ReimbursementReview
+type ReimbursementReview struct {
+	// Unique identifier for the review
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ID gorm.ULID `json:"id" gorm:"column:id;type:ulid;primaryKey"`
+
+	// ID of the reviewed reimbursement record
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ReimbursementID gorm.ULID `json:"reimbursement_id" gorm:"column:reimbursement_id;type:ulid;not null"`
+
+	// Submission of the reimbursement the decision was made on
+	// example: 1
+	Submission int `json:"submission" gorm:"column:submission;type:integer;not null"`
+
+	// Stage the decision was made at
+	// example: "manager"
+	Stage ReimbursementStage `json:"stage" gorm:"column:stage;type:reimbursement_stage;not null"`
+
+	// Decision made
+	// example: "approved"
+	Decision ReimbursementDecision `json:"decision" gorm:"column:decision;type:reimbursement_decision;not null"`
+
+	// Amount approved, empty for a rejection
+	// example: 120000
+	Amount *int `json:"amount" gorm:"column:amount;type:integer"`
+
+	// Comment left by the reviewer, the reason of a rejection
+	// example: "Only the taxi fare is covered"
+	Comment *string `json:"comment" gorm:"column:comment;type:text"`
+
+	// Timestamp when the decision was made
+	// example: "2024-01-16T08:00:00Z"
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP"`
+
+	// ID of the manager or admin who made the decision
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	CreatedBy gorm.ULID `json:"created_by" gorm:"column:created_by;type:ulid;not null"`
+}
+
+// CreateReimbursementReviewProps represents the properties needed to create a new reimbursement review
+// swagger:model CreateReimbursementReviewProps
+type CreateReimbursementReviewProps struct {
+	// Reviewed reimbursement record, holding its submission and stage before the decision
+	Reimbursement *Reimbursement
+	// Decision made
+	Decision ReimbursementDecision
+	// Amount approved, nil for a rejection
+	Amount *int
+	// Comment left by the reviewer
+	Comment *string
+	// ID of the manager or admin making the decision
+	CreatedBy gorm.ULID
+}
+
+func NewReimbursementReview(props *CreateReimbursementReviewProps) *ReimbursementReview {
+	return &ReimbursementReview{
+		ID:              gorm.ULID(ulid.Make()),
+		ReimbursementID: props.Reimbursement.ID,
+		Submission:      props.Reimbursement.Submission,
+		Stage:           props.Reimbursement.Stage,
+		Decision:        props.Decision,
+		Amount:          props.Amount,
+		Comment:         props.Comment,
+		CreatedAt:       time.Now(),
+		CreatedBy:       props.CreatedBy,
+	}
+}
+
+func (r *ReimbursementReview) TableName() string {
+	return "reimbursement_review"
+}
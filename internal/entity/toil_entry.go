@@ -0,0 +1,220 @@
+package entity
+
+import (
+	"sort"
+	"time"
+
+	"payslip-generator-service/pkg/database/gorm"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// ToilEntryType represents the kind of movement of a time-off-in-lieu balance
+type ToilEntryType string
+
+const (
+	ToilEntryTypeAccrual ToilEntryType = "accrual"
+	ToilEntryTypeUsage   ToilEntryType = "usage"
+	ToilEntryTypeExpiry  ToilEntryType = "expiry"
+)
+
+// ToilEntry represents a movement of the time-off-in-lieu balance of an employee: hours banked from overtime,
+// hours taken as leave, or banked hours lapsing unused
+// swagger:model ToilEntry
+type ToilEntry struct {
+	// Unique identifier for the entry
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ID gorm.ULID `json:"id" gorm:"column:id;type:ulid;primaryKey"`
+
+	// ID of the employee owning the balance
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	EmployeeID gorm.ULID `json:"employee_id" gorm:"column:employee_id;type:ulid;not null"`
+
+	// Kind of movement
+	// example: "accrual"
+	Type ToilEntryType `json:"type" gorm:"column:type;type:toil_entry_type;not null"`
+
+	// Hours added to the balance by an accrual, taken from it by a usage or an expiry
+	// example: 2
+	Hours int `json:"hours" gorm:"column:hours;type:integer;not null"`
+
+	// Day of the banked overtime, of the leave taken, or of the expiry
+	// example: "2024-01-15T00:00:00Z"
+	Date time.Time `json:"date" gorm:"column:date;type:date;not null"`
+
+	// Last day the banked hours of an accrual can be taken, empty when they never expire
+	// example: "2024-04-14T00:00:00Z"
+	ExpiresOn *time.Time `json:"expires_on,omitempty" gorm:"column:expires_on;type:date"`
+
+	// Overtime the hours of an accrual were banked from
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	OvertimeID *gorm.ULID `json:"overtime_id,omitempty" gorm:"column:overtime_id;type:ulid"`
+
+	// Accrual whose hours lapsed, for an expiry
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	AccrualID *gorm.ULID `json:"accrual_id,omitempty" gorm:"column:accrual_id;type:ulid"`
+
+	// Reason given for a usage
+	// example: "Half day off on Friday"
+	Reason *string `json:"reason,omitempty" gorm:"column:reason;type:text"`
+
+	// Timestamp when the entry was recorded
+	// example: "2024-01-16T08:00:00Z"
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP"`
+
+	// ID of the employee who recorded the entry, empty for an expiry
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	CreatedBy *gorm.ULID `json:"created_by" gorm:"column:created_by;type:ulid"`
+}
+
+// CreateToilUsageProps represents the properties needed to record time off in lieu taken by an employee
+// swagger:model CreateToilUsageProps
+type CreateToilUsageProps struct {
+	// ID of the employee taking the time off
+	EmployeeID gorm.ULID
+	// Day the time off is taken
+	Date time.Time
+	// Hours taken
+	Hours int
+	// Reason given for the time off
+	Reason string
+	// ID of the admin recording the time off
+	CreatedBy gorm.ULID
+}
+
+// NewToilAccrual banks the paid hours of an approved overtime, the hours can be taken until expiresOn
+func NewToilAccrual(overtime *Overtime, expiresOn *time.Time, createdBy gorm.ULID) *ToilEntry {
+	overtimeID := overtime.ID
+	return &ToilEntry{
+		ID:         gorm.ULID(ulid.Make()),
+		EmployeeID: overtime.CreatedBy,
+		Type:       ToilEntryTypeAccrual,
+		Hours:      overtime.PaidHours(),
+		Date:       overtime.Date,
+		ExpiresOn:  expiresOn,
+		OvertimeID: &overtimeID,
+		CreatedAt:  time.Now(),
+		CreatedBy:  &createdBy,
+	}
+}
+
+func NewToilUsage(props *CreateToilUsageProps) *ToilEntry {
+	return &ToilEntry{
+		ID:         gorm.ULID(ulid.Make()),
+		EmployeeID: props.EmployeeID,
+		Type:       ToilEntryTypeUsage,
+		Hours:      props.Hours,
+		Date:       props.Date,
+		Reason:     &props.Reason,
+		CreatedAt:  time.Now(),
+		CreatedBy:  &props.CreatedBy,
+	}
+}
+
+// NewToilExpiry lapses the unused hours of an accrual the day after it expires
+func NewToilExpiry(accrual *ToilEntry, hours int) *ToilEntry {
+	accrualID := accrual.ID
+	return &ToilEntry{
+		ID:         gorm.ULID(ulid.Make()),
+		EmployeeID: accrual.EmployeeID,
+		Type:       ToilEntryTypeExpiry,
+		Hours:      hours,
+		Date:       accrual.ExpiresOn.AddDate(0, 0, 1),
+		AccrualID:  &accrualID,
+		CreatedAt:  time.Now(),
+	}
+}
+
+func (e *ToilEntry) TableName() string {
+	return "toil_entry"
+}
+
+// ToilLedger is the time-off-in-lieu history of an employee, in the order the entries were recorded
+type ToilLedger []ToilEntry
+
+// Balance returns the hours banked and not taken or lapsed
+func (l ToilLedger) Balance() int {
+	balance := 0
+	for _, e := range l {
+		if e.Type == ToilEntryTypeAccrual {
+			balance += e.Hours
+		} else {
+			balance -= e.Hours
+		}
+	}
+	return balance
+}
+
+// Remaining returns the hours left of each accrual, keyed by accrual ID. Time off is taken from the accruals
+// expiring first, so the fewest hours lapse
+func (l ToilLedger) Remaining() map[gorm.ULID]int {
+	remaining := make(map[gorm.ULID]int)
+	open := make([]*ToilEntry, 0)
+
+	for i := range l {
+		e := &l[i]
+		switch e.Type {
+		case ToilEntryTypeAccrual:
+			remaining[e.ID] = e.Hours
+			open = append(open, e)
+		case ToilEntryTypeExpiry:
+			if e.AccrualID != nil {
+				remaining[*e.AccrualID] = max(remaining[*e.AccrualID]-e.Hours, 0)
+			}
+		case ToilEntryTypeUsage:
+			sort.SliceStable(open, func(i, j int) bool {
+				return expiresBefore(open[i], open[j])
+			})
+			hours := e.Hours
+			for _, accrual := range open {
+				taken := min(hours, remaining[accrual.ID])
+				remaining[accrual.ID] -= taken
+				if hours -= taken; hours == 0 {
+					break
+				}
+			}
+		}
+	}
+
+	return remaining
+}
+
+// Expire returns the expiry entries lapsing the unused hours of the accruals expired before the date
+func (l ToilLedger) Expire(date time.Time) []ToilEntry {
+	day := date.Format(time.DateOnly)
+	remaining := l.Remaining()
+
+	expiries := make([]ToilEntry, 0)
+	for i := range l {
+		accrual := &l[i]
+		if accrual.Type != ToilEntryTypeAccrual || accrual.ExpiresOn == nil || accrual.ExpiresOn.Format(time.DateOnly) >= day {
+			continue
+		}
+		if hours := remaining[accrual.ID]; hours > 0 {
+			expiries = append(expiries, *NewToilExpiry(accrual, hours))
+		}
+	}
+	return expiries
+}
+
+// FindAccrual returns the accrual banking the overtime, nil when the overtime is not banked
+func (l ToilLedger) FindAccrual(overtimeID gorm.ULID) *ToilEntry {
+	for i := range l {
+		if l[i].Type == ToilEntryTypeAccrual && l[i].OvertimeID != nil && *l[i].OvertimeID == overtimeID {
+			return &l[i]
+		}
+	}
+	return nil
+}
+
+// expiresBefore orders accruals by expiry, the accruals that never expire last
+func expiresBefore(a, b *ToilEntry) bool {
+	switch {
+	case a.ExpiresOn == nil:
+		return false
+	case b.ExpiresOn == nil:
+		return true
+	default:
+		return a.ExpiresOn.Before(*b.ExpiresOn)
+	}
+}
@@ -0,0 +1,209 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"payslip-generator-service/pkg/database/gorm"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const shiftTimeLayout = "15:04"
+
+// WorkDays is a set of weekdays stored as a bitmask, bit n is set when time.Weekday n is a work day
+type WorkDays int16
+
+// NewWorkDays builds the set from lower-cased weekday names, ok is false for an unknown name
+func NewWorkDays(names []string) (days WorkDays, ok bool) {
+	for _, name := range names {
+		found := false
+		for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+			if strings.EqualFold(name, weekday.String()) {
+				days |= 1 << weekday
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, false
+		}
+	}
+	return days, true
+}
+
+// Has checks if the weekday is a work day
+func (d WorkDays) Has(weekday time.Weekday) bool {
+	return d&(1<<weekday) != 0
+}
+
+// Names returns the lower-cased weekday names of the set, starting from Sunday
+func (d WorkDays) Names() []string {
+	names := make([]string, 0, 7)
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if d.Has(weekday) {
+			names = append(names, strings.ToLower(weekday.String()))
+		}
+	}
+	return names
+}
+
+// MarshalJSON writes the set as a list of weekday names
+func (d WorkDays) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Names())
+}
+
+// WorkSchedule represents the work days and shift hours assigned to employees
+// swagger:model WorkSchedule
+type WorkSchedule struct {
+	// Unique identifier for the work schedule, empty for the default schedule
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ID gorm.ULID `json:"id" gorm:"column:id;type:ulid;primaryKey"`
+
+	// Name of the work schedule (unique)
+	// example: "Night shift"
+	Name string `json:"name" gorm:"column:name;size:100;not null;unique"`
+
+	// Days of the week the shift starts on
+	// example: ["monday", "tuesday", "wednesday", "thursday", "friday"]
+	WorkDays WorkDays `json:"work_days" gorm:"column:work_days;type:smallint;not null"`
+
+	// Shift start time (HH:MM)
+	// example: "22:00"
+	ShiftStart string `json:"shift_start" gorm:"column:shift_start;size:5;not null"`
+
+	// Shift end time (HH:MM), earlier than the start time for a cross-midnight shift
+	// example: "06:00"
+	ShiftEnd string `json:"shift_end" gorm:"column:shift_end;size:5;not null"`
+
+	// Timestamp when the work schedule was created
+	// example: "2024-01-15T08:00:00Z"
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP"`
+
+	// ID of the admin who created the work schedule
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	CreatedBy gorm.ULID `json:"created_by" gorm:"column:created_by;type:ulid;not null"`
+
+	// Timestamp when the work schedule was last updated
+	// example: "2024-01-15T08:00:00Z"
+	UpdatedAt *time.Time `json:"updated_at" gorm:"column:updated_at;type:timestamp with time zone"`
+
+	// ID of the admin who last updated the work schedule
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	UpdatedBy *gorm.ULID `json:"updated_by" gorm:"column:updated_by;type:ulid"`
+}
+
+// CreateWorkScheduleProps represents the properties needed to create a new work schedule
+// swagger:model CreateWorkScheduleProps
+type CreateWorkScheduleProps struct {
+	// Name of the work schedule
+	Name string
+	// Days of the week the shift starts on
+	WorkDays WorkDays
+	// Shift start time (HH:MM)
+	ShiftStart string
+	// Shift end time (HH:MM)
+	ShiftEnd string
+	// ID of the admin creating the work schedule
+	CreatedBy gorm.ULID
+}
+
+func NewWorkSchedule(props *CreateWorkScheduleProps) *WorkSchedule {
+	return &WorkSchedule{
+		ID:         gorm.ULID(ulid.Make()),
+		Name:       props.Name,
+		WorkDays:   props.WorkDays,
+		ShiftStart: props.ShiftStart,
+		ShiftEnd:   props.ShiftEnd,
+		CreatedAt:  time.Now(),
+		CreatedBy:  props.CreatedBy,
+	}
+}
+
+// NewDefaultWorkSchedule returns the Monday to Friday day shift followed by employees without a schedule
+func NewDefaultWorkSchedule(shiftEnd string) *WorkSchedule {
+	workDays, _ := NewWorkDays([]string{"monday", "tuesday", "wednesday", "thursday", "friday"})
+	return &WorkSchedule{
+		Name:       "default",
+		WorkDays:   workDays,
+		ShiftStart: "08:00",
+		ShiftEnd:   shiftEnd,
+	}
+}
+
+func (w *WorkSchedule) TableName() string {
+	return "work_schedule"
+}
+
+// Update updates the work schedule
+func (w *WorkSchedule) Update(name string, workDays WorkDays, shiftStart, shiftEnd string, updatedBy gorm.ULID) {
+	now := time.Now()
+	w.Name = name
+	w.WorkDays = workDays
+	w.ShiftStart = shiftStart
+	w.ShiftEnd = shiftEnd
+	w.UpdatedAt = &now
+	w.UpdatedBy = &updatedBy
+}
+
+// IsValidShift checks if the shift times are valid HH:MM times and not equal
+func (w *WorkSchedule) IsValidShift() bool {
+	start, err := time.Parse(shiftTimeLayout, w.ShiftStart)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(shiftTimeLayout, w.ShiftEnd)
+	if err != nil {
+		return false
+	}
+	return !start.Equal(end)
+}
+
+// IsCrossMidnight checks if the shift ends on the day after it starts
+func (w *WorkSchedule) IsCrossMidnight() bool {
+	return w.ShiftEnd < w.ShiftStart
+}
+
+// IsWorkDay checks if a shift starts on the day of the given time
+func (w *WorkSchedule) IsWorkDay(t time.Time) bool {
+	return w.WorkDays.Has(t.Weekday())
+}
+
+// ShiftStartAt returns the start of the shift on the day of the given time
+func (w *WorkSchedule) ShiftStartAt(day time.Time) time.Time {
+	start, err := time.Parse(shiftTimeLayout, w.ShiftStart)
+	if err != nil {
+		start = time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, day.Location())
+}
+
+// ShiftDuration returns the scheduled length of a shift
+func (w *WorkSchedule) ShiftDuration() time.Duration {
+	day := time.Date(2000, 1, 3, 0, 0, 0, 0, time.UTC)
+	return w.ShiftEndAt(day).Sub(w.ShiftStartAt(day))
+}
+
+// ShiftEndAt returns the end of the shift starting on the day of the given time
+func (w *WorkSchedule) ShiftEndAt(day time.Time) time.Time {
+	end, err := time.Parse(shiftTimeLayout, w.ShiftEnd)
+	if err != nil {
+		end = time.Date(0, 1, 1, 23, 59, 59, 0, time.UTC)
+	}
+
+	shiftEnd := time.Date(day.Year(), day.Month(), day.Day(), end.Hour(), end.Minute(), end.Second(), 0, day.Location())
+	if w.IsCrossMidnight() {
+		shiftEnd = shiftEnd.AddDate(0, 0, 1)
+	}
+	return shiftEnd
+}
+
+// LastShiftDay returns the last calendar day a shift starting on the day of the given time may end on
+func (w *WorkSchedule) LastShiftDay(day time.Time) time.Time {
+	lastDay := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	if w.IsCrossMidnight() {
+		lastDay = lastDay.AddDate(0, 0, 1)
+	}
+	return lastDay
+}
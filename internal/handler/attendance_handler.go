@@ -1,9 +1,13 @@
 package handler
 
 import (
+	"context"
+	"math"
+	"payslip-generator-service/internal/entity"
 	"payslip-generator-service/internal/middleware"
 	"payslip-generator-service/internal/model"
 	"payslip-generator-service/internal/usecase"
+	"payslip-generator-service/internal/vm"
 	"payslip-generator-service/pkg/logger"
 	"payslip-generator-service/pkg/validator"
 
@@ -30,7 +34,7 @@ func NewAttendanceHandler(
 
 // Create creates a new attendance record for the authenticated employee
 // @Summary Create attendance record
-// @Description Create a new attendance record with start and end times for the authenticated employee
+// @Description Create a new attendance record with start and end times for the authenticated employee. Breaks taken within the shift may be recorded with it, unpaid breaks are excluded from the worked hours. The coordinates and the caller IP are checked against the configured offices, an attendance outside every office is marked remote or rejected according to the location policy
 // @Tags Attendance
 // @Accept json
 // @Produce json
@@ -47,6 +51,7 @@ func (h *AttendanceHandler) Create(ctx *fiber.Ctx) error {
 		h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
 		return fiber.ErrBadRequest
 	}
+	request.IPAddress = ctx.IP()
 
 	errValidation := h.Validator.ValidateStruct(request)
 	if errValidation != nil {
@@ -71,3 +76,698 @@ func (h *AttendanceHandler) Create(ctx *fiber.Ctx) error {
 		Ok: true,
 	})
 }
+
+// CheckIn opens an attendance session stamped with the server time
+// @Summary Check in
+// @Description Start today's attendance for the authenticated employee at the current server time. A session left open on a previous day is automatically checked out at the configured time and flagged with auto_checked_out. The coordinates and the caller IP are checked against the configured offices, a check-in outside every office is marked remote or rejected according to the location policy
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param request body model.CheckInRequest false "Check-in location"
+// @Router /attendance/check-in [post]
+func (h *AttendanceHandler) CheckIn(ctx *fiber.Ctx) error {
+	method := "AttendanceHandler.CheckIn"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := new(model.CheckInRequest)
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+			return fiber.ErrBadRequest
+		}
+	}
+	request.IPAddress = ctx.IP()
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.CheckIn(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.Attendance]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// CheckOut closes the open attendance session stamped with the server time
+// @Summary Check out
+// @Description End the open attendance session of the authenticated employee at the current server time, ending the break the employee is on
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Router /attendance/check-out [post]
+func (h *AttendanceHandler) CheckOut(ctx *fiber.Ctx) error {
+	method := "AttendanceHandler.CheckOut"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.CheckOut(requestCtx, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.Attendance]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// StartBreak puts the checked-in employee on a break stamped with the server time
+// @Summary Start a break
+// @Description Start a break in the open attendance session of the authenticated employee at the current server time. Breaks are unpaid unless paid is set, unpaid breaks are excluded from the worked hours. A break still in progress ends with the check-out
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param request body model.StartBreakRequest false "Break"
+// @Router /attendance/break/start [post]
+func (h *AttendanceHandler) StartBreak(ctx *fiber.Ctx) error {
+	method := "AttendanceHandler.StartBreak"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := new(model.StartBreakRequest)
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+			return fiber.ErrBadRequest
+		}
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.StartBreak(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.Attendance]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// EndBreak ends the break of the checked-in employee stamped with the server time
+// @Summary End a break
+// @Description End the break the authenticated employee is on at the current server time
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Router /attendance/break/end [post]
+func (h *AttendanceHandler) EndBreak(ctx *fiber.Ctx) error {
+	method := "AttendanceHandler.EndBreak"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.EndBreak(requestCtx, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.Attendance]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// GetSession retrieves the open attendance session of the authenticated employee
+// @Summary Get open attendance session
+// @Description Get the attendance session the authenticated employee is checked in to; data is omitted when checked out
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Router /attendance/session [get]
+func (h *AttendanceHandler) GetSession(ctx *fiber.Ctx) error {
+	method := "AttendanceHandler.GetSession"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.GetOpenSession(requestCtx, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.Attendance]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// List retrieves a paginated list of attendance records
+// @Summary List attendance records
+// @Description Get a paginated list of attendance records, latest first, filtered by start date range and payroll period. Employees only see their own records, admins can filter by employee
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param size query int false "Page size (default: 10)" minimum(1) maximum(100)
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param period_id query string false "Payroll period ID"
+// @Param employee_id query string false "Employee ID (Admin only)"
+// @Router /attendance [get]
+func (h *AttendanceHandler) List(ctx *fiber.Ctx) error {
+	method := "AttendanceHandler.List"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	request := &model.ListAttendanceRequest{
+		Page:       ctx.QueryInt("page", 1),
+		PageSize:   ctx.QueryInt("size", 10),
+		StartDate:  ctx.Query("start_date"),
+		EndDate:    ctx.Query("end_date"),
+		PeriodID:   ctx.Query("period_id"),
+		EmployeeID: ctx.Query("employee_id"),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, total, err := h.UseCase.List(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		PageSize:  request.PageSize,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.PageSize))),
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+	return ctx.JSON(model.WebResponseWithData[[]entity.Attendance]{
+		Ok:     true,
+		Data:   data,
+		Paging: paging,
+	})
+}
+
+// CreateCorrection records attendance on behalf of an employee
+// @Summary Create attendance correction
+// @Description Record attendance for any employee on a past date. The reason is kept in the attendance revision history. Dates in an already processed payroll period are rejected
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param request body model.CreateAttendanceCorrectionRequest true "Attendance correction"
+// @Router /attendance/correction [post]
+func (h *AttendanceHandler) CreateCorrection(ctx *fiber.Ctx) error {
+	method := "AttendanceHandler.CreateCorrection"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := new(model.CreateAttendanceCorrectionRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+		return fiber.ErrBadRequest
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.CreateCorrection(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.Attendance]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// AmendCorrection changes the times of an attendance record
+// @Summary Amend attendance
+// @Description Change the start and end times of an attendance record. The original values and the reason are kept in the attendance revision history. Records in an already processed payroll period are rejected
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Attendance ID"
+// @Param request body model.AmendAttendanceCorrectionRequest true "Corrected times"
+// @Router /attendance/correction/{id} [put]
+func (h *AttendanceHandler) AmendCorrection(ctx *fiber.Ctx) error {
+	method := "AttendanceHandler.AmendCorrection"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := new(model.AmendAttendanceCorrectionRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+		return fiber.ErrBadRequest
+	}
+	request.ID = ctx.Params("id")
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.AmendCorrection(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.Attendance]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// VoidCorrection voids an attendance record
+// @Summary Void attendance
+// @Description Void an attendance record so it is no longer paid. The original values and the reason are kept in the attendance revision history. Records in an already processed payroll period are rejected
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Attendance ID"
+// @Param request body model.VoidAttendanceCorrectionRequest true "Void reason"
+// @Router /attendance/correction/{id}/void [post]
+func (h *AttendanceHandler) VoidCorrection(ctx *fiber.Ctx) error {
+	method := "AttendanceHandler.VoidCorrection"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := new(model.VoidAttendanceCorrectionRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+		return fiber.ErrBadRequest
+	}
+	request.ID = ctx.Params("id")
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.VoidCorrection(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.Attendance]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// SubmitCorrectionRequest asks admins to fix a missed or wrong attendance day
+// @Summary Request attendance correction
+// @Description Submit proposed times and a reason for a missed or wrong attendance day of the authenticated employee. The request stays pending until an admin approves or rejects it
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param request body model.SubmitAttendanceCorrectionRequest true "Correction request"
+// @Router /attendance/correction-request [post]
+func (h *AttendanceHandler) SubmitCorrectionRequest(ctx *fiber.Ctx) error {
+	method := "AttendanceHandler.SubmitCorrectionRequest"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := new(model.SubmitAttendanceCorrectionRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+		return fiber.ErrBadRequest
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.SubmitCorrectionRequest(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.AttendanceCorrectionRequest]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// ListCorrectionRequest retrieves a paginated list of attendance correction requests
+// @Summary List attendance correction requests
+// @Description Get a paginated list of attendance correction requests, latest first. Employees only see their own requests, admins see every request and can filter by employee
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param size query int false "Page size (default: 10)" minimum(1) maximum(100)
+// @Param status query string false "Status (pending, approved, rejected)"
+// @Param employee_id query string false "Employee ID (Admin only)"
+// @Router /attendance/correction-request [get]
+func (h *AttendanceHandler) ListCorrectionRequest(ctx *fiber.Ctx) error {
+	method := "AttendanceHandler.ListCorrectionRequest"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	request := &model.ListAttendanceCorrectionRequest{
+		Page:       ctx.QueryInt("page", 1),
+		PageSize:   ctx.QueryInt("size", 10),
+		Status:     ctx.Query("status"),
+		EmployeeID: ctx.Query("employee_id"),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, total, err := h.UseCase.ListCorrectionRequest(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		PageSize:  request.PageSize,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.PageSize))),
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+	return ctx.JSON(model.WebResponseWithData[[]entity.AttendanceCorrectionRequest]{
+		Ok:     true,
+		Data:   data,
+		Paging: paging,
+	})
+}
+
+// ApproveCorrectionRequest applies a pending attendance correction request
+// @Summary Approve attendance correction request
+// @Description Approve a pending correction request, creating or amending the employee's attendance with the proposed times. The change is kept in the attendance revision history
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Correction request ID"
+// @Param request body model.ReviewAttendanceCorrectionRequest false "Review comment"
+// @Router /attendance/correction-request/{id}/approve [post]
+func (h *AttendanceHandler) ApproveCorrectionRequest(ctx *fiber.Ctx) error {
+	return h.reviewCorrectionRequest(ctx, "AttendanceHandler.ApproveCorrectionRequest", h.UseCase.ApproveCorrectionRequest)
+}
+
+// RejectCorrectionRequest rejects a pending attendance correction request
+// @Summary Reject attendance correction request
+// @Description Reject a pending correction request with a comment for the employee
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Correction request ID"
+// @Param request body model.ReviewAttendanceCorrectionRequest true "Review comment"
+// @Router /attendance/correction-request/{id}/reject [post]
+func (h *AttendanceHandler) RejectCorrectionRequest(ctx *fiber.Ctx) error {
+	return h.reviewCorrectionRequest(ctx, "AttendanceHandler.RejectCorrectionRequest", h.UseCase.RejectCorrectionRequest)
+}
+
+func (h *AttendanceHandler) reviewCorrectionRequest(
+	ctx *fiber.Ctx,
+	method string,
+	review func(context.Context, *model.ReviewAttendanceCorrectionRequest, *model.Auth) (*entity.AttendanceCorrectionRequest, error),
+) error {
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := new(model.ReviewAttendanceCorrectionRequest)
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+			return fiber.ErrBadRequest
+		}
+	}
+	request.ID = ctx.Params("id")
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := review(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.AttendanceCorrectionRequest]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// Import imports a punch log exported by biometric or timeclock terminals
+// @Summary Import attendance punches
+// @Description Import a CSV punch log. Punches are matched to employees by their device user ID and paired into attendance records, which follow the same rules as admin corrections. Runs as a dry run unless dry_run is false, the report lists the attendance that was or would be created and the rejected rows
+// @Tags Attendance
+// @Accept multipart/form-data
+// @Produce json
+// @Security bearer
+// @Param file formData file true "CSV punch log"
+// @Param dry_run formData bool false "Validate without saving (default: true)"
+// @Param has_header formData bool false "First row holds the column names (default: true)"
+// @Param delimiter formData string false "Field delimiter"
+// @Param device_user_id_column formData string false "Device user ID column name or 1-based number"
+// @Param timestamp_column formData string false "Punch time column name or 1-based number"
+// @Param direction_column formData string false "Punch direction column name or 1-based number"
+// @Param timestamp_layout formData string false "Go time layout of the punch time"
+// @Param in_values formData string false "Comma separated check-in direction values"
+// @Param out_values formData string false "Comma separated check-out direction values"
+// @Router /attendance/import [post]
+func (h *AttendanceHandler) Import(ctx *fiber.Ctx) error {
+	method := "AttendanceHandler.Import"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		h.Log.WithContext(ctx).Error("failed parse file: ", err.Error())
+		return fiber.ErrBadRequest
+	}
+
+	request := &model.ImportAttendanceRequest{
+		File:               file,
+		DryRun:             ctx.FormValue("dry_run") != "false",
+		HasHeader:          ctx.FormValue("has_header") != "false",
+		Delimiter:          ctx.FormValue("delimiter"),
+		DeviceUserIDColumn: ctx.FormValue("device_user_id_column"),
+		TimestampColumn:    ctx.FormValue("timestamp_column"),
+		DirectionColumn:    ctx.FormValue("direction_column"),
+		TimestampLayout:    ctx.FormValue("timestamp_layout"),
+		InValues:           ctx.FormValue("in_values"),
+		OutValues:          ctx.FormValue("out_values"),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.Import(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*model.ImportAttendanceResponse]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// GetAnalytics reports attendance figures of the employees and of the company
+// @Summary Get attendance analytics
+// @Description Report the attendance rate, average hours worked, late arrivals, absence streaks and days without check-out per employee and company-wide over a date range, bucketed by day, week or month. Employees are paginated; company-wide figures cover the full filtered set (Admin only)
+// @Tags Attendance
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param start_date query string true "First day of the range (YYYY-MM-DD)"
+// @Param end_date query string true "Last day of the range (YYYY-MM-DD), at most 366 days"
+// @Param bucket query string false "Bucket size (default: week)" Enums(day, week, month)
+// @Param employee_id query string false "Only report on this employee"
+// @Param department query string false "Filter by department"
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param size query int false "Page size (default: 10)" minimum(1) maximum(100)
+// @Router /attendance/analytics [get]
+func (h *AttendanceHandler) GetAnalytics(ctx *fiber.Ctx) error {
+	method := "AttendanceHandler.GetAnalytics"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	request := &model.GetAttendanceAnalyticsRequest{
+		StartDate:  ctx.Query("start_date"),
+		EndDate:    ctx.Query("end_date"),
+		Bucket:     ctx.Query("bucket"),
+		EmployeeID: ctx.Query("employee_id"),
+		Department: ctx.Query("department"),
+		Page:       ctx.QueryInt("page", 1),
+		PageSize:   ctx.QueryInt("size", 10),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, total, err := h.UseCase.GetAnalytics(requestCtx, request)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		PageSize:  request.PageSize,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.PageSize))),
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*vm.AttendanceAnalytics]{
+		Ok:     true,
+		Data:   data,
+		Paging: paging,
+	})
+}
@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"payslip-generator-service/internal/entity"
+	"payslip-generator-service/internal/middleware"
+	"payslip-generator-service/internal/model"
+	"payslip-generator-service/internal/usecase"
+	"payslip-generator-service/pkg/logger"
+	"payslip-generator-service/pkg/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type EmployeeHandler struct {
+	Log       *logger.ContextLogger
+	UseCase   *usecase.EmployeeUseCase
+	Validator *validator.Validator
+}
+
+func NewEmployeeHandler(
+	useCase *usecase.EmployeeUseCase,
+	log *logger.ContextLogger,
+	validator *validator.Validator,
+) *EmployeeHandler {
+	return &EmployeeHandler{
+		Log:       log,
+		UseCase:   useCase,
+		Validator: validator,
+	}
+}
+
+// UpdateTimezone sets the time zone of an employee
+// @Summary Set employee time zone
+// @Description Set the IANA time zone the employee's attendance days, today checks and payroll period inclusion are evaluated in. Omit timezone to restore the configured default
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Employee ID"
+// @Param request body model.UpdateEmployeeTimezoneRequest true "Time zone"
+// @Router /employee/{id}/timezone [put]
+func (h *EmployeeHandler) UpdateTimezone(ctx *fiber.Ctx) error {
+	method := "EmployeeHandler.UpdateTimezone"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	request := new(model.UpdateEmployeeTimezoneRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+		return fiber.ErrBadRequest
+	}
+	request.ID = ctx.Params("id")
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.UpdateTimezone(requestCtx, request)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.Employee]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// UpdateDeviceUser links an employee to a terminal user ID
+// @Summary Set employee device user ID
+// @Description Link the employee to the user ID it is enrolled with on biometric or timeclock terminals, imported punches are matched to employees by it. Omit device_user_id to unlink the employee
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Employee ID"
+// @Param request body model.UpdateEmployeeDeviceUserRequest true "Device user ID"
+// @Router /employee/{id}/device-user [put]
+func (h *EmployeeHandler) UpdateDeviceUser(ctx *fiber.Ctx) error {
+	method := "EmployeeHandler.UpdateDeviceUser"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	request := new(model.UpdateEmployeeDeviceUserRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+		return fiber.ErrBadRequest
+	}
+	request.ID = ctx.Params("id")
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.UpdateDeviceUser(requestCtx, request)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.Employee]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// UpdateManager sets the manager of an employee
+// @Summary Set employee manager
+// @Description Set the manager of the employee, the manager may approve or reject the employee's overtime. Omit manager_id to remove the manager
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Employee ID"
+// @Param request body model.UpdateEmployeeManagerRequest true "Manager"
+// @Router /employee/{id}/manager [put]
+func (h *EmployeeHandler) UpdateManager(ctx *fiber.Ctx) error {
+	method := "EmployeeHandler.UpdateManager"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	request := new(model.UpdateEmployeeManagerRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+		return fiber.ErrBadRequest
+	}
+	request.ID = ctx.Params("id")
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.UpdateManager(requestCtx, request)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.Employee]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// UpdateOvertimeEligibility changes the overtime entitlement of an employee
+// @Summary Change employee overtime eligibility
+// @Description Record whether the employee may claim and is paid overtime from an effective date on, the entitlement lasts until the next change. The effective date must fall after the last processed payroll period
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Employee ID"
+// @Param request body model.UpdateEmployeeOvertimeEligibilityRequest true "Overtime eligibility"
+// @Router /employee/{id}/overtime-eligibility [post]
+func (h *EmployeeHandler) UpdateOvertimeEligibility(ctx *fiber.Ctx) error {
+	method := "EmployeeHandler.UpdateOvertimeEligibility"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	request := new(model.UpdateEmployeeOvertimeEligibilityRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+		return fiber.ErrBadRequest
+	}
+	request.ID = ctx.Params("id")
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.UpdateOvertimeEligibility(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.OvertimeEligibility]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// ListOvertimeEligibility lists the overtime entitlement history of an employee
+// @Summary List employee overtime eligibility
+// @Description Get the changes of the employee's overtime entitlement, oldest first. An employee without a change is entitled to overtime
+// @Tags Employee
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Employee ID"
+// @Router /employee/{id}/overtime-eligibility [get]
+func (h *EmployeeHandler) ListOvertimeEligibility(ctx *fiber.Ctx) error {
+	method := "EmployeeHandler.ListOvertimeEligibility"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	request := &model.ListEmployeeOvertimeEligibilityRequest{
+		ID: ctx.Params("id"),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.ListOvertimeEligibility(requestCtx, request)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[entity.OvertimeEligibilities]{
+		Ok:   true,
+		Data: data,
+	})
+}
@@ -1,9 +1,14 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"math"
+	"payslip-generator-service/internal/entity"
 	"payslip-generator-service/internal/middleware"
 	"payslip-generator-service/internal/model"
 	"payslip-generator-service/internal/usecase"
+	"payslip-generator-service/internal/vm"
 	"payslip-generator-service/pkg/logger"
 	"payslip-generator-service/pkg/validator"
 
@@ -30,7 +35,7 @@ func NewOvertimeHandler(
 
 // Create creates a new overtime record for the authenticated employee
 // @Summary Create overtime record
-// @Description Create a new overtime record with date and total hours for the authenticated employee
+// @Description Create a new overtime record with date and total hours for the authenticated employee, paid in cash or banked as time off in lieu
 // @Tags Overtime
 // @Accept json
 // @Produce json
@@ -62,7 +67,7 @@ func (h *OvertimeHandler) Create(ctx *fiber.Ctx) error {
 	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
 			Ok:     false,
-			Errors: err.Error(),
+			Errors: overtimeError(err),
 		})
 	}
 
@@ -72,3 +77,719 @@ func (h *OvertimeHandler) Create(ctx *fiber.Ctx) error {
 		Ok: true,
 	})
 }
+
+// overtimeError returns the response errors of a failed overtime request: the cap, usage and hours left when
+// a cap is exceeded, the error code otherwise
+func overtimeError(err error) any {
+	var capErr *vm.OvertimeCapError
+	if errors.As(err, &capErr) {
+		return capErr
+	}
+	return err.Error()
+}
+
+// GetUsage retrieves the overtime usage of the authenticated employee against the caps
+// @Summary Get overtime usage
+// @Description Get the hours of pending and approved overtime and overtime plans of the authenticated employee against the daily, weekly and monthly caps of a day, with the hours left under each cap
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param date query string false "Day to report the usage for (YYYY-MM-DD), default today"
+// @Router /overtime/usage [get]
+func (h *OvertimeHandler) GetUsage(ctx *fiber.Ctx) error {
+	method := "OvertimeHandler.GetUsage"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	request := &model.GetOvertimeUsageRequest{
+		Date: ctx.Query("date"),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.GetUsage(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*vm.OvertimeUsage]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// List retrieves a paginated list of overtime records
+// @Summary List overtime records
+// @Description Get a paginated list of overtime records, latest first, filtered by overtime date range, payroll period and approval status. Employees only see their own records, admins can filter by employee
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param size query int false "Page size (default: 10)" minimum(1) maximum(100)
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param period_id query string false "Payroll period ID"
+// @Param employee_id query string false "Employee ID (Admin only)"
+// @Param status query string false "Approval status (pending, approved, rejected, cancelled)"
+// @Router /overtime [get]
+func (h *OvertimeHandler) List(ctx *fiber.Ctx) error {
+	method := "OvertimeHandler.List"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	request := &model.ListOvertimeRequest{
+		Page:       ctx.QueryInt("page", 1),
+		PageSize:   ctx.QueryInt("size", 10),
+		StartDate:  ctx.Query("start_date"),
+		EndDate:    ctx.Query("end_date"),
+		PeriodID:   ctx.Query("period_id"),
+		EmployeeID: ctx.Query("employee_id"),
+		Status:     ctx.Query("status"),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, total, err := h.UseCase.List(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		PageSize:  request.PageSize,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.PageSize))),
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+	return ctx.JSON(model.WebResponseWithData[[]entity.Overtime]{
+		Ok:     true,
+		Data:   data,
+		Paging: paging,
+	})
+}
+
+// ListForApproval retrieves the pending overtime waiting for the caller's decision
+// @Summary List overtime waiting for approval
+// @Description Get a paginated list of pending overtime records, oldest first, that the caller may approve or reject: every pending overtime for admins, the overtime of their direct reports for managers
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param size query int false "Page size (default: 10)" minimum(1) maximum(100)
+// @Router /overtime/approval [get]
+func (h *OvertimeHandler) ListForApproval(ctx *fiber.Ctx) error {
+	method := "OvertimeHandler.ListForApproval"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	request := &model.ListOvertimeApprovalRequest{
+		Page:     ctx.QueryInt("page", 1),
+		PageSize: ctx.QueryInt("size", 10),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, total, err := h.UseCase.ListForApproval(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		PageSize:  request.PageSize,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.PageSize))),
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+	return ctx.JSON(model.WebResponseWithData[[]entity.Overtime]{
+		Ok:     true,
+		Data:   data,
+		Paging: paging,
+	})
+}
+
+// Approve approves a pending overtime record
+// @Summary Approve overtime
+// @Description Approve a pending overtime record so it is paid in the payslip. Allowed for admins and the manager of the employee, while the payroll period of the overtime is not processed
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Overtime ID"
+// @Param request body model.ReviewOvertimeRequest false "Review comment"
+// @Router /overtime/{id}/approve [post]
+func (h *OvertimeHandler) Approve(ctx *fiber.Ctx) error {
+	return reviewOvertime(h, ctx, "OvertimeHandler.Approve", h.UseCase.Approve)
+}
+
+// Reject rejects a pending overtime record
+// @Summary Reject overtime
+// @Description Reject a pending overtime record with a comment for the employee. Allowed for admins and the manager of the employee, while the payroll period of the overtime is not processed
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Overtime ID"
+// @Param request body model.ReviewOvertimeRequest true "Review comment"
+// @Router /overtime/{id}/reject [post]
+func (h *OvertimeHandler) Reject(ctx *fiber.Ctx) error {
+	return reviewOvertime(h, ctx, "OvertimeHandler.Reject", h.UseCase.Reject)
+}
+
+// reviewOvertime handles approving or rejecting an overtime or overtime plan
+func reviewOvertime[T any](
+	h *OvertimeHandler,
+	ctx *fiber.Ctx,
+	method string,
+	review func(context.Context, *model.ReviewOvertimeRequest, *model.Auth) (T, error),
+) error {
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := new(model.ReviewOvertimeRequest)
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+			return fiber.ErrBadRequest
+		}
+	}
+	request.ID = ctx.Params("id")
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := review(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[T]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// Cancel withdraws a pending overtime record of the authenticated employee
+// @Summary Cancel overtime
+// @Description Withdraw a pending overtime record of the authenticated employee, the day can then be submitted again
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Overtime ID"
+// @Router /overtime/{id}/cancel [post]
+func (h *OvertimeHandler) Cancel(ctx *fiber.Ctx) error {
+	method := "OvertimeHandler.Cancel"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := &model.CancelOvertimeRequest{
+		ID: ctx.Params("id"),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.Cancel(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.Overtime]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// OverridePaidHours overrides the paid hours of overtime confirmed from a plan
+// @Summary Override paid overtime hours
+// @Description Set the hours paid for overtime confirmed from a plan, replacing the lesser of the planned and actual hours. The paid hours cannot exceed the actual hours and the payroll period of the overtime must not be processed (Admin only)
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Overtime ID"
+// @Param request body model.OverrideOvertimeHoursRequest true "Paid hours and reason"
+// @Router /overtime/{id}/paid-hours [put]
+func (h *OvertimeHandler) OverridePaidHours(ctx *fiber.Ctx) error {
+	method := "OvertimeHandler.OverridePaidHours"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := new(model.OverrideOvertimeHoursRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+		return fiber.ErrBadRequest
+	}
+	request.ID = ctx.Params("id")
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.OverridePaidHours(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.Overtime]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// Reconcile compares the claimed and observed overtime of a payroll period
+// @Summary Reconcile overtime
+// @Description Compare the pending and approved overtime claimed by each employee in a payroll period with the whole hours worked beyond the shift, day by day. Days claiming more hours than were observed are flagged (Admin only)
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param period_id query string true "Payroll period ID"
+// @Param employee_id query string false "Only reconcile this employee"
+// @Param department query string false "Filter by department"
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param size query int false "Page size (default: 10)" minimum(1) maximum(100)
+// @Router /overtime/reconciliation [get]
+func (h *OvertimeHandler) Reconcile(ctx *fiber.Ctx) error {
+	method := "OvertimeHandler.Reconcile"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	request := &model.ReconcileOvertimeRequest{
+		PeriodID:   ctx.Query("period_id"),
+		EmployeeID: ctx.Query("employee_id"),
+		Department: ctx.Query("department"),
+		Page:       ctx.QueryInt("page", 1),
+		PageSize:   ctx.QueryInt("size", 10),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, total, err := h.UseCase.ReconcilePeriod(requestCtx, request)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		PageSize:  request.PageSize,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.PageSize))),
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*vm.OvertimeReconciliation]{
+		Ok:     true,
+		Data:   data,
+		Paging: paging,
+	})
+}
+
+// CreatePlan requests overtime in advance for the authenticated employee
+// @Summary Create overtime plan
+// @Description Request overtime in advance for today or a later day, with the planned hours and a reason. The plan is approved by an admin or the manager of the employee, then confirmed with the actual hours once worked
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param request body model.CreateOvertimePlanRequest true "Overtime plan details"
+// @Router /overtime/plan [post]
+func (h *OvertimeHandler) CreatePlan(ctx *fiber.Ctx) error {
+	method := "OvertimeHandler.CreatePlan"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := new(model.CreateOvertimePlanRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+		return fiber.ErrBadRequest
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.CreatePlan(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: overtimeError(err),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.OvertimePlan]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// ListPlans retrieves a paginated list of overtime plans
+// @Summary List overtime plans
+// @Description Get a paginated list of overtime plans, latest first, filtered by planned date range and status. Employees see their own plans and the plans of their direct reports, admins see every plan and can filter by employee
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param size query int false "Page size (default: 10)" minimum(1) maximum(100)
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param employee_id query string false "Employee ID (Admin only)"
+// @Param status query string false "Status (pending, approved, rejected, cancelled, confirmed)"
+// @Router /overtime/plan [get]
+func (h *OvertimeHandler) ListPlans(ctx *fiber.Ctx) error {
+	method := "OvertimeHandler.ListPlans"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	request := &model.ListOvertimePlanRequest{
+		Page:       ctx.QueryInt("page", 1),
+		PageSize:   ctx.QueryInt("size", 10),
+		StartDate:  ctx.Query("start_date"),
+		EndDate:    ctx.Query("end_date"),
+		EmployeeID: ctx.Query("employee_id"),
+		Status:     ctx.Query("status"),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, total, err := h.UseCase.ListPlans(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		PageSize:  request.PageSize,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.PageSize))),
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+	return ctx.JSON(model.WebResponseWithData[[]entity.OvertimePlan]{
+		Ok:     true,
+		Data:   data,
+		Paging: paging,
+	})
+}
+
+// ApprovePlan approves a pending overtime plan
+// @Summary Approve overtime plan
+// @Description Approve a pending overtime plan so the employee can confirm its actual hours once worked. Allowed for admins and the manager of the employee
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Overtime plan ID"
+// @Param request body model.ReviewOvertimeRequest false "Review comment"
+// @Router /overtime/plan/{id}/approve [post]
+func (h *OvertimeHandler) ApprovePlan(ctx *fiber.Ctx) error {
+	return reviewOvertime(h, ctx, "OvertimeHandler.ApprovePlan", h.UseCase.ApprovePlan)
+}
+
+// RejectPlan rejects a pending overtime plan
+// @Summary Reject overtime plan
+// @Description Reject a pending overtime plan with a comment for the employee. Allowed for admins and the manager of the employee
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Overtime plan ID"
+// @Param request body model.ReviewOvertimeRequest true "Review comment"
+// @Router /overtime/plan/{id}/reject [post]
+func (h *OvertimeHandler) RejectPlan(ctx *fiber.Ctx) error {
+	return reviewOvertime(h, ctx, "OvertimeHandler.RejectPlan", h.UseCase.RejectPlan)
+}
+
+// CancelPlan withdraws a pending or approved overtime plan of the authenticated employee
+// @Summary Cancel overtime plan
+// @Description Withdraw a pending or approved overtime plan of the authenticated employee, the day can then be planned again
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Overtime plan ID"
+// @Router /overtime/plan/{id}/cancel [post]
+func (h *OvertimeHandler) CancelPlan(ctx *fiber.Ctx) error {
+	method := "OvertimeHandler.CancelPlan"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := &model.CancelOvertimeRequest{
+		ID: ctx.Params("id"),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.CancelPlan(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.OvertimePlan]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// ConfirmPlan confirms the actual hours of an approved overtime plan
+// @Summary Confirm overtime plan
+// @Description Record the actual hours of an approved overtime plan on or after its day, with attendance on that day. The overtime is recorded as approved and paid, or banked as time off in lieu, for the lesser of the planned and actual hours unless an admin overrides it
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Overtime plan ID"
+// @Param request body model.ConfirmOvertimePlanRequest true "Actual hours"
+// @Router /overtime/plan/{id}/confirm [post]
+func (h *OvertimeHandler) ConfirmPlan(ctx *fiber.Ctx) error {
+	method := "OvertimeHandler.ConfirmPlan"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := new(model.ConfirmOvertimePlanRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+		return fiber.ErrBadRequest
+	}
+	request.ID = ctx.Params("id")
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.ConfirmPlan(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: overtimeError(err),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.Overtime]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// GetToilBalance retrieves a time-off-in-lieu balance
+// @Summary Get time-off-in-lieu balance
+// @Description Get the hours of overtime banked as time off in lieu and not taken or lapsed, the hours about to lapse and the ledger of accruals, usage and expiries. Employees get their own balance, admins may pass employee_id
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param employee_id query string false "Employee ID (admin only)"
+// @Router /overtime/toil [get]
+func (h *OvertimeHandler) GetToilBalance(ctx *fiber.Ctx) error {
+	method := "OvertimeHandler.GetToilBalance"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	request := &model.GetToilBalanceRequest{
+		EmployeeID: ctx.Query("employee_id"),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.GetToilBalance(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*vm.ToilBalance]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// RecordToilUsage records time off in lieu taken by an employee
+// @Summary Record time-off-in-lieu usage
+// @Description Take hours of time off in lieu from the balance of an employee, from the banked hours expiring first. The balance must cover the hours (Admin only)
+// @Tags Overtime
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param request body model.RecordToilUsageRequest true "Time off taken"
+// @Router /overtime/toil/usage [post]
+func (h *OvertimeHandler) RecordToilUsage(ctx *fiber.Ctx) error {
+	method := "OvertimeHandler.RecordToilUsage"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := new(model.RecordToilUsageRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+		return fiber.ErrBadRequest
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.RecordToilUsage(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.ToilEntry]{
+		Ok:   true,
+		Data: data,
+	})
+}
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"bytes"
+	"fmt"
 	"math"
 	"payslip-generator-service/internal/entity"
 	"payslip-generator-service/internal/middleware"
@@ -206,14 +208,196 @@ func (h *PayrollHandler) GetPayslip(ctx *fiber.Ctx) error {
 	})
 }
 
+// ListPayslip retrieves the payslip history of the authenticated employee
+// @Summary List payslips
+// @Description Get a paginated list of payslip summaries of every processed period for the authenticated employee, latest first (Employee only)
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param size query int false "Page size (default: 10)" minimum(1) maximum(100)
+// @Router /payroll/payslips [get]
+func (h *PayrollHandler) ListPayslip(ctx *fiber.Ctx) error {
+	method := "PayrollHandler.ListPayslip"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	request := &model.ListPayslipRequest{
+		Page:     ctx.QueryInt("page", 1),
+		PageSize: ctx.QueryInt("size", 10),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, total, err := h.UseCase.ListPayslip(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		PageSize:  request.PageSize,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.PageSize))),
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+	return ctx.JSON(model.WebResponseWithData[[]vm.PayslipSummary]{
+		Ok:     true,
+		Data:   data,
+		Paging: paging,
+	})
+}
+
+// GetPayslipPDF downloads the payslip of the authenticated employee as PDF
+// @Summary Download payslip PDF
+// @Description Download the payslip of the authenticated employee in a specific period as a PDF document (Employee only)
+// @Tags Payroll
+// @Produce application/pdf
+// @Security bearer
+// @Param period_id query string true "Payroll period ID" example("01HXYZ123456789ABCDEFGHIJK")
+// @Router /payroll/payslip/pdf [get]
+func (h *PayrollHandler) GetPayslipPDF(ctx *fiber.Ctx) error {
+	method := "PayrollHandler.GetPayslipPDF"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	request := &model.GetPayslipRequest{
+		PeriodID: ctx.Query("period_id"),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.GetPayslipPDF(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	ctx.Attachment(fmt.Sprintf("payslip-%s.pdf", request.PeriodID))
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	return ctx.Send(data)
+}
+
+// ExplainPayslip retrieves the calculation trace of the authenticated employee's payslip
+// @Summary Explain payslip
+// @Description Get how each payslip figure was derived for the authenticated employee in a specific period, including excluded records and the reason they were excluded (Employee only)
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param period_id query string true "Payroll period ID" example("01HXYZ123456789ABCDEFGHIJK")
+// @Router /payroll/payslip/explain [get]
+func (h *PayrollHandler) ExplainPayslip(ctx *fiber.Ctx) error {
+	method := "PayrollHandler.ExplainPayslip"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	request := &model.GetPayslipRequest{
+		PeriodID: ctx.Query("period_id"),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.ExplainPayslip(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*vm.PayslipTrace]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// GetPayslipEstimate retrieves the provisional payslip of the current period for the authenticated employee
+// @Summary Get payslip estimate
+// @Description Get estimated earnings to date for the current, not yet processed period, with a month-end projection assuming full attendance for the remaining working days (Employee only)
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Router /payroll/payslip/estimate [get]
+func (h *PayrollHandler) GetPayslipEstimate(ctx *fiber.Ctx) error {
+	method := "PayrollHandler.GetPayslipEstimate"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.GetPayslipEstimate(requestCtx, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*vm.PayslipEstimate]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
 // GetPayslipReport retrieves payslip report for all employees
 // @Summary Get payslip report
-// @Description Get comprehensive payslip report for all employees in a specific period (Admin only)
+// @Description Get a paginated, filterable and sortable payslip report for all employees in a specific period; grand totals cover the full filtered set (Admin only)
 // @Tags Payroll
 // @Accept json
 // @Produce json
 // @Security bearer
 // @Param period_id query string true "Payroll period ID" example("01HXYZ123456789ABCDEFGHIJK")
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param size query int false "Page size (default: 10)" minimum(1) maximum(100)
+// @Param search query string false "Search by username"
+// @Param department query string false "Filter by department"
+// @Param min_take_home_pay query int false "Minimum take-home pay (inclusive)"
+// @Param max_take_home_pay query int false "Maximum take-home pay (inclusive)"
+// @Param sort query string false "Sort column (default: username)" Enums(username, basic_salary, salary, take_home_pay)
+// @Param order query string false "Sort direction (default: asc)" Enums(asc, desc)
 // @Router /payroll/payslip/report [get]
 func (h *PayrollHandler) GetPayslipReport(ctx *fiber.Ctx) error {
 	method := "PayrollHandler.GetPayslipReport"
@@ -221,8 +405,22 @@ func (h *PayrollHandler) GetPayslipReport(ctx *fiber.Ctx) error {
 
 	auth := middleware.GetAuth(ctx)
 
-	request := &model.GetPayslipRequest{
-		PeriodID: ctx.Query("period_id"),
+	request := &model.GetPayslipReportRequest{
+		PeriodID:   ctx.Query("period_id"),
+		Page:       ctx.QueryInt("page", 1),
+		PageSize:   ctx.QueryInt("size", 10),
+		Search:     ctx.Query("search"),
+		Department: ctx.Query("department"),
+		SortBy:     ctx.Query("sort"),
+		SortOrder:  ctx.Query("order"),
+	}
+	if ctx.Query("min_take_home_pay") != "" {
+		minTakeHomePay := ctx.QueryInt("min_take_home_pay")
+		request.MinTakeHomePay = &minTakeHomePay
+	}
+	if ctx.Query("max_take_home_pay") != "" {
+		maxTakeHomePay := ctx.QueryInt("max_take_home_pay")
+		request.MaxTakeHomePay = &maxTakeHomePay
 	}
 
 	errValidation := h.Validator.ValidateStruct(request)
@@ -235,7 +433,7 @@ func (h *PayrollHandler) GetPayslipReport(ctx *fiber.Ctx) error {
 
 	// Create context with request_id
 	requestCtx := ctx.UserContext()
-	data, err := h.UseCase.GetPayslipReport(requestCtx, request, auth)
+	data, total, err := h.UseCase.GetPayslipReport(requestCtx, request, auth)
 	if err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
 			Ok:     false,
@@ -243,9 +441,76 @@ func (h *PayrollHandler) GetPayslipReport(ctx *fiber.Ctx) error {
 		})
 	}
 
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		PageSize:  request.PageSize,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.PageSize))),
+	}
+
 	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
 
 	return ctx.JSON(model.WebResponse[*vm.PayslipReport]{
+		Ok:     true,
+		Data:   data,
+		Paging: paging,
+	})
+}
+
+// GetJournal exports the general ledger journal for a processed payroll period
+// @Summary Export payroll journal
+// @Description Get balanced double-entry journal lines for a processed payroll period as JSON or CSV (Admin only)
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Security bearer
+// @Param period_id query string true "Payroll period ID" example("01HXYZ123456789ABCDEFGHIJK")
+// @Param format query string false "Export format (default: json)" Enums(json, csv)
+// @Router /payroll/journal [get]
+func (h *PayrollHandler) GetJournal(ctx *fiber.Ctx) error {
+	method := "PayrollHandler.GetJournal"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	request := &model.GetJournalRequest{
+		PeriodID: ctx.Query("period_id"),
+		Format:   ctx.Query("format", "json"),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.GetJournal(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	if request.Format == "csv" {
+		var buf bytes.Buffer
+		if err := data.WriteCSV(&buf); err != nil {
+			return err
+		}
+
+		ctx.Set(fiber.HeaderContentType, "text/csv")
+		ctx.Attachment(fmt.Sprintf("journal-%s.csv", request.PeriodID))
+		return ctx.Send(buf.Bytes())
+	}
+
+	return ctx.JSON(model.WebResponse[*vm.Journal]{
 		Ok:   true,
 		Data: data,
 	})
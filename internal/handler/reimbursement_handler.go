@@ -1,6 +1,10 @@
 package handler
 
 import (
+	"context"
+	"math"
+	"mime/multipart"
+	"payslip-generator-service/internal/entity"
 	"payslip-generator-service/internal/middleware"
 	"payslip-generator-service/internal/model"
 	"payslip-generator-service/internal/usecase"
@@ -30,12 +34,13 @@ func NewReimbursementHandler(
 
 // Create creates a new reimbursement record for the authenticated employee
 // @Summary Create reimbursement record
-// @Description Create a new reimbursement record with amount and description for the authenticated employee
+// @Description Create a new reimbursement record with amount and description for the authenticated employee. Sent as a multipart form, receipt images or PDFs can be attached as receipts files
 // @Tags Reimbursement
-// @Accept json
+// @Accept json,mpfd
 // @Produce json
 // @Security bearer
 // @Param request body model.CreateReimbursementRequest true "Reimbursement details"
+// @Param receipts formData file false "Receipt image or PDF, repeatable"
 // @Router /reimbursement [post]
 func (h *ReimbursementHandler) Create(ctx *fiber.Ctx) error {
 	method := "ReimbursementHandler.Create"
@@ -46,6 +51,7 @@ func (h *ReimbursementHandler) Create(ctx *fiber.Ctx) error {
 	if err := ctx.BodyParser(request); err != nil {
 		return fiber.ErrBadRequest
 	}
+	request.Receipts = receiptFiles(ctx)
 
 	errValidation := h.Validator.ValidateStruct(request)
 	if errValidation != nil {
@@ -70,3 +76,383 @@ func (h *ReimbursementHandler) Create(ctx *fiber.Ctx) error {
 		Ok: true,
 	})
 }
+
+// List retrieves a paginated list of reimbursement records
+// @Summary List reimbursement records
+// @Description Get a paginated list of reimbursement records, latest first, filtered by submission date range and payroll period. Employees only see their own records, admins can filter by employee
+// @Tags Reimbursement
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param size query int false "Page size (default: 10)" minimum(1) maximum(100)
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param period_id query string false "Payroll period ID"
+// @Param employee_id query string false "Employee ID (Admin only)"
+// @Router /reimbursement [get]
+func (h *ReimbursementHandler) List(ctx *fiber.Ctx) error {
+	method := "ReimbursementHandler.List"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	request := &model.ListReimbursementRequest{
+		Page:       ctx.QueryInt("page", 1),
+		PageSize:   ctx.QueryInt("size", 10),
+		StartDate:  ctx.Query("start_date"),
+		EndDate:    ctx.Query("end_date"),
+		PeriodID:   ctx.Query("period_id"),
+		EmployeeID: ctx.Query("employee_id"),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, total, err := h.UseCase.List(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		PageSize:  request.PageSize,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.PageSize))),
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+	return ctx.JSON(model.WebResponseWithData[[]entity.Reimbursement]{
+		Ok:     true,
+		Data:   data,
+		Paging: paging,
+	})
+}
+
+// ListForApproval retrieves the pending reimbursements waiting for the caller's decision
+// @Summary List reimbursements waiting for approval
+// @Description Get a paginated list of pending reimbursement records, oldest first, that the caller may approve or reject: every pending reimbursement for admins, the reimbursements of their direct reports at the manager stage for managers
+// @Tags Reimbursement
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param page query int false "Page number (default: 1)" minimum(1)
+// @Param size query int false "Page size (default: 10)" minimum(1) maximum(100)
+// @Router /reimbursement/approval [get]
+func (h *ReimbursementHandler) ListForApproval(ctx *fiber.Ctx) error {
+	method := "ReimbursementHandler.ListForApproval"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+
+	request := &model.ListReimbursementApprovalRequest{
+		Page:     ctx.QueryInt("page", 1),
+		PageSize: ctx.QueryInt("size", 10),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, total, err := h.UseCase.ListForApproval(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	paging := &model.PageMetadata{
+		Page:      request.Page,
+		PageSize:  request.PageSize,
+		TotalItem: total,
+		TotalPage: int64(math.Ceil(float64(total) / float64(request.PageSize))),
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+	return ctx.JSON(model.WebResponseWithData[[]entity.Reimbursement]{
+		Ok:     true,
+		Data:   data,
+		Paging: paging,
+	})
+}
+
+// Approve approves a pending reimbursement at its current stage
+// @Summary Approve reimbursement
+// @Description Approve a pending reimbursement record in full or in part. The manager stage is decided by admins and the manager of the employee and passes the reimbursement on to finance; the finance stage is decided by admins and makes the approved amount payable in the payslip
+// @Tags Reimbursement
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Reimbursement ID"
+// @Param request body model.ReviewReimbursementRequest false "Approved amount and comment"
+// @Router /reimbursement/{id}/approve [post]
+func (h *ReimbursementHandler) Approve(ctx *fiber.Ctx) error {
+	return reviewReimbursement(h, ctx, "ReimbursementHandler.Approve", h.UseCase.Approve)
+}
+
+// Reject rejects a pending reimbursement at its current stage
+// @Summary Reject reimbursement
+// @Description Reject a pending reimbursement record with a reason for the employee, who may correct and resubmit it. Allowed for the approvers of the current stage
+// @Tags Reimbursement
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Reimbursement ID"
+// @Param request body model.ReviewReimbursementRequest true "Rejection reason"
+// @Router /reimbursement/{id}/reject [post]
+func (h *ReimbursementHandler) Reject(ctx *fiber.Ctx) error {
+	return reviewReimbursement(h, ctx, "ReimbursementHandler.Reject", h.UseCase.Reject)
+}
+
+// reviewReimbursement handles approving or rejecting a reimbursement
+func reviewReimbursement(
+	h *ReimbursementHandler,
+	ctx *fiber.Ctx,
+	method string,
+	review func(context.Context, *model.ReviewReimbursementRequest, *model.Auth) (*entity.Reimbursement, error),
+) error {
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := new(model.ReviewReimbursementRequest)
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+			return fiber.ErrBadRequest
+		}
+	}
+	request.ID = ctx.Params("id")
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := review(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.Reimbursement]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// Resubmit submits a rejected reimbursement of the authenticated employee again
+// @Summary Resubmit reimbursement
+// @Description Submit a rejected reimbursement record of the authenticated employee again with a corrected amount and description. It goes back to the manager stage and through both stages again. Sent as a multipart form, receipts can be added to the ones already attached
+// @Tags Reimbursement
+// @Accept json,mpfd
+// @Produce json
+// @Security bearer
+// @Param id path string true "Reimbursement ID"
+// @Param request body model.ResubmitReimbursementRequest true "Corrected reimbursement details"
+// @Param receipts formData file false "Receipt image or PDF, repeatable"
+// @Router /reimbursement/{id}/resubmit [post]
+func (h *ReimbursementHandler) Resubmit(ctx *fiber.Ctx) error {
+	method := "ReimbursementHandler.Resubmit"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := new(model.ResubmitReimbursementRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		return fiber.ErrBadRequest
+	}
+	request.ID = ctx.Params("id")
+	request.Receipts = receiptFiles(ctx)
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.Resubmit(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.Reimbursement]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// ListReviews retrieves the decisions made on a reimbursement
+// @Summary List reimbursement decisions
+// @Description Get the approvals and rejections of a reimbursement record across its stages and submissions, oldest first. Visible to the employee, their manager and admins
+// @Tags Reimbursement
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Reimbursement ID"
+// @Router /reimbursement/{id}/review [get]
+func (h *ReimbursementHandler) ListReviews(ctx *fiber.Ctx) error {
+	method := "ReimbursementHandler.ListReviews"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := &model.ListReimbursementReviewRequest{
+		ID: ctx.Params("id"),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.ListReviews(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[[]entity.ReimbursementReview]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// ListReceipts retrieves the receipts attached to a reimbursement
+// @Summary List reimbursement receipts
+// @Description Get the receipts attached to a reimbursement record, in upload order. Visible to the employee, their manager and admins
+// @Tags Reimbursement
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Reimbursement ID"
+// @Router /reimbursement/{id}/receipt [get]
+func (h *ReimbursementHandler) ListReceipts(ctx *fiber.Ctx) error {
+	method := "ReimbursementHandler.ListReceipts"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := &model.ListReimbursementReceiptRequest{
+		ID: ctx.Params("id"),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.ListReceipts(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[[]entity.ReimbursementReceipt]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// GetReceipt downloads a receipt attached to a reimbursement
+// @Summary Download reimbursement receipt
+// @Description Download a receipt attached to a reimbursement record as an attachment. Visible to the employee, their manager and admins
+// @Tags Reimbursement
+// @Produce octet-stream
+// @Security bearer
+// @Param id path string true "Reimbursement ID"
+// @Param receipt_id path string true "Receipt ID"
+// @Router /reimbursement/{id}/receipt/{receipt_id} [get]
+func (h *ReimbursementHandler) GetReceipt(ctx *fiber.Ctx) error {
+	method := "ReimbursementHandler.GetReceipt"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := &model.GetReimbursementReceiptRequest{
+		ID:        ctx.Params("id"),
+		ReceiptID: ctx.Params("receipt_id"),
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	receipt, content, err := h.UseCase.GetReceipt(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	ctx.Attachment(receipt.FileName)
+	ctx.Set(fiber.HeaderContentType, receipt.ContentType)
+	ctx.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	return ctx.SendStream(content, receipt.Size)
+}
+
+// receiptFiles returns the receipts files of a multipart form, none for a JSON body
+func receiptFiles(ctx *fiber.Ctx) []*multipart.FileHeader {
+	form, err := ctx.MultipartForm()
+	if err != nil {
+		return nil
+	}
+	return form.File["receipts"]
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"payslip-generator-service/internal/entity"
+	"payslip-generator-service/internal/middleware"
+	"payslip-generator-service/internal/model"
+	"payslip-generator-service/internal/usecase"
+	"payslip-generator-service/pkg/logger"
+	"payslip-generator-service/pkg/validator"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WorkScheduleHandler struct {
+	Log       *logger.ContextLogger
+	UseCase   *usecase.WorkScheduleUseCase
+	Validator *validator.Validator
+}
+
+func NewWorkScheduleHandler(
+	useCase *usecase.WorkScheduleUseCase,
+	log *logger.ContextLogger,
+	validator *validator.Validator,
+) *WorkScheduleHandler {
+	return &WorkScheduleHandler{
+		Log:       log,
+		UseCase:   useCase,
+		Validator: validator,
+	}
+}
+
+// List retrieves every work schedule
+// @Summary List work schedules
+// @Description Get every work schedule ordered by name. Employees without a schedule follow the default Monday to Friday day shift
+// @Tags Work Schedule
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Router /work-schedule [get]
+func (h *WorkScheduleHandler) List(ctx *fiber.Ctx) error {
+	method := "WorkScheduleHandler.List"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.List(requestCtx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponseWithData[[]entity.WorkSchedule]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// Create creates a new work schedule
+// @Summary Create work schedule
+// @Description Create a work schedule with its work days and shift hours. A shift ending earlier than it starts crosses midnight
+// @Tags Work Schedule
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param request body model.CreateWorkScheduleRequest true "Work schedule"
+// @Router /work-schedule [post]
+func (h *WorkScheduleHandler) Create(ctx *fiber.Ctx) error {
+	method := "WorkScheduleHandler.Create"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := new(model.CreateWorkScheduleRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+		return fiber.ErrBadRequest
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.Create(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.WorkSchedule]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// Update updates a work schedule
+// @Summary Update work schedule
+// @Description Update the name, work days and shift hours of a work schedule. The change applies to attendance validated from now on
+// @Tags Work Schedule
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param id path string true "Work schedule ID"
+// @Param request body model.CreateWorkScheduleRequest true "Work schedule"
+// @Router /work-schedule/{id} [put]
+func (h *WorkScheduleHandler) Update(ctx *fiber.Ctx) error {
+	method := "WorkScheduleHandler.Update"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	auth := middleware.GetAuth(ctx)
+	request := new(model.UpdateWorkScheduleRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+		return fiber.ErrBadRequest
+	}
+	request.ID = ctx.Params("id")
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	data, err := h.UseCase.Update(requestCtx, request, auth)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[*entity.WorkSchedule]{
+		Ok:   true,
+		Data: data,
+	})
+}
+
+// Assign assigns a work schedule to employees
+// @Summary Assign work schedule
+// @Description Assign a work schedule to employees; omit work_schedule_id to restore the default Monday to Friday day shift
+// @Tags Work Schedule
+// @Accept json
+// @Produce json
+// @Security bearer
+// @Param request body model.AssignWorkScheduleRequest true "Assignment"
+// @Router /work-schedule/assign [post]
+func (h *WorkScheduleHandler) Assign(ctx *fiber.Ctx) error {
+	method := "WorkScheduleHandler.Assign"
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	request := new(model.AssignWorkScheduleRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		h.Log.WithContext(ctx).Error("failed parse body: ", err.Error())
+		return fiber.ErrBadRequest
+	}
+
+	errValidation := h.Validator.ValidateStruct(request)
+	if errValidation != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: errValidation,
+		})
+	}
+
+	// Create context with request_id
+	requestCtx := ctx.UserContext()
+	if err := h.UseCase.Assign(requestCtx, request); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+			Ok:     false,
+			Errors: err.Error(),
+		})
+	}
+
+	h.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return ctx.JSON(model.WebResponse[any]{
+		Ok: true,
+	})
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"payslip-generator-service/config"
 	"payslip-generator-service/internal/model"
 	"payslip-generator-service/internal/usecase"
 	"payslip-generator-service/internal/utils"
@@ -9,7 +10,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func NewAuthMiddleware(employeeUseCase *usecase.EmployeeUseCase, util *utils.JwtUtil) fiber.Handler {
+func NewAuthMiddleware(employeeUseCase *usecase.EmployeeUseCase, util *utils.JwtUtil, config *config.Config) fiber.Handler {
 	return func(ctx *fiber.Ctx) error {
 		request := &model.VerifyAccountRequest{
 			Token: ctx.Get("Authorization", "NOT_FOUND"),
@@ -42,8 +43,9 @@ func NewAuthMiddleware(employeeUseCase *usecase.EmployeeUseCase, util *utils.Jwt
 		}
 
 		ctx.Locals("auth", &model.Auth{
-			ID:      employee.ID,
-			IsAdmin: employee.IsAdmin,
+			ID:       employee.ID,
+			IsAdmin:  employee.IsAdmin,
+			Location: employee.GetLocation(config.App.TimeZone),
 		})
 		return ctx.Next()
 	}
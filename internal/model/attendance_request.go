@@ -1,5 +1,7 @@
 package model
 
+import "mime/multipart"
+
 // CreateAttendanceRequest represents the request body for creating attendance record
 // swagger:model CreateAttendanceRequest
 type CreateAttendanceRequest struct {
@@ -12,4 +14,319 @@ type CreateAttendanceRequest struct {
 	// required: true
 	// example: "2024-01-15 17:00:00"
 	EndTime string `json:"end_time" validate:"required,is-valid-datetime"`
+
+	// Latitude the employee checks in from, required with longitude
+	// required: false
+	// example: -6.2254
+	Latitude *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,latitude"`
+
+	// Longitude the employee checks in from, required with latitude
+	// required: false
+	// example: 106.7997
+	Longitude *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,longitude"`
+
+	// Breaks taken within the work shift, unpaid breaks are excluded from the worked hours
+	// required: false
+	Breaks []CreateAttendanceBreakRequest `json:"breaks" validate:"omitempty,max=10,dive"`
+
+	// IP address of the caller, resolved from the trusted proxies only
+	IPAddress string `json:"-"`
+}
+
+// CreateAttendanceBreakRequest represents a break recorded together with an attendance
+// swagger:model CreateAttendanceBreakRequest
+type CreateAttendanceBreakRequest struct {
+	// Start time of the break (RFC3339 format)
+	// required: true
+	// example: "2024-01-15T12:00:00+07:00"
+	StartTime string `json:"start_time" validate:"required,is-valid-datetime"`
+
+	// End time of the break (RFC3339 format)
+	// required: true
+	// example: "2024-01-15T13:00:00+07:00"
+	EndTime string `json:"end_time" validate:"required,is-valid-datetime"`
+
+	// Whether the break counts as worked time
+	// required: false
+	// example: false
+	Paid bool `json:"paid"`
+}
+
+// StartBreakRequest represents the optional request body for starting a break
+// swagger:model StartBreakRequest
+type StartBreakRequest struct {
+	// Whether the break counts as worked time, breaks are unpaid by default
+	// required: false
+	// example: false
+	Paid bool `json:"paid"`
+}
+
+// CheckInRequest represents the optional request body for checking in
+// swagger:model CheckInRequest
+type CheckInRequest struct {
+	// Latitude the employee checks in from, required with longitude
+	// required: false
+	// example: -6.2254
+	Latitude *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,latitude"`
+
+	// Longitude the employee checks in from, required with latitude
+	// required: false
+	// example: 106.7997
+	Longitude *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,longitude"`
+
+	// IP address of the caller, resolved from the trusted proxies only
+	IPAddress string `json:"-"`
+}
+
+// ListAttendanceRequest represents the request parameters for listing attendance records
+// swagger:model ListAttendanceRequest
+type ListAttendanceRequest struct {
+	// Page number for pagination (minimum 1)
+	// required: false
+	// minimum: 1
+	// example: 1
+	Page int `json:"page" validate:"min=1"`
+
+	// Number of items per page (minimum 1, maximum 100)
+	// required: false
+	// minimum: 1
+	// maximum: 100
+	// example: 10
+	PageSize int `json:"size" validate:"min=1,max=100"`
+
+	// Only include records on or after this date (YYYY-MM-DD format)
+	// required: false
+	// example: "2024-01-01"
+	StartDate string `json:"start_date" validate:"omitempty,is-valid-date"`
+
+	// Only include records on or before this date (YYYY-MM-DD format)
+	// required: false
+	// example: "2024-01-31"
+	EndDate string `json:"end_date" validate:"omitempty,is-valid-date"`
+
+	// Only include records within this payroll period
+	// required: false
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	PeriodID string `json:"period_id" validate:"omitempty,ulid"`
+
+	// Only include records of this employee, ignored for non-admin callers
+	// required: false
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	EmployeeID string `json:"employee_id" validate:"omitempty,ulid"`
+}
+
+// CreateAttendanceCorrectionRequest represents the request body for an admin creating attendance on behalf of an employee
+// swagger:model CreateAttendanceCorrectionRequest
+type CreateAttendanceCorrectionRequest struct {
+	// Employee the attendance belongs to
+	// required: true
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	EmployeeID string `json:"employee_id" validate:"required,ulid"`
+
+	// Start time of work shift (RFC 3339 format)
+	// required: true
+	// example: "2024-01-15T08:00:00+07:00"
+	StartTime string `json:"start_time" validate:"required,is-valid-datetime"`
+
+	// End time of work shift (RFC 3339 format)
+	// required: true
+	// example: "2024-01-15T17:00:00+07:00"
+	EndTime string `json:"end_time" validate:"required,is-valid-datetime"`
+
+	// Reason for the correction
+	// required: true
+	// example: "Fingerprint reader was offline"
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// AmendAttendanceCorrectionRequest represents the request body for an admin amending an attendance record
+// swagger:model AmendAttendanceCorrectionRequest
+type AmendAttendanceCorrectionRequest struct {
+	// Attendance record to amend, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+
+	// Corrected start time of work shift (RFC 3339 format)
+	// required: true
+	// example: "2024-01-15T08:00:00+07:00"
+	StartTime string `json:"start_time" validate:"required,is-valid-datetime"`
+
+	// Corrected end time of work shift (RFC 3339 format)
+	// required: true
+	// example: "2024-01-15T17:00:00+07:00"
+	EndTime string `json:"end_time" validate:"required,is-valid-datetime"`
+
+	// Reason for the correction
+	// required: true
+	// example: "Employee forgot to check out"
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// VoidAttendanceCorrectionRequest represents the request body for an admin voiding an attendance record
+// swagger:model VoidAttendanceCorrectionRequest
+type VoidAttendanceCorrectionRequest struct {
+	// Attendance record to void, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+
+	// Reason for voiding the record
+	// required: true
+	// example: "Employee was on leave that day"
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// SubmitAttendanceCorrectionRequest represents the request body for an employee asking to fix an attendance day
+// swagger:model SubmitAttendanceCorrectionRequest
+type SubmitAttendanceCorrectionRequest struct {
+	// Attendance record to amend, omit for a missed day
+	// required: false
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	AttendanceID string `json:"attendance_id" validate:"omitempty,ulid"`
+
+	// Proposed start time (RFC 3339 format)
+	// required: true
+	// example: "2024-01-15T08:00:00+07:00"
+	StartTime string `json:"start_time" validate:"required,is-valid-datetime"`
+
+	// Proposed end time (RFC 3339 format)
+	// required: true
+	// example: "2024-01-15T17:00:00+07:00"
+	EndTime string `json:"end_time" validate:"required,is-valid-datetime"`
+
+	// Reason for the correction
+	// required: true
+	// example: "Forgot to check in after the client visit"
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// ListAttendanceCorrectionRequest represents the request parameters for listing attendance correction requests
+// swagger:model ListAttendanceCorrectionRequest
+type ListAttendanceCorrectionRequest struct {
+	// Page number for pagination (minimum 1)
+	// required: false
+	// minimum: 1
+	// example: 1
+	Page int `json:"page" validate:"min=1"`
+
+	// Number of items per page (minimum 1, maximum 100)
+	// required: false
+	// minimum: 1
+	// maximum: 100
+	// example: 10
+	PageSize int `json:"size" validate:"min=1,max=100"`
+
+	// Only include requests with this status
+	// required: false
+	// example: "pending"
+	Status string `json:"status" validate:"omitempty,oneof=pending approved rejected"`
+
+	// Only include requests of this employee, ignored for non-admin callers
+	// required: false
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	EmployeeID string `json:"employee_id" validate:"omitempty,ulid"`
+}
+
+// ReviewAttendanceCorrectionRequest represents the request body for an admin approving or rejecting a correction request
+// swagger:model ReviewAttendanceCorrectionRequest
+type ReviewAttendanceCorrectionRequest struct {
+	// Correction request to review, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+
+	// Comment for the employee, required when rejecting
+	// required: false
+	// example: "No record of the client visit"
+	Comment string `json:"comment" validate:"max=500"`
+}
+
+// ImportAttendanceRequest represents the multipart form for importing punch logs exported by terminals
+// swagger:model ImportAttendanceRequest
+type ImportAttendanceRequest struct {
+	// CSV punch log, one punch per row
+	File *multipart.FileHeader `json:"-" validate:"required"`
+
+	// Validate and pair the punches without saving them, anything but "false" is a dry run
+	// required: false
+	// example: true
+	DryRun bool `json:"dry_run"`
+
+	// Whether the first row of the file holds the column names, anything but "false" means it does
+	// required: false
+	// example: true
+	HasHeader bool `json:"has_header"`
+
+	// Field delimiter, defaults to Attendance.Import.Delimiter
+	// required: false
+	// example: ";"
+	Delimiter string `json:"delimiter" validate:"omitempty,len=1"`
+
+	// Column holding the terminal user ID, a column name or a 1-based column number
+	// required: false
+	// example: "user_id"
+	DeviceUserIDColumn string `json:"device_user_id_column" validate:"omitempty,max=100"`
+
+	// Column holding the punch time, a column name or a 1-based column number
+	// required: false
+	// example: "timestamp"
+	TimestampColumn string `json:"timestamp_column" validate:"omitempty,max=100"`
+
+	// Column holding the punch direction, a column name or a 1-based column number; without it the first and
+	// last punch of each day are paired
+	// required: false
+	// example: "state"
+	DirectionColumn string `json:"direction_column" validate:"omitempty,max=100"`
+
+	// Go time layout of the punch time, read in the employee's time zone
+	// required: false
+	// example: "2006-01-02 15:04:05"
+	TimestampLayout string `json:"timestamp_layout" validate:"omitempty,max=50"`
+
+	// Comma separated direction values of a check-in
+	// required: false
+	// example: "in,0"
+	InValues string `json:"in_values" validate:"omitempty,max=200"`
+
+	// Comma separated direction values of a check-out
+	// required: false
+	// example: "out,1"
+	OutValues string `json:"out_values" validate:"omitempty,max=200"`
+}
+
+// GetAttendanceAnalyticsRequest represents the request parameters for the attendance analytics
+// swagger:model GetAttendanceAnalyticsRequest
+type GetAttendanceAnalyticsRequest struct {
+	// First day of the range (YYYY-MM-DD format)
+	// required: true
+	// example: "2024-01-01"
+	StartDate string `json:"start_date" validate:"required,is-valid-date"`
+
+	// Last day of the range (YYYY-MM-DD format), at most 366 days after the start date
+	// required: true
+	// example: "2024-01-31"
+	EndDate string `json:"end_date" validate:"required,is-valid-date"`
+
+	// Size of the buckets (default: week)
+	// required: false
+	// example: "week"
+	Bucket string `json:"bucket" validate:"omitempty,oneof=day week month"`
+
+	// Only report on this employee
+	// required: false
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	EmployeeID string `json:"employee_id" validate:"omitempty,ulid"`
+
+	// Department of the employees to report on
+	// required: false
+	// example: "engineering"
+	Department string `json:"department" validate:"max=100"`
+
+	// Page number of the employees (minimum 1)
+	// required: false
+	// minimum: 1
+	// example: 1
+	Page int `json:"page" validate:"min=1"`
+
+	// Number of employees per page (minimum 1, maximum 100)
+	// required: false
+	// minimum: 1
+	// maximum: 100
+	// example: 10
+	PageSize int `json:"size" validate:"min=1,max=100"`
 }
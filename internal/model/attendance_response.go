@@ -1,3 +1,47 @@
 package model
 
+import "payslip-generator-service/internal/entity"
+
 type CreateAttendanceResponse struct{}
+
+// ImportAttendanceRejection represents punches of an import that did not become an attendance
+// swagger:model ImportAttendanceRejection
+type ImportAttendanceRejection struct {
+	// Line numbers of the rejected punches in the file
+	// example: [12, 13]
+	Rows []int `json:"rows"`
+
+	// Terminal user ID of the punches
+	// example: "1024"
+	DeviceUserID string `json:"device_user_id"`
+
+	// Reason code of the rejection
+	// example: "attendance/import-missing-check-out"
+	Reason string `json:"reason"`
+}
+
+// ImportAttendanceResponse represents the validation report of a punch log import
+// swagger:model ImportAttendanceResponse
+type ImportAttendanceResponse struct {
+	// Whether the import was a dry run, nothing is saved then
+	// example: true
+	DryRun bool `json:"dry_run"`
+
+	// Number of punch rows read from the file
+	// example: 120
+	TotalRows int `json:"total_rows"`
+
+	// Number of attendance records created, or that would be created in a dry run
+	// example: 58
+	ImportedCount int `json:"imported_count"`
+
+	// Number of punch rows rejected
+	// example: 4
+	RejectedRows int `json:"rejected_rows"`
+
+	// Attendance records created from paired punches
+	Attendances []entity.Attendance `json:"attendances"`
+
+	// Rejected punches, in file order
+	Rejections []ImportAttendanceRejection `json:"rejections"`
+}
@@ -1,6 +1,9 @@
 package model
 
-import "payslip-generator-service/pkg/database/gorm"
+import (
+	"payslip-generator-service/pkg/database/gorm"
+	"time"
+)
 
 type Role string
 
@@ -12,4 +15,6 @@ const (
 type Auth struct {
 	ID      gorm.ULID
 	IsAdmin bool
+	// Location is the employee's time zone, day boundaries are evaluated in it
+	Location *time.Location
 }
@@ -0,0 +1,66 @@
+package model
+
+// UpdateEmployeeTimezoneRequest represents the request body for setting the time zone of an employee
+// swagger:model UpdateEmployeeTimezoneRequest
+type UpdateEmployeeTimezoneRequest struct {
+	// Employee to update, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+
+	// IANA time zone name, omit to restore the configured default
+	// required: false
+	// example: "Asia/Makassar"
+	Timezone string `json:"timezone" validate:"omitempty,timezone"`
+}
+
+// UpdateEmployeeDeviceUserRequest represents the request body for linking an employee to a terminal user ID
+// swagger:model UpdateEmployeeDeviceUserRequest
+type UpdateEmployeeDeviceUserRequest struct {
+	// Employee to update, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+
+	// User ID the employee is enrolled with on biometric or timeclock terminals, omit to unlink the employee
+	// required: false
+	// example: "1024"
+	DeviceUserID string `json:"device_user_id" validate:"omitempty,max=64"`
+}
+
+// UpdateEmployeeManagerRequest represents the request body for setting the manager of an employee
+// swagger:model UpdateEmployeeManagerRequest
+type UpdateEmployeeManagerRequest struct {
+	// Employee to update, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+
+	// Manager of the employee, omit to remove the manager
+	// required: false
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	ManagerID string `json:"manager_id" validate:"omitempty,ulid"`
+}
+
+// UpdateEmployeeOvertimeEligibilityRequest represents the request body for changing the overtime entitlement of an employee
+// swagger:model UpdateEmployeeOvertimeEligibilityRequest
+type UpdateEmployeeOvertimeEligibilityRequest struct {
+	// Employee to update, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+
+	// Whether the employee may claim and is paid overtime from the effective date on
+	// required: true
+	// example: false
+	Eligible *bool `json:"eligible" validate:"required"`
+
+	// First day the entitlement applies to, after the last processed payroll period
+	// required: true
+	// example: "2025-07-01"
+	EffectiveDate string `json:"effective_date" validate:"required,is-valid-date"`
+
+	// Reason for the change
+	// required: true
+	// example: "Promoted to engineering manager"
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// ListEmployeeOvertimeEligibilityRequest represents the request for the overtime entitlement history of an employee
+// swagger:model ListEmployeeOvertimeEligibilityRequest
+type ListEmployeeOvertimeEligibilityRequest struct {
+	// Employee to list the history of, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+}
@@ -14,4 +14,260 @@ type CreateOvertimeRequest struct {
 	// maximum: 3
 	// example: 2
 	TotalHours int `json:"total_hours" validate:"required,min=1,max=3"`
+
+	// How the overtime is compensated: cash (default) or toil to bank the hours as time off in lieu
+	// required: false
+	// enum: cash,toil
+	// example: "toil"
+	Compensation string `json:"compensation" validate:"omitempty,oneof=cash toil"`
+}
+
+// ListOvertimeRequest represents the request parameters for listing overtime records
+// swagger:model ListOvertimeRequest
+type ListOvertimeRequest struct {
+	// Page number for pagination (minimum 1)
+	// required: false
+	// minimum: 1
+	// example: 1
+	Page int `json:"page" validate:"min=1"`
+
+	// Number of items per page (minimum 1, maximum 100)
+	// required: false
+	// minimum: 1
+	// maximum: 100
+	// example: 10
+	PageSize int `json:"size" validate:"min=1,max=100"`
+
+	// Only include records on or after this date (YYYY-MM-DD format)
+	// required: false
+	// example: "2024-01-01"
+	StartDate string `json:"start_date" validate:"omitempty,is-valid-date"`
+
+	// Only include records on or before this date (YYYY-MM-DD format)
+	// required: false
+	// example: "2024-01-31"
+	EndDate string `json:"end_date" validate:"omitempty,is-valid-date"`
+
+	// Only include records within this payroll period
+	// required: false
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	PeriodID string `json:"period_id" validate:"omitempty,ulid"`
+
+	// Only include records of this employee, ignored for non-admin callers
+	// required: false
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	EmployeeID string `json:"employee_id" validate:"omitempty,ulid"`
+
+	// Only include records with this approval status
+	// required: false
+	// example: "pending"
+	Status string `json:"status" validate:"omitempty,oneof=pending approved rejected cancelled"`
+}
+
+// ListOvertimeApprovalRequest represents the request parameters for listing overtime waiting for approval
+// swagger:model ListOvertimeApprovalRequest
+type ListOvertimeApprovalRequest struct {
+	// Page number for pagination (minimum 1)
+	// required: false
+	// minimum: 1
+	// example: 1
+	Page int `json:"page" validate:"min=1"`
+
+	// Number of items per page (minimum 1, maximum 100)
+	// required: false
+	// minimum: 1
+	// maximum: 100
+	// example: 10
+	PageSize int `json:"size" validate:"min=1,max=100"`
+}
+
+// ReviewOvertimeRequest represents the request body for an admin or manager approving or rejecting overtime
+// swagger:model ReviewOvertimeRequest
+type ReviewOvertimeRequest struct {
+	// Overtime or overtime plan to review, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+
+	// Comment for the employee, required when rejecting
+	// required: false
+	// example: "No release was scheduled that evening"
+	Comment string `json:"comment" validate:"max=500"`
+}
+
+// CancelOvertimeRequest represents the request for an employee withdrawing an overtime or overtime plan
+// swagger:model CancelOvertimeRequest
+type CancelOvertimeRequest struct {
+	// Overtime or overtime plan to cancel, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+}
+
+// CreateOvertimePlanRequest represents the request body for requesting overtime in advance
+// swagger:model CreateOvertimePlanRequest
+type CreateOvertimePlanRequest struct {
+	// Date the overtime is planned on, today or later (YYYY-MM-DD format)
+	// required: true
+	// example: "2024-01-20"
+	Date string `json:"date" validate:"required,is-valid-date"`
+
+	// Planned hours of overtime (1-3 hours maximum)
+	// required: true
+	// minimum: 1
+	// maximum: 3
+	// example: 3
+	PlannedHours int `json:"planned_hours" validate:"required,min=1,max=3"`
+
+	// Reason for the overtime
+	// required: true
+	// example: "Production release on Saturday"
+	Reason string `json:"reason" validate:"required,max=500"`
+}
+
+// ListOvertimePlanRequest represents the request parameters for listing overtime plans
+// swagger:model ListOvertimePlanRequest
+type ListOvertimePlanRequest struct {
+	// Page number for pagination (minimum 1)
+	// required: false
+	// minimum: 1
+	// example: 1
+	Page int `json:"page" validate:"min=1"`
+
+	// Number of items per page (minimum 1, maximum 100)
+	// required: false
+	// minimum: 1
+	// maximum: 100
+	// example: 10
+	PageSize int `json:"size" validate:"min=1,max=100"`
+
+	// Only include plans on or after this date (YYYY-MM-DD format)
+	// required: false
+	// example: "2024-01-01"
+	StartDate string `json:"start_date" validate:"omitempty,is-valid-date"`
+
+	// Only include plans on or before this date (YYYY-MM-DD format)
+	// required: false
+	// example: "2024-01-31"
+	EndDate string `json:"end_date" validate:"omitempty,is-valid-date"`
+
+	// Only include plans of this employee, ignored for non-admin callers
+	// required: false
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	EmployeeID string `json:"employee_id" validate:"omitempty,ulid"`
+
+	// Only include plans with this status
+	// required: false
+	// example: "approved"
+	Status string `json:"status" validate:"omitempty,oneof=pending approved rejected cancelled confirmed"`
+}
+
+// ConfirmOvertimePlanRequest represents the request body for an employee confirming the actual hours of an approved plan
+// swagger:model ConfirmOvertimePlanRequest
+type ConfirmOvertimePlanRequest struct {
+	// Overtime plan to confirm, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+
+	// Actual hours of overtime worked (1-3 hours maximum)
+	// required: true
+	// minimum: 1
+	// maximum: 3
+	// example: 2
+	TotalHours int `json:"total_hours" validate:"required,min=1,max=3"`
+
+	// How the overtime is compensated: cash (default) or toil to bank the hours as time off in lieu
+	// required: false
+	// enum: cash,toil
+	// example: "toil"
+	Compensation string `json:"compensation" validate:"omitempty,oneof=cash toil"`
+}
+
+// OverrideOvertimeHoursRequest represents the request body for an admin overriding the paid hours of planned overtime
+// swagger:model OverrideOvertimeHoursRequest
+type OverrideOvertimeHoursRequest struct {
+	// Overtime to override, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+
+	// Hours to pay, at most the actual hours worked
+	// required: true
+	// minimum: 0
+	// maximum: 3
+	// example: 3
+	PaidHours *int `json:"paid_hours" validate:"required,min=0,max=3"`
+
+	// Reason for the override
+	// required: true
+	// example: "Release ran late, approved by the CTO"
+	Comment string `json:"comment" validate:"required,max=500"`
+}
+
+// GetOvertimeUsageRequest represents the request parameters for the overtime usage of the authenticated employee
+// swagger:model GetOvertimeUsageRequest
+type GetOvertimeUsageRequest struct {
+	// Day to report the usage for, today when empty (YYYY-MM-DD format)
+	// required: false
+	// example: "2024-01-17"
+	Date string `json:"date" validate:"omitempty,is-valid-date"`
+}
+
+// ReconcileOvertimeRequest represents the request parameters for comparing claimed and observed overtime in a period
+// swagger:model ReconcileOvertimeRequest
+type ReconcileOvertimeRequest struct {
+	// Payroll period to reconcile
+	// required: true
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	PeriodID string `json:"period_id" validate:"required,ulid"`
+
+	// Only reconcile this employee
+	// required: false
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	EmployeeID string `json:"employee_id" validate:"omitempty,ulid"`
+
+	// Department of the employees to reconcile
+	// required: false
+	// example: "engineering"
+	Department string `json:"department" validate:"max=100"`
+
+	// Page number of the employees (minimum 1)
+	// required: false
+	// minimum: 1
+	// example: 1
+	Page int `json:"page" validate:"min=1"`
+
+	// Number of employees per page (minimum 1, maximum 100)
+	// required: false
+	// minimum: 1
+	// maximum: 100
+	// example: 10
+	PageSize int `json:"size" validate:"min=1,max=100"`
+}
+
+// GetToilBalanceRequest represents the request parameters for a time-off-in-lieu balance
+// swagger:model GetToilBalanceRequest
+type GetToilBalanceRequest struct {
+	// Employee to get the balance of, admins only; employees get their own balance
+	// required: false
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	EmployeeID string `json:"employee_id" validate:"omitempty,ulid"`
+}
+
+// RecordToilUsageRequest represents the request body for recording time off in lieu taken by an employee
+// swagger:model RecordToilUsageRequest
+type RecordToilUsageRequest struct {
+	// Employee taking the time off
+	// required: true
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	EmployeeID string `json:"employee_id" validate:"required,ulid"`
+
+	// Day the time off is taken (YYYY-MM-DD format)
+	// required: true
+	// example: "2024-02-02"
+	Date string `json:"date" validate:"required,is-valid-date"`
+
+	// Hours taken from the balance
+	// required: true
+	// minimum: 1
+	// example: 4
+	Hours int `json:"hours" validate:"required,min=1"`
+
+	// Reason for the time off
+	// required: true
+	// example: "Half day off on Friday"
+	Reason string `json:"reason" validate:"required,max=500"`
 }
@@ -2,6 +2,7 @@ package model
 
 import (
 	"payslip-generator-service/internal/entity"
+	"time"
 
 	ulid "payslip-generator-service/pkg/database/gorm"
 )
@@ -54,6 +55,89 @@ type GetPayslipRequest struct {
 	PeriodID string `json:"period_id" validate:"required,ulid"`
 }
 
+// ListPayslipRequest represents the request parameters for listing the payslip history
+// swagger:model ListPayslipRequest
+type ListPayslipRequest struct {
+	// Page number for pagination (minimum 1)
+	// required: false
+	// minimum: 1
+	// example: 1
+	Page int `json:"page" validate:"min=1"`
+
+	// Number of items per page (minimum 1, maximum 100)
+	// required: false
+	// minimum: 1
+	// maximum: 100
+	// example: 10
+	PageSize int `json:"size" validate:"min=1,max=100"`
+}
+
+// GetPayslipReportRequest represents the request parameters for retrieving the payslip report
+// swagger:model GetPayslipReportRequest
+type GetPayslipReportRequest struct {
+	// Unique identifier of the payroll period
+	// required: true
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	PeriodID string `json:"period_id" validate:"required,ulid"`
+
+	// Page number for pagination (minimum 1)
+	// required: false
+	// minimum: 1
+	// example: 1
+	Page int `json:"page" validate:"min=1"`
+
+	// Number of items per page (minimum 1, maximum 100)
+	// required: false
+	// minimum: 1
+	// maximum: 100
+	// example: 10
+	PageSize int `json:"size" validate:"min=1,max=100"`
+
+	// Case-insensitive search on the employee username
+	// required: false
+	// example: "emp_00"
+	Search string `json:"search" validate:"max=50"`
+
+	// Department of the employees to include
+	// required: false
+	// example: "engineering"
+	Department string `json:"department" validate:"max=100"`
+
+	// Minimum take-home pay (inclusive)
+	// required: false
+	// example: 1000000
+	MinTakeHomePay *int `json:"min_take_home_pay"`
+
+	// Maximum take-home pay (inclusive)
+	// required: false
+	// example: 5000000
+	MaxTakeHomePay *int `json:"max_take_home_pay"`
+
+	// Column to sort by (default: username)
+	// required: false
+	// example: "take_home_pay"
+	SortBy string `json:"sort" validate:"omitempty,oneof=username basic_salary salary take_home_pay"`
+
+	// Sort direction (default: asc)
+	// required: false
+	// example: "desc"
+	SortOrder string `json:"order" validate:"omitempty,oneof=asc desc"`
+}
+
+// GetJournalRequest represents the request parameters for exporting the payroll journal
+// swagger:model GetJournalRequest
+type GetJournalRequest struct {
+	// Unique identifier of the payroll period
+	// required: true
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	PeriodID string `json:"period_id" validate:"required,ulid"`
+
+	// Export format, either json or csv (default: json)
+	// required: false
+	// example: "csv"
+	Format string `json:"format" validate:"omitempty,oneof=json csv"`
+}
+
 // GeneratePayslipRequest represents the request body for generating payslip
 // swagger:model GeneratePayslipRequest
 type GeneratePayslipRequest struct {
@@ -71,4 +155,7 @@ type GeneratePayslipRequest struct {
 	// Payroll period information
 	// required: true
 	Period entity.PayrollPeriod `json:"period"`
+
+	// Employee's time zone, the day of each record is taken in it
+	Location *time.Location `json:"-"`
 }
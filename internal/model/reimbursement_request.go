@@ -1,5 +1,129 @@
 package model
 
+import "mime/multipart"
+
 // CreateReimbursementResponse represents the response body for creating reimbursement record
 // swagger:model CreateReimbursementResponse
 type CreateReimbursementResponse struct{}
+
+// ListReimbursementRequest represents the request parameters for listing reimbursement records
+// swagger:model ListReimbursementRequest
+type ListReimbursementRequest struct {
+	// Page number for pagination (minimum 1)
+	// required: false
+	// minimum: 1
+	// example: 1
+	Page int `json:"page" validate:"min=1"`
+
+	// Number of items per page (minimum 1, maximum 100)
+	// required: false
+	// minimum: 1
+	// maximum: 100
+	// example: 10
+	PageSize int `json:"size" validate:"min=1,max=100"`
+
+	// Only include records on or after this date (YYYY-MM-DD format)
+	// required: false
+	// example: "2024-01-01"
+	StartDate string `json:"start_date" validate:"omitempty,is-valid-date"`
+
+	// Only include records on or before this date (YYYY-MM-DD format)
+	// required: false
+	// example: "2024-01-31"
+	EndDate string `json:"end_date" validate:"omitempty,is-valid-date"`
+
+	// Only include records within this payroll period
+	// required: false
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	PeriodID string `json:"period_id" validate:"omitempty,ulid"`
+
+	// Only include records of this employee, ignored for non-admin callers
+	// required: false
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	EmployeeID string `json:"employee_id" validate:"omitempty,ulid"`
+}
+
+// ListReimbursementApprovalRequest represents the request parameters for listing reimbursements waiting for approval
+// swagger:model ListReimbursementApprovalRequest
+type ListReimbursementApprovalRequest struct {
+	// Page number for pagination (minimum 1)
+	// required: false
+	// minimum: 1
+	// example: 1
+	Page int `json:"page" validate:"min=1"`
+
+	// Number of items per page (minimum 1, maximum 100)
+	// required: false
+	// minimum: 1
+	// maximum: 100
+	// example: 10
+	PageSize int `json:"size" validate:"min=1,max=100"`
+}
+
+// ReviewReimbursementRequest represents the request body for a manager or finance approving or rejecting a reimbursement
+// swagger:model ReviewReimbursementRequest
+type ReviewReimbursementRequest struct {
+	// Reimbursement to review, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+
+	// Amount approved, at most the amount approved by the previous stage; the whole amount when empty.
+	// Ignored when rejecting
+	// required: false
+	// minimum: 1
+	// example: 120000
+	Amount *int `json:"amount" validate:"omitempty,min=1"`
+
+	// Comment for the employee, the reason required when rejecting
+	// required: false
+	// example: "Only the taxi fare is covered"
+	Comment string `json:"comment" validate:"max=500"`
+}
+
+// ResubmitReimbursementRequest represents the request body for an employee submitting a rejected reimbursement
+// again, sent as JSON or as a multipart form along with receipts
+// swagger:model ResubmitReimbursementRequest
+type ResubmitReimbursementRequest struct {
+	// Reimbursement to resubmit, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+
+	// Corrected reimbursement amount in currency units
+	// required: true
+	// minimum: 1
+	// example: 120000
+	Amount int `json:"amount" form:"amount" validate:"required,min=1"`
+
+	// Corrected description of the reimbursement expense
+	// required: true
+	// min: 5
+	// max: 255
+	// example: "Taxi fare for client meeting"
+	Description string `json:"description" form:"description" validate:"required,min=5,max=255"`
+
+	// Receipts added to the ones already attached, taken from the receipts files of a multipart form
+	// required: false
+	Receipts []*multipart.FileHeader `json:"-" form:"-"`
+}
+
+// ListReimbursementReviewRequest represents the request for listing the decisions made on a reimbursement
+// swagger:model ListReimbursementReviewRequest
+type ListReimbursementReviewRequest struct {
+	// Reimbursement to list the decisions of, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+}
+
+// ListReimbursementReceiptRequest represents the request for listing the receipts attached to a reimbursement
+// swagger:model ListReimbursementReceiptRequest
+type ListReimbursementReceiptRequest struct {
+	// Reimbursement to list the receipts of, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+}
+
+// GetReimbursementReceiptRequest represents the request for downloading a receipt attached to a reimbursement
+// swagger:model GetReimbursementReceiptRequest
+type GetReimbursementReceiptRequest struct {
+	// Reimbursement the receipt is attached to, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+
+	// Receipt to download, taken from the path
+	ReceiptID string `json:"-" validate:"required,ulid"`
+}
@@ -1,18 +1,25 @@
 package model
 
-// CreateReimbursementRequest represents the request body for creating reimbursement record
+import "mime/multipart"
+
+// CreateReimbursementRequest represents the request body for creating reimbursement record, sent as JSON or as a
+// multipart form along with receipts
 // swagger:model CreateReimbursementRequest
 type CreateReimbursementRequest struct {
 	// Reimbursement amount in currency units
 	// required: true
 	// minimum: 1
 	// example: 150000
-	Amount int `json:"amount" validate:"required,min=1"`
+	Amount int `json:"amount" form:"amount" validate:"required,min=1"`
 
 	// Description of the reimbursement expense
 	// required: true
 	// min: 5
 	// max: 255
 	// example: "Transportation expenses for client meeting"
-	Description string `json:"description" validate:"required,min=5,max=255"`
+	Description string `json:"description" form:"description" validate:"required,min=5,max=255"`
+
+	// Receipt images or PDFs, taken from the receipts files of a multipart form
+	// required: false
+	Receipts []*multipart.FileHeader `json:"-" form:"-"`
 }
@@ -0,0 +1,48 @@
+package model
+
+// CreateWorkScheduleRequest represents the request body for creating a work schedule
+// swagger:model CreateWorkScheduleRequest
+type CreateWorkScheduleRequest struct {
+	// Name of the work schedule (unique)
+	// required: true
+	// example: "Night shift"
+	Name string `json:"name" validate:"required,max=100"`
+
+	// Days of the week the shift starts on
+	// required: true
+	// example: ["monday", "tuesday", "wednesday", "thursday", "friday"]
+	WorkDays []string `json:"work_days" validate:"required,min=1,dive,oneof=sunday monday tuesday wednesday thursday friday saturday"`
+
+	// Shift start time (HH:MM format)
+	// required: true
+	// example: "22:00"
+	ShiftStart string `json:"shift_start" validate:"required,datetime=15:04"`
+
+	// Shift end time (HH:MM format), earlier than the start time for a cross-midnight shift
+	// required: true
+	// example: "06:00"
+	ShiftEnd string `json:"shift_end" validate:"required,datetime=15:04"`
+}
+
+// UpdateWorkScheduleRequest represents the request body for updating a work schedule
+// swagger:model UpdateWorkScheduleRequest
+type UpdateWorkScheduleRequest struct {
+	// Work schedule to update, taken from the path
+	ID string `json:"-" validate:"required,ulid"`
+
+	CreateWorkScheduleRequest
+}
+
+// AssignWorkScheduleRequest represents the request body for assigning a work schedule to employees
+// swagger:model AssignWorkScheduleRequest
+type AssignWorkScheduleRequest struct {
+	// Work schedule to assign, omit to restore the default Monday to Friday day shift
+	// required: false
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	WorkScheduleID string `json:"work_schedule_id" validate:"omitempty,ulid"`
+
+	// Employees to assign the work schedule to
+	// required: true
+	// example: ["01HXYZ123456789ABCDEFGHIJK"]
+	EmployeeIDs []string `json:"employee_ids" validate:"required,min=1,max=500,dive,ulid"`
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"payslip-generator-service/internal/entity"
+	ulid "payslip-generator-service/pkg/database/gorm"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type AttendanceBreakRepository struct {
+	Repository[entity.AttendanceBreak]
+	Log *logrus.Logger
+}
+
+func NewAttendanceBreakRepository(log *logrus.Logger) *AttendanceBreakRepository {
+	return &AttendanceBreakRepository{
+		Log: log,
+	}
+}
+
+// FindAllByAttendanceIds returns the breaks of the attendance records in time order
+func (a *AttendanceBreakRepository) FindAllByAttendanceIds(db *gorm.DB, attendanceIDs []ulid.ULID) ([]entity.AttendanceBreak, error) {
+	var breaks []entity.AttendanceBreak
+	if err := db.Debug().Where("attendance_id IN ?", attendanceIDs).Order("start_time ASC").Find(&breaks).Error; err != nil {
+		return nil, err
+	}
+	return breaks, nil
+}
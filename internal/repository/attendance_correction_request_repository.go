@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"payslip-generator-service/internal/entity"
+	ulid "payslip-generator-service/pkg/database/gorm"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type AttendanceCorrectionRequestRepository struct {
+	Repository[entity.AttendanceCorrectionRequest]
+	Log *logrus.Logger
+}
+
+func NewAttendanceCorrectionRequestRepository(log *logrus.Logger) *AttendanceCorrectionRequestRepository {
+	return &AttendanceCorrectionRequestRepository{
+		Log: log,
+	}
+}
+
+// FindPendingByDate returns the pending request of the employee for the shift day of date in its location
+func (r *AttendanceCorrectionRequestRepository) FindPendingByDate(db *gorm.DB, employeeID ulid.ULID, date time.Time) (*entity.AttendanceCorrectionRequest, error) {
+	var request entity.AttendanceCorrectionRequest
+	err := db.Debug().
+		Where("created_by = ? AND status = ? AND work_date = ?", employeeID, entity.AttendanceCorrectionRequestStatusPending, date.Format(time.DateOnly)).
+		First(&request).Error
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
@@ -20,9 +20,24 @@ func NewAttendanceRepository(log *logrus.Logger) *AttendanceRepository {
 	}
 }
 
-func (a *AttendanceRepository) FindByDate(db *gorm.DB, date time.Time) (*entity.Attendance, error) {
+// FindByDate returns the attendance whose shift day is the calendar day of date in its location
+func (a *AttendanceRepository) FindByDate(db *gorm.DB, employeeID ulid.ULID, date time.Time) (*entity.Attendance, error) {
 	var attendance entity.Attendance
-	if err := db.Where("DATE(start_time) = ?", date.Format(time.DateOnly)).First(&attendance).Error; err != nil {
+	if err := db.Where("created_by = ? AND work_date = ? AND voided_at IS NULL", employeeID, date.Format(time.DateOnly)).First(&attendance).Error; err != nil {
+		return nil, err
+	}
+	return &attendance, nil
+}
+
+// FindWithBreaksByDate returns the attendance of the day like FindByDate, along with its breaks
+func (a *AttendanceRepository) FindWithBreaksByDate(db *gorm.DB, employeeID ulid.ULID, date time.Time) (*entity.Attendance, error) {
+	var attendance entity.Attendance
+	err := db.Where("created_by = ? AND work_date = ? AND voided_at IS NULL", employeeID, date.Format(time.DateOnly)).
+		Preload("Breaks", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_time ASC")
+		}).
+		First(&attendance).Error
+	if err != nil {
 		return nil, err
 	}
 	return &attendance, nil
@@ -32,9 +47,34 @@ func (a *AttendanceRepository) FindByPeriod(db *gorm.DB, employeeID ulid.ULID, s
 	var attendances []entity.Attendance
 
 	err := db.Debug().
-		Where("DATE(start_time) BETWEEN ? AND ?", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly)).
-		Where("DATE(end_time) BETWEEN ? AND ?", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly)).
-		Where("created_by = ?", employeeID).
+		Where("work_date BETWEEN ? AND ?", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly)).
+		Where("created_by = ? AND voided_at IS NULL", employeeID).
+		Preload("Breaks", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_time ASC")
+		}).
+		Preload("Revisions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Find(&attendances).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return attendances, nil
+}
+
+// FindAllByWorkDateRange returns the attendance of the employees whose shift day is in the range
+func (a *AttendanceRepository) FindAllByWorkDateRange(db *gorm.DB, employeeIDs []ulid.ULID, startDate, endDate time.Time) ([]entity.Attendance, error) {
+	var attendances []entity.Attendance
+
+	err := db.Debug().
+		Where("work_date BETWEEN ? AND ?", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly)).
+		Where("created_by IN ? AND voided_at IS NULL", employeeIDs).
+		Preload("Breaks", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_time ASC")
+		}).
+		Order("work_date ASC").
 		Find(&attendances).Error
 
 	if err != nil {
@@ -43,3 +83,34 @@ func (a *AttendanceRepository) FindByPeriod(db *gorm.DB, employeeID ulid.ULID, s
 
 	return attendances, nil
 }
+
+// FindAllOpen returns the sessions every employee is still checked in to
+func (a *AttendanceRepository) FindAllOpen(db *gorm.DB) ([]entity.Attendance, error) {
+	var attendances []entity.Attendance
+	err := db.Debug().
+		Where("end_time IS NULL AND voided_at IS NULL").
+		Preload("Breaks", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_time ASC")
+		}).
+		Order("start_time ASC").
+		Find(&attendances).Error
+	if err != nil {
+		return nil, err
+	}
+	return attendances, nil
+}
+
+func (a *AttendanceRepository) FindOpenByEmployee(db *gorm.DB, employeeID ulid.ULID) (*entity.Attendance, error) {
+	var attendance entity.Attendance
+	err := db.Debug().
+		Where("created_by = ? AND end_time IS NULL AND voided_at IS NULL", employeeID).
+		Preload("Breaks", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_time ASC")
+		}).
+		Order("start_time DESC").
+		First(&attendance).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attendance, nil
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"payslip-generator-service/internal/entity"
+
+	"github.com/sirupsen/logrus"
+)
+
+type AttendanceRevisionRepository struct {
+	Repository[entity.AttendanceRevision]
+	Log *logrus.Logger
+}
+
+func NewAttendanceRevisionRepository(log *logrus.Logger) *AttendanceRevisionRepository {
+	return &AttendanceRevisionRepository{
+		Log: log,
+	}
+}
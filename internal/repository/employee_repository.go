@@ -2,6 +2,9 @@ package repository
 
 import (
 	"payslip-generator-service/internal/entity"
+	ulid "payslip-generator-service/pkg/database/gorm"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
@@ -21,3 +24,49 @@ func NewEmployeeRepository(log *logrus.Logger) *EmployeeRepository {
 func (r *EmployeeRepository) GetByUsername(db *gorm.DB, employee *entity.Employee, username string) error {
 	return db.Debug().Where("username = ?", username).Take(employee).Error
 }
+
+func (r *EmployeeRepository) FindAllByFilter(db *gorm.DB, search, department string) ([]entity.Employee, error) {
+	var employees []entity.Employee
+
+	query := db.Debug()
+	if search != "" {
+		query = query.Where(`username ILIKE ? ESCAPE '\'`, "%"+escapeLike(search)+"%")
+	}
+	if department != "" {
+		query = query.Where("LOWER(department) = LOWER(?)", department)
+	}
+
+	if err := query.Order("username ASC").Find(&employees).Error; err != nil {
+		return nil, err
+	}
+
+	return employees, nil
+}
+
+// FindAllByDeviceUserIds returns the employees enrolled on terminals with the given user IDs
+func (r *EmployeeRepository) FindAllByDeviceUserIds(db *gorm.DB, deviceUserIDs []string) ([]entity.Employee, error) {
+	var employees []entity.Employee
+	err := db.Debug().Where("device_user_id IN ?", deviceUserIDs).Find(&employees).Error
+	return employees, err
+}
+
+func (r *EmployeeRepository) CountByIds(db *gorm.DB, ids []ulid.ULID) (int64, error) {
+	var total int64
+	err := db.Debug().Model(new(entity.Employee)).Where("id IN ?", ids).Count(&total).Error
+	return total, err
+}
+
+// AssignWorkSchedule sets the work schedule of the employees, a nil scheduleID restores the default schedule
+func (r *EmployeeRepository) AssignWorkSchedule(db *gorm.DB, ids []ulid.ULID, scheduleID *ulid.ULID) error {
+	return db.Debug().Model(new(entity.Employee)).
+		Where("id IN ?", ids).
+		Updates(map[string]any{"work_schedule_id": scheduleID, "updated_at": time.Now()}).Error
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern, and the escape character itself, so a search matches them
+// literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(value string) string {
+	return likeEscaper.Replace(value)
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"payslip-generator-service/internal/entity"
+	ulid "payslip-generator-service/pkg/database/gorm"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type OvertimeEligibilityRepository struct {
+	Repository[entity.OvertimeEligibility]
+	Log *logrus.Logger
+}
+
+func NewOvertimeEligibilityRepository(log *logrus.Logger) *OvertimeEligibilityRepository {
+	return &OvertimeEligibilityRepository{
+		Log: log,
+	}
+}
+
+// FindAllByEmployeeId returns the overtime entitlement history of the employee, oldest change first
+func (a *OvertimeEligibilityRepository) FindAllByEmployeeId(db *gorm.DB, employeeID ulid.ULID) (entity.OvertimeEligibilities, error) {
+	var eligibilities entity.OvertimeEligibilities
+
+	err := db.Debug().
+		Where("employee_id = ?", employeeID).
+		Order("effective_date ASC").
+		Find(&eligibilities).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return eligibilities, nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"payslip-generator-service/internal/entity"
+	ulid "payslip-generator-service/pkg/database/gorm"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// activeOvertimePlanStatuses are the statuses of an overtime plan occupying its day
+var activeOvertimePlanStatuses = []entity.OvertimePlanStatus{
+	entity.OvertimePlanStatusPending,
+	entity.OvertimePlanStatusApproved,
+	entity.OvertimePlanStatusConfirmed,
+}
+
+type OvertimePlanRepository struct {
+	Repository[entity.OvertimePlan]
+	Log *logrus.Logger
+}
+
+func NewOvertimePlanRepository(log *logrus.Logger) *OvertimePlanRepository {
+	return &OvertimePlanRepository{
+		Log: log,
+	}
+}
+
+// FindByDate returns the pending, approved or confirmed overtime plan of the employee on the date
+func (a *OvertimePlanRepository) FindByDate(db *gorm.DB, employeeID ulid.ULID, date time.Time) (*entity.OvertimePlan, error) {
+	var plan entity.OvertimePlan
+	if err := db.Where("created_by = ? AND date = ? AND status IN ?", employeeID, date.Format(time.DateOnly), activeOvertimePlanStatuses).First(&plan).Error; err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// FindAllUnconfirmedByDateRange returns the pending or approved overtime plans of the employee in the date range,
+// the plans whose hours are not recorded as overtime yet
+func (a *OvertimePlanRepository) FindAllUnconfirmedByDateRange(db *gorm.DB, employeeID ulid.ULID, startDate, endDate time.Time) ([]entity.OvertimePlan, error) {
+	var plans []entity.OvertimePlan
+
+	err := db.Debug().
+		Where("date BETWEEN ? AND ?", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly)).
+		Where("created_by = ? AND status IN ?", employeeID, []entity.OvertimePlanStatus{entity.OvertimePlanStatusPending, entity.OvertimePlanStatusApproved}).
+		Find(&plans).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return plans, nil
+}
@@ -9,6 +9,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// activeOvertimeStatuses are the statuses of an overtime occupying its day
+var activeOvertimeStatuses = []entity.OvertimeStatus{entity.OvertimeStatusPending, entity.OvertimeStatusApproved}
+
 type OvertimeRepository struct {
 	Repository[entity.Overtime]
 	Log *logrus.Logger
@@ -20,20 +23,39 @@ func NewOvertimeRepository(log *logrus.Logger) *OvertimeRepository {
 	}
 }
 
-func (a *OvertimeRepository) FindByDate(db *gorm.DB, date time.Time) (*entity.Overtime, error) {
+// FindByDate returns the pending or approved overtime of the employee on the date
+func (a *OvertimeRepository) FindByDate(db *gorm.DB, employeeID ulid.ULID, date time.Time) (*entity.Overtime, error) {
 	var overtime entity.Overtime
-	if err := db.Where("date = ?", date.Format(time.DateOnly)).First(&overtime).Error; err != nil {
+	if err := db.Where("created_by = ? AND date = ? AND status IN ?", employeeID, date.Format(time.DateOnly), activeOvertimeStatuses).First(&overtime).Error; err != nil {
 		return nil, err
 	}
 	return &overtime, nil
 }
 
-func (a *OvertimeRepository) FindByPeriod(db *gorm.DB, employeeID ulid.ULID, startDate, endDate time.Time) ([]entity.Overtime, error) {
+// FindAllActiveByDateRange returns the pending or approved overtime of the employees in the date range
+func (a *OvertimeRepository) FindAllActiveByDateRange(db *gorm.DB, employeeIDs []ulid.ULID, startDate, endDate time.Time) ([]entity.Overtime, error) {
+	var overtimes []entity.Overtime
+
+	err := db.Debug().
+		Where("date BETWEEN ? AND ?", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly)).
+		Where("created_by IN ? AND status IN ?", employeeIDs, activeOvertimeStatuses).
+		Order("date ASC").
+		Find(&overtimes).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return overtimes, nil
+}
+
+// FindApprovedByPeriod returns the approved overtime of the employee in the date range
+func (a *OvertimeRepository) FindApprovedByPeriod(db *gorm.DB, employeeID ulid.ULID, startDate, endDate time.Time) ([]entity.Overtime, error) {
 	var overtimes []entity.Overtime
 
 	err := db.Debug().
 		Where("date BETWEEN ? AND ?", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly)).
-		Where("created_by = ?", employeeID).
+		Where("created_by = ? AND status = ?", employeeID, entity.OvertimeStatusApproved).
 		Find(&overtimes).Error
 
 	if err != nil {
@@ -40,3 +40,40 @@ func (a *PayrollPeriodRepository) IsOverlapping(db *gorm.DB, startDate, endDate
 
 	return exists, err
 }
+
+func (a *PayrollPeriodRepository) FindByDate(db *gorm.DB, date time.Time) (*entity.PayrollPeriod, error) {
+	var payrollPeriod entity.PayrollPeriod
+	err := db.Debug().
+		Where("start_date <= ? AND end_date >= ?", date.Format(time.DateOnly), date.Format(time.DateOnly)).
+		First(&payrollPeriod).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payrollPeriod, nil
+}
+
+// FindLastProcessed returns the processed payroll period ending last
+func (a *PayrollPeriodRepository) FindLastProcessed(db *gorm.DB) (*entity.PayrollPeriod, error) {
+	var payrollPeriod entity.PayrollPeriod
+	err := db.Debug().
+		Where("processed_at IS NOT NULL").
+		Order("end_date DESC").
+		First(&payrollPeriod).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payrollPeriod, nil
+}
+
+// FindProcessedBefore returns the payroll period processed last before the time
+func (a *PayrollPeriodRepository) FindProcessedBefore(db *gorm.DB, processedAt time.Time) (*entity.PayrollPeriod, error) {
+	var payrollPeriod entity.PayrollPeriod
+	err := db.Debug().
+		Where("processed_at < ?", processedAt).
+		Order("processed_at DESC").
+		First(&payrollPeriod).Error
+	if err != nil {
+		return nil, err
+	}
+	return &payrollPeriod, nil
+}
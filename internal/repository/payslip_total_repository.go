@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"payslip-generator-service/internal/entity"
+	ulid "payslip-generator-service/pkg/database/gorm"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type PayslipTotalRepository struct {
+	Repository[entity.PayslipTotal]
+	Log *logrus.Logger
+}
+
+func NewPayslipTotalRepository(log *logrus.Logger) *PayslipTotalRepository {
+	return &PayslipTotalRepository{
+		Log: log,
+	}
+}
+
+// CreateAll stores the payslip totals of a processed payroll period in batches
+func (a *PayslipTotalRepository) CreateAll(db *gorm.DB, totals []entity.PayslipTotal) error {
+	if len(totals) == 0 {
+		return nil
+	}
+	return db.Debug().CreateInBatches(totals, 500).Error
+}
+
+// FindAllByEmployeeIdAndPeriodIds returns the stored payslip totals of the employee for the given payroll periods
+func (a *PayslipTotalRepository) FindAllByEmployeeIdAndPeriodIds(db *gorm.DB, employeeID ulid.ULID, periodIDs []ulid.ULID) ([]entity.PayslipTotal, error) {
+	var totals []entity.PayslipTotal
+
+	if len(periodIDs) == 0 {
+		return totals, nil
+	}
+
+	err := db.Debug().
+		Where("employee_id = ? AND payroll_period_id IN ?", employeeID, periodIDs).
+		Find(&totals).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+// FindByEmployeeIdAndPeriodId returns the stored payslip total of the employee for the payroll period
+func (a *PayslipTotalRepository) FindByEmployeeIdAndPeriodId(db *gorm.DB, employeeID, periodID ulid.ULID) (*entity.PayslipTotal, error) {
+	var total entity.PayslipTotal
+
+	err := db.Debug().
+		Where("employee_id = ? AND payroll_period_id = ?", employeeID, periodID).
+		First(&total).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &total, nil
+}
+
+// HasTotals checks if the payroll period was processed with its payslip totals stored
+func (a *PayslipTotalRepository) HasTotals(db *gorm.DB, periodID ulid.ULID) (bool, error) {
+	var exists bool
+	err := db.Model(new(entity.PayslipTotal)).
+		Select("1").
+		Where("payroll_period_id = ?", periodID).
+		Limit(1).
+		Scan(&exists).Error
+
+	return exists, err
+}
+
+// FindAllByPeriodId returns the stored payslip totals of every employee for the payroll period
+func (a *PayslipTotalRepository) FindAllByPeriodId(db *gorm.DB, periodID ulid.ULID) ([]entity.PayslipTotal, error) {
+	var totals []entity.PayslipTotal
+
+	err := db.Debug().
+		Where("payroll_period_id = ?", periodID).
+		Order("employee_id ASC").
+		Find(&totals).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
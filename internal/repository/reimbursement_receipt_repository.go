@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"payslip-generator-service/internal/entity"
+	ulid "payslip-generator-service/pkg/database/gorm"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ReimbursementReceiptRepository struct {
+	Repository[entity.ReimbursementReceipt]
+	Log *logrus.Logger
+}
+
+func NewReimbursementReceiptRepository(log *logrus.Logger) *ReimbursementReceiptRepository {
+	return &ReimbursementReceiptRepository{
+		Log: log,
+	}
+}
+
+// FindAllByReimbursementId returns the receipts attached to the reimbursement, in upload order
+func (a *ReimbursementReceiptRepository) FindAllByReimbursementId(db *gorm.DB, reimbursementID ulid.ULID) ([]entity.ReimbursementReceipt, error) {
+	var receipts []entity.ReimbursementReceipt
+
+	err := db.Debug().
+		Where("reimbursement_id = ?", reimbursementID).
+		Order("created_at ASC, id ASC").
+		Find(&receipts).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return receipts, nil
+}
+
+// FindByReimbursementId returns the receipt when it is attached to the reimbursement
+func (a *ReimbursementReceiptRepository) FindByReimbursementId(db *gorm.DB, reimbursementID, id ulid.ULID) (*entity.ReimbursementReceipt, error) {
+	var receipt entity.ReimbursementReceipt
+
+	if err := db.Where("id = ? AND reimbursement_id = ?", id, reimbursementID).First(&receipt).Error; err != nil {
+		return nil, err
+	}
+
+	return &receipt, nil
+}
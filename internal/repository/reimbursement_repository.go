@@ -20,13 +20,15 @@ func NewReimbursementRepository(log *logrus.Logger) *ReimbursementRepository {
 	}
 }
 
-func (a *ReimbursementRepository) FindByPeriod(db *gorm.DB, employeeID ulid.ULID, startDate, endDate time.Time) ([]entity.Reimbursement, error) {
+// FindApprovedByPeriod returns the reimbursements of the employee approved by finance between the dates, taking
+// the approval day in the location
+func (a *ReimbursementRepository) FindApprovedByPeriod(db *gorm.DB, employeeID ulid.ULID, startDate, endDate time.Time, location *time.Location) ([]entity.Reimbursement, error) {
 	var reimbursements []entity.Reimbursement
 
 	err := db.
 		Debug().
-		Where("DATE(created_at) BETWEEN ? AND ?", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly)).
-		Where("created_by = ?", employeeID).
+		Where("DATE(approved_at AT TIME ZONE ?) BETWEEN ? AND ?", location.String(), startDate.Format(time.DateOnly), endDate.In(location).Format(time.DateOnly)).
+		Where("created_by = ? AND status = ?", employeeID, entity.ReimbursementStatusApproved).
 		Find(&reimbursements).Error
 
 	if err != nil {
@@ -35,3 +37,25 @@ func (a *ReimbursementRepository) FindByPeriod(db *gorm.DB, employeeID ulid.ULID
 
 	return reimbursements, nil
 }
+
+// FindApprovedBetween returns the reimbursements of the employee approved by finance after the start, when given,
+// until the end
+func (a *ReimbursementRepository) FindApprovedBetween(db *gorm.DB, employeeID ulid.ULID, after *time.Time, until time.Time) ([]entity.Reimbursement, error) {
+	var reimbursements []entity.Reimbursement
+
+	query := db.
+		Debug().
+		Where("created_by = ? AND status = ?", employeeID, entity.ReimbursementStatusApproved).
+		Where("approved_at <= ?", until)
+	if after != nil {
+		query = query.Where("approved_at > ?", *after)
+	}
+
+	err := query.Find(&reimbursements).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reimbursements, nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"payslip-generator-service/internal/entity"
+	ulid "payslip-generator-service/pkg/database/gorm"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type ReimbursementReviewRepository struct {
+	Repository[entity.ReimbursementReview]
+	Log *logrus.Logger
+}
+
+func NewReimbursementReviewRepository(log *logrus.Logger) *ReimbursementReviewRepository {
+	return &ReimbursementReviewRepository{
+		Log: log,
+	}
+}
+
+// FindAllByReimbursementId returns the decisions made on the reimbursement, oldest first
+func (a *ReimbursementReviewRepository) FindAllByReimbursementId(db *gorm.DB, reimbursementID ulid.ULID) ([]entity.ReimbursementReview, error) {
+	var reviews []entity.ReimbursementReview
+
+	err := db.Debug().
+		Where("reimbursement_id = ?", reimbursementID).
+		Order("created_at ASC, id ASC").
+		Find(&reviews).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
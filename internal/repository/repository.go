@@ -5,6 +5,7 @@ import (
 	ulid "payslip-generator-service/pkg/database/gorm"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository[T any] struct {
@@ -33,6 +34,11 @@ func (r *Repository[T]) FindById(db *gorm.DB, entity *T, id ulid.ULID) error {
 	return db.Debug().Where("id = ?", id).Take(entity).Error
 }
 
+// FindByIdForUpdate loads the entity and locks its row until the transaction of db ends
+func (r *Repository[T]) FindByIdForUpdate(db *gorm.DB, entity *T, id ulid.ULID) error {
+	return db.Debug().Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Take(entity).Error
+}
+
 func (r *Repository[T]) FindAll(db *gorm.DB, entities *[]T) error {
 	return db.Debug().Find(entities).Error
 }
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"payslip-generator-service/internal/entity"
+	ulid "payslip-generator-service/pkg/database/gorm"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ToilEntryRepository struct {
+	Repository[entity.ToilEntry]
+	Log *logrus.Logger
+}
+
+func NewToilEntryRepository(log *logrus.Logger) *ToilEntryRepository {
+	return &ToilEntryRepository{
+		Log: log,
+	}
+}
+
+// FindAllByEmployeeId returns the time-off-in-lieu ledger of the employee in the order the entries were recorded
+func (a *ToilEntryRepository) FindAllByEmployeeId(db *gorm.DB, employeeID ulid.ULID) (entity.ToilLedger, error) {
+	var ledger entity.ToilLedger
+
+	err := db.Debug().
+		Where("employee_id = ?", employeeID).
+		Order("created_at ASC, id ASC").
+		Find(&ledger).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ledger, nil
+}
+
+// CreateExpiry records the expiry of an accrual unless its expiry is already recorded, reporting whether it was
+func (a *ToilEntryRepository) CreateExpiry(db *gorm.DB, expiry *entity.ToilEntry) (bool, error) {
+	result := db.Debug().
+		Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "accrual_id"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "type = 'expiry'"}}},
+			DoNothing:   true,
+		}).
+		Create(expiry)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"payslip-generator-service/internal/entity"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type WorkScheduleRepository struct {
+	Repository[entity.WorkSchedule]
+	Log *logrus.Logger
+}
+
+func NewWorkScheduleRepository(log *logrus.Logger) *WorkScheduleRepository {
+	return &WorkScheduleRepository{
+		Log: log,
+	}
+}
+
+func (r *WorkScheduleRepository) FindAllOrderByName(db *gorm.DB) ([]entity.WorkSchedule, error) {
+	var schedules []entity.WorkSchedule
+	if err := db.Debug().Order("name ASC").Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
@@ -10,4 +10,49 @@ func (a *Route) SetupAttendanceRoute() {
 
 	a.App.Post("/v1/attendance", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.AttendanceHandler.Create)
 	a.Log.Info("mapped {/v1/attendance, POST} route")
+
+	a.App.Get("/v1/attendance", a.AuthMiddleware, a.AttendanceHandler.List)
+	a.Log.Info("mapped {/v1/attendance, GET} route")
+
+	a.App.Post("/v1/attendance/check-in", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.AttendanceHandler.CheckIn)
+	a.Log.Info("mapped {/v1/attendance/check-in, POST} route")
+
+	a.App.Post("/v1/attendance/check-out", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.AttendanceHandler.CheckOut)
+	a.Log.Info("mapped {/v1/attendance/check-out, POST} route")
+
+	a.App.Post("/v1/attendance/break/start", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.AttendanceHandler.StartBreak)
+	a.Log.Info("mapped {/v1/attendance/break/start, POST} route")
+
+	a.App.Post("/v1/attendance/break/end", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.AttendanceHandler.EndBreak)
+	a.Log.Info("mapped {/v1/attendance/break/end, POST} route")
+
+	a.App.Get("/v1/attendance/session", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.AttendanceHandler.GetSession)
+	a.Log.Info("mapped {/v1/attendance/session, GET} route")
+
+	a.App.Post("/v1/attendance/correction", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.AttendanceHandler.CreateCorrection)
+	a.Log.Info("mapped {/v1/attendance/correction, POST} route")
+
+	a.App.Put("/v1/attendance/correction/:id", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.AttendanceHandler.AmendCorrection)
+	a.Log.Info("mapped {/v1/attendance/correction/:id, PUT} route")
+
+	a.App.Post("/v1/attendance/correction/:id/void", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.AttendanceHandler.VoidCorrection)
+	a.Log.Info("mapped {/v1/attendance/correction/:id/void, POST} route")
+
+	a.App.Post("/v1/attendance/correction-request", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.AttendanceHandler.SubmitCorrectionRequest)
+	a.Log.Info("mapped {/v1/attendance/correction-request, POST} route")
+
+	a.App.Get("/v1/attendance/correction-request", a.AuthMiddleware, a.AttendanceHandler.ListCorrectionRequest)
+	a.Log.Info("mapped {/v1/attendance/correction-request, GET} route")
+
+	a.App.Post("/v1/attendance/correction-request/:id/approve", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.AttendanceHandler.ApproveCorrectionRequest)
+	a.Log.Info("mapped {/v1/attendance/correction-request/:id/approve, POST} route")
+
+	a.App.Post("/v1/attendance/correction-request/:id/reject", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.AttendanceHandler.RejectCorrectionRequest)
+	a.Log.Info("mapped {/v1/attendance/correction-request/:id/reject, POST} route")
+
+	a.App.Post("/v1/attendance/import", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.AttendanceHandler.Import)
+	a.Log.Info("mapped {/v1/attendance/import, POST} route")
+
+	a.App.Get("/v1/attendance/analytics", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.AttendanceHandler.GetAnalytics)
+	a.Log.Info("mapped {/v1/attendance/analytics, GET} route")
 }
@@ -0,0 +1,25 @@
+package route
+
+import (
+	"payslip-generator-service/internal/middleware"
+	"payslip-generator-service/internal/model"
+)
+
+func (a *Route) SetupEmployeeRoute() {
+	a.Log.Info("setting up employee routes")
+
+	a.App.Put("/v1/employee/:id/timezone", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.EmployeeHandler.UpdateTimezone)
+	a.Log.Info("mapped {/v1/employee/:id/timezone, PUT} route")
+
+	a.App.Put("/v1/employee/:id/device-user", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.EmployeeHandler.UpdateDeviceUser)
+	a.Log.Info("mapped {/v1/employee/:id/device-user, PUT} route")
+
+	a.App.Put("/v1/employee/:id/manager", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.EmployeeHandler.UpdateManager)
+	a.Log.Info("mapped {/v1/employee/:id/manager, PUT} route")
+
+	a.App.Post("/v1/employee/:id/overtime-eligibility", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.EmployeeHandler.UpdateOvertimeEligibility)
+	a.Log.Info("mapped {/v1/employee/:id/overtime-eligibility, POST} route")
+
+	a.App.Get("/v1/employee/:id/overtime-eligibility", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.EmployeeHandler.ListOvertimeEligibility)
+	a.Log.Info("mapped {/v1/employee/:id/overtime-eligibility, GET} route")
+}
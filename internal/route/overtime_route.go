@@ -10,4 +10,52 @@ func (a *Route) SetupOvertimeRoute() {
 
 	a.App.Post("/v1/overtime", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.OvertimeHandler.Create)
 	a.Log.Info("mapped {/v1/overtime, POST} route")
+
+	a.App.Get("/v1/overtime", a.AuthMiddleware, a.OvertimeHandler.List)
+	a.Log.Info("mapped {/v1/overtime, GET} route")
+
+	a.App.Get("/v1/overtime/usage", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.OvertimeHandler.GetUsage)
+	a.Log.Info("mapped {/v1/overtime/usage, GET} route")
+
+	a.App.Get("/v1/overtime/toil", a.AuthMiddleware, a.OvertimeHandler.GetToilBalance)
+	a.Log.Info("mapped {/v1/overtime/toil, GET} route")
+
+	a.App.Post("/v1/overtime/toil/usage", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.OvertimeHandler.RecordToilUsage)
+	a.Log.Info("mapped {/v1/overtime/toil/usage, POST} route")
+
+	a.App.Get("/v1/overtime/approval", a.AuthMiddleware, a.OvertimeHandler.ListForApproval)
+	a.Log.Info("mapped {/v1/overtime/approval, GET} route")
+
+	a.App.Get("/v1/overtime/reconciliation", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.OvertimeHandler.Reconcile)
+	a.Log.Info("mapped {/v1/overtime/reconciliation, GET} route")
+
+	a.App.Post("/v1/overtime/:id/approve", a.AuthMiddleware, a.OvertimeHandler.Approve)
+	a.Log.Info("mapped {/v1/overtime/:id/approve, POST} route")
+
+	a.App.Post("/v1/overtime/:id/reject", a.AuthMiddleware, a.OvertimeHandler.Reject)
+	a.Log.Info("mapped {/v1/overtime/:id/reject, POST} route")
+
+	a.App.Post("/v1/overtime/:id/cancel", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.OvertimeHandler.Cancel)
+	a.Log.Info("mapped {/v1/overtime/:id/cancel, POST} route")
+
+	a.App.Put("/v1/overtime/:id/paid-hours", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.OvertimeHandler.OverridePaidHours)
+	a.Log.Info("mapped {/v1/overtime/:id/paid-hours, PUT} route")
+
+	a.App.Post("/v1/overtime/plan", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.OvertimeHandler.CreatePlan)
+	a.Log.Info("mapped {/v1/overtime/plan, POST} route")
+
+	a.App.Get("/v1/overtime/plan", a.AuthMiddleware, a.OvertimeHandler.ListPlans)
+	a.Log.Info("mapped {/v1/overtime/plan, GET} route")
+
+	a.App.Post("/v1/overtime/plan/:id/approve", a.AuthMiddleware, a.OvertimeHandler.ApprovePlan)
+	a.Log.Info("mapped {/v1/overtime/plan/:id/approve, POST} route")
+
+	a.App.Post("/v1/overtime/plan/:id/reject", a.AuthMiddleware, a.OvertimeHandler.RejectPlan)
+	a.Log.Info("mapped {/v1/overtime/plan/:id/reject, POST} route")
+
+	a.App.Post("/v1/overtime/plan/:id/cancel", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.OvertimeHandler.CancelPlan)
+	a.Log.Info("mapped {/v1/overtime/plan/:id/cancel, POST} route")
+
+	a.App.Post("/v1/overtime/plan/:id/confirm", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.OvertimeHandler.ConfirmPlan)
+	a.Log.Info("mapped {/v1/overtime/plan/:id/confirm, POST} route")
 }
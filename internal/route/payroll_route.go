@@ -20,6 +20,21 @@ func (a *Route) SetupPayrollRoute() {
 	a.App.Get("/v1/payroll/payslip", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.PayrollHandler.GetPayslip)
 	a.Log.Info("mapped {/v1/payroll/payslip, GET} route")
 
+	a.App.Get("/v1/payroll/payslips", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.PayrollHandler.ListPayslip)
+	a.Log.Info("mapped {/v1/payroll/payslips, GET} route")
+
+	a.App.Get("/v1/payroll/payslip/pdf", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.PayrollHandler.GetPayslipPDF)
+	a.Log.Info("mapped {/v1/payroll/payslip/pdf, GET} route")
+
+	a.App.Get("/v1/payroll/payslip/explain", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.PayrollHandler.ExplainPayslip)
+	a.Log.Info("mapped {/v1/payroll/payslip/explain, GET} route")
+
+	a.App.Get("/v1/payroll/payslip/estimate", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.PayrollHandler.GetPayslipEstimate)
+	a.Log.Info("mapped {/v1/payroll/payslip/estimate, GET} route")
+
 	a.App.Get("/v1/payroll/payslip/report", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.PayrollHandler.GetPayslipReport)
 	a.Log.Info("mapped {/v1/payroll/payslip/report, GET} route")
+
+	a.App.Get("/v1/payroll/journal", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.PayrollHandler.GetJournal)
+	a.Log.Info("mapped {/v1/payroll/journal, GET} route")
 }
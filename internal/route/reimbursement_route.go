@@ -10,4 +10,28 @@ func (a *Route) SetupReimbursementRoute() {
 
 	a.App.Post("/v1/reimbursement", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.ReimbursementHandler.Create)
 	a.Log.Info("mapped {/v1/reimbursement, POST} route")
+
+	a.App.Get("/v1/reimbursement", a.AuthMiddleware, a.ReimbursementHandler.List)
+	a.Log.Info("mapped {/v1/reimbursement, GET} route")
+
+	a.App.Get("/v1/reimbursement/approval", a.AuthMiddleware, a.ReimbursementHandler.ListForApproval)
+	a.Log.Info("mapped {/v1/reimbursement/approval, GET} route")
+
+	a.App.Post("/v1/reimbursement/:id/approve", a.AuthMiddleware, a.ReimbursementHandler.Approve)
+	a.Log.Info("mapped {/v1/reimbursement/:id/approve, POST} route")
+
+	a.App.Post("/v1/reimbursement/:id/reject", a.AuthMiddleware, a.ReimbursementHandler.Reject)
+	a.Log.Info("mapped {/v1/reimbursement/:id/reject, POST} route")
+
+	a.App.Post("/v1/reimbursement/:id/resubmit", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleEmployee), a.ReimbursementHandler.Resubmit)
+	a.Log.Info("mapped {/v1/reimbursement/:id/resubmit, POST} route")
+
+	a.App.Get("/v1/reimbursement/:id/review", a.AuthMiddleware, a.ReimbursementHandler.ListReviews)
+	a.Log.Info("mapped {/v1/reimbursement/:id/review, GET} route")
+
+	a.App.Get("/v1/reimbursement/:id/receipt", a.AuthMiddleware, a.ReimbursementHandler.ListReceipts)
+	a.Log.Info("mapped {/v1/reimbursement/:id/receipt, GET} route")
+
+	a.App.Get("/v1/reimbursement/:id/receipt/:receipt_id", a.AuthMiddleware, a.ReimbursementHandler.GetReceipt)
+	a.Log.Info("mapped {/v1/reimbursement/:id/receipt/:receipt_id, GET} route")
 }
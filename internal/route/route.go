@@ -16,6 +16,8 @@ type Route struct {
 	AttendanceHandler    *handler.AttendanceHandler
 	OvertimeHandler      *handler.OvertimeHandler
 	PayrollHandler       *handler.PayrollHandler
+	WorkScheduleHandler  *handler.WorkScheduleHandler
+	EmployeeHandler      *handler.EmployeeHandler
 }
 
 func NewRoute(
@@ -27,6 +29,8 @@ func NewRoute(
 	attendanceHandler *handler.AttendanceHandler,
 	overtimeHandler *handler.OvertimeHandler,
 	payrollHandler *handler.PayrollHandler,
+	workScheduleHandler *handler.WorkScheduleHandler,
+	employeeHandler *handler.EmployeeHandler,
 ) *Route {
 	return &Route{
 		App:                  app,
@@ -37,6 +41,8 @@ func NewRoute(
 		AttendanceHandler:    attendanceHandler,
 		OvertimeHandler:      overtimeHandler,
 		PayrollHandler:       payrollHandler,
+		WorkScheduleHandler:  workScheduleHandler,
+		EmployeeHandler:      employeeHandler,
 	}
 }
 
@@ -48,5 +54,7 @@ func (a *Route) Setup() {
 	a.SetupAttendanceRoute()
 	a.SetupOvertimeRoute()
 	a.SetupPayrollRoute()
+	a.SetupWorkScheduleRoute()
+	a.SetupEmployeeRoute()
 	a.SetupSwaggerRoute()
 }
@@ -0,0 +1,22 @@
+package route
+
+import (
+	"payslip-generator-service/internal/middleware"
+	"payslip-generator-service/internal/model"
+)
+
+func (a *Route) SetupWorkScheduleRoute() {
+	a.Log.Info("setting up work schedule routes")
+
+	a.App.Get("/v1/work-schedule", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.WorkScheduleHandler.List)
+	a.Log.Info("mapped {/v1/work-schedule, GET} route")
+
+	a.App.Post("/v1/work-schedule", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.WorkScheduleHandler.Create)
+	a.Log.Info("mapped {/v1/work-schedule, POST} route")
+
+	a.App.Post("/v1/work-schedule/assign", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.WorkScheduleHandler.Assign)
+	a.Log.Info("mapped {/v1/work-schedule/assign, POST} route")
+
+	a.App.Put("/v1/work-schedule/:id", a.AuthMiddleware, middleware.RoleMiddleware(model.RoleAdmin), a.WorkScheduleHandler.Update)
+	a.Log.Info("mapped {/v1/work-schedule/:id, PUT} route")
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payslip-generator-service/internal/entity"
+	"payslip-generator-service/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StartBreak puts the checked-in employee on a break, the break ends on EndBreak or with the check-out
+func (a *AttendanceUseCase) StartBreak(ctx context.Context, request *model.StartBreakRequest, auth *model.Auth) (*entity.Attendance, error) {
+	method := "AttendanceUseCase.StartBreak"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+	now := time.Now().In(auth.Location)
+
+	a.closeStaleSession(ctx, auth.ID, now)
+
+	attendance, err := a.AttendanceRepository.FindOpenByEmployee(db, auth.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("attendance/not-checked-in")
+		}
+		panic(err)
+	}
+
+	if attendance.OpenBreak() != nil {
+		return nil, fmt.Errorf("attendance/already-on-break")
+	}
+
+	b := entity.NewAttendanceBreak(&entity.CreateAttendanceBreakProps{
+		AttendanceID: attendance.ID,
+		StartTime:    now,
+		Paid:         request.Paid,
+		CreatedBy:    auth.ID,
+	})
+
+	if err := a.BreakRepository.Create(db, b); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fmt.Errorf("attendance/already-on-break")
+		}
+		panic(err)
+	}
+	attendance.Breaks = append(attendance.Breaks, *b)
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return attendance, nil
+}
+
+// EndBreak ends the break the checked-in employee is on
+func (a *AttendanceUseCase) EndBreak(ctx context.Context, auth *model.Auth) (*entity.Attendance, error) {
+	method := "AttendanceUseCase.EndBreak"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	db := a.DB.WithContext(ctx)
+	now := time.Now().In(auth.Location)
+
+	a.closeStaleSession(ctx, auth.ID, now)
+
+	attendance, err := a.AttendanceRepository.FindOpenByEmployee(db, auth.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("attendance/not-checked-in")
+		}
+		panic(err)
+	}
+
+	b := attendance.OpenBreak()
+	if b == nil {
+		return nil, fmt.Errorf("attendance/not-on-break")
+	}
+
+	b.End(now, auth.ID)
+	if err := a.BreakRepository.Update(db, b); err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return attendance, nil
+}
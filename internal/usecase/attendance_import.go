@@ -0,0 +1,378 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"payslip-generator-service/internal/entity"
+	"payslip-generator-service/internal/model"
+	"payslip-generator-service/pkg/timezone"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	ulid "payslip-generator-service/pkg/database/gorm"
+
+	"gorm.io/gorm"
+)
+
+// errImportDryRun rolls back the import transaction of a dry run
+var errImportDryRun = errors.New("attendance import dry run")
+
+type punchDirection int
+
+const (
+	punchUnknown punchDirection = iota
+	punchIn
+	punchOut
+)
+
+// punch is a single row of a terminal punch log
+type punch struct {
+	Row          int
+	DeviceUserID string
+	Timestamp    string
+	Value        string
+	Employee     *entity.Employee
+	Time         time.Time
+	Direction    punchDirection
+}
+
+// punchPair is a check-in and check-out pair that becomes an attendance
+type punchPair struct {
+	Employee *entity.Employee
+	In       *punch
+	Out      *punch
+}
+
+// punchMapping locates the punch fields in the rows of a punch log
+type punchMapping struct {
+	DeviceUserID int
+	Timestamp    int
+	// Direction is -1 when the file has no direction column
+	Direction int
+	Layout    string
+	InValues  map[string]bool
+	OutValues map[string]bool
+}
+
+// Import pairs the in and out punches of a terminal punch log into attendance records. Each pair is recorded like
+// an admin correction, so it follows the same schedule, payroll period and one-attendance-per-day rules
+func (a *AttendanceUseCase) Import(
+	ctx context.Context,
+	request *model.ImportAttendanceRequest,
+	auth *model.Auth,
+) (*model.ImportAttendanceResponse, error) {
+	method := "AttendanceUseCase.Import"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("file", request.File.Filename).WithField("dry_run", request.DryRun).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	if request.File.Size > int64(a.Config.Attendance.Import.MaxFileSize) {
+		return nil, fmt.Errorf("attendance/import-file-too-large")
+	}
+
+	file, err := request.File.Open()
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+
+	punches, mapping, err := a.readPunches(file, request)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &model.ImportAttendanceResponse{
+		DryRun:      request.DryRun,
+		TotalRows:   len(punches),
+		Attendances: make([]entity.Attendance, 0),
+		Rejections:  make([]model.ImportAttendanceRejection, 0),
+	}
+	reject := func(reason string, punches ...*punch) {
+		rows := make([]int, len(punches))
+		for i, p := range punches {
+			rows[i] = p.Row
+		}
+		response.Rejections = append(response.Rejections, model.ImportAttendanceRejection{
+			Rows:         rows,
+			DeviceUserID: punches[0].DeviceUserID,
+			Reason:       reason,
+		})
+		response.RejectedRows += len(punches)
+	}
+
+	valid := a.resolvePunches(db, punches, mapping, reject)
+	pairs := pairPunches(valid, mapping.Direction >= 0, reject)
+
+	source := &correctionSource{
+		Reason:    fmt.Sprintf("Imported from %s", request.File.Filename),
+		CreatedBy: auth.ID,
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, pair := range pairs {
+			attendance, err := a.createCorrection(tx, pair.Employee.ID, pair.In.Time, pair.Out.Time, source)
+			if err != nil {
+				reject(err.Error(), pair.In, pair.Out)
+				continue
+			}
+			response.Attendances = append(response.Attendances, *attendance)
+		}
+
+		if request.DryRun {
+			return errImportDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportDryRun) {
+		panic(err)
+	}
+
+	response.ImportedCount = len(response.Attendances)
+	sort.SliceStable(response.Rejections, func(i, j int) bool {
+		return response.Rejections[i].Rows[0] < response.Rejections[j].Rows[0]
+	})
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return response, nil
+}
+
+// readPunches reads the rows of the punch log with the column mapping of the request,
+// falling back to the configured mapping
+func (a *AttendanceUseCase) readPunches(file io.Reader, request *model.ImportAttendanceRequest) ([]*punch, *punchMapping, error) {
+	defaults := a.Config.Attendance.Import
+	orDefault := func(value, fallback string) string {
+		if value != "" {
+			return value
+		}
+		return fallback
+	}
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+	if delimiter := orDefault(request.Delimiter, defaults.Delimiter); delimiter != "" {
+		reader.Comma = []rune(delimiter)[0]
+	}
+
+	var header []string
+	if request.HasHeader {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("attendance/import-empty-file")
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("attendance/import-invalid-file")
+		}
+		header = record
+	}
+
+	mapping := &punchMapping{
+		Direction: -1,
+		Layout:    orDefault(request.TimestampLayout, defaults.TimestampLayout),
+		InValues:  directionValues(request.InValues, defaults.InValues),
+		OutValues: directionValues(request.OutValues, defaults.OutValues),
+	}
+
+	var ok bool
+	if mapping.DeviceUserID, ok = resolveColumn(header, orDefault(request.DeviceUserIDColumn, defaults.DeviceUserIDColumn)); !ok {
+		return nil, nil, fmt.Errorf("attendance/import-unknown-column")
+	}
+	if mapping.Timestamp, ok = resolveColumn(header, orDefault(request.TimestampColumn, defaults.TimestampColumn)); !ok {
+		return nil, nil, fmt.Errorf("attendance/import-unknown-column")
+	}
+	if column := orDefault(request.DirectionColumn, defaults.DirectionColumn); column != "" {
+		if mapping.Direction, ok = resolveColumn(header, column); !ok {
+			return nil, nil, fmt.Errorf("attendance/import-unknown-column")
+		}
+	}
+
+	punches := make([]*punch, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("attendance/import-invalid-file")
+		}
+
+		row, _ := reader.FieldPos(0)
+		field := func(column int) string {
+			if column < 0 || column >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[column])
+		}
+
+		punches = append(punches, &punch{
+			Row:          row,
+			DeviceUserID: field(mapping.DeviceUserID),
+			Timestamp:    field(mapping.Timestamp),
+			Value:        field(mapping.Direction),
+		})
+	}
+
+	if len(punches) == 0 {
+		return nil, nil, fmt.Errorf("attendance/import-empty-file")
+	}
+
+	return punches, mapping, nil
+}
+
+// resolvePunches matches the punches to employees and parses their time in the employee's time zone,
+// rejecting the punches that cannot be read
+func (a *AttendanceUseCase) resolvePunches(db *gorm.DB, punches []*punch, mapping *punchMapping, reject func(string, ...*punch)) []*punch {
+	deviceUserIDs := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, p := range punches {
+		if p.DeviceUserID != "" && !seen[p.DeviceUserID] {
+			seen[p.DeviceUserID] = true
+			deviceUserIDs = append(deviceUserIDs, p.DeviceUserID)
+		}
+	}
+
+	employees := make(map[string]*entity.Employee, len(deviceUserIDs))
+	if len(deviceUserIDs) > 0 {
+		found, err := a.EmployeeRepository.FindAllByDeviceUserIds(db, deviceUserIDs)
+		if err != nil {
+			panic(err)
+		}
+		for i := range found {
+			employees[*found[i].DeviceUserID] = &found[i]
+		}
+	}
+
+	valid := make([]*punch, 0, len(punches))
+	for _, p := range punches {
+		if p.DeviceUserID == "" || p.Timestamp == "" {
+			reject("attendance/import-missing-value", p)
+			continue
+		}
+
+		employee, ok := employees[p.DeviceUserID]
+		if !ok {
+			reject("attendance/import-unknown-device-user", p)
+			continue
+		}
+
+		t, err := time.ParseInLocation(mapping.Layout, p.Timestamp, employee.GetLocation(a.Config.App.TimeZone))
+		if err != nil {
+			reject("attendance/import-invalid-timestamp", p)
+			continue
+		}
+
+		if mapping.Direction >= 0 {
+			switch value := strings.ToLower(p.Value); {
+			case mapping.InValues[value]:
+				p.Direction = punchIn
+			case mapping.OutValues[value]:
+				p.Direction = punchOut
+			default:
+				reject("attendance/import-invalid-direction", p)
+				continue
+			}
+		}
+
+		p.Employee = employee
+		p.Time = t
+		valid = append(valid, p)
+	}
+
+	return valid
+}
+
+// pairPunches pairs the punches of each employee in time order. With a direction every check-in is closed by the
+// next check-out, otherwise the first and last punch of each day are paired and the punches in between are dropped
+func pairPunches(punches []*punch, hasDirection bool, reject func(string, ...*punch)) []punchPair {
+	byEmployee := make(map[ulid.ULID][]*punch)
+	employeeIDs := make([]ulid.ULID, 0)
+	for _, p := range punches {
+		if _, ok := byEmployee[p.Employee.ID]; !ok {
+			employeeIDs = append(employeeIDs, p.Employee.ID)
+		}
+		byEmployee[p.Employee.ID] = append(byEmployee[p.Employee.ID], p)
+	}
+
+	pairs := make([]punchPair, 0)
+	for _, id := range employeeIDs {
+		employeePunches := byEmployee[id]
+		sort.SliceStable(employeePunches, func(i, j int) bool {
+			return employeePunches[i].Time.Before(employeePunches[j].Time)
+		})
+
+		if hasDirection {
+			var open *punch
+			for _, p := range employeePunches {
+				switch {
+				case p.Direction == punchIn && open != nil:
+					reject("attendance/import-missing-check-out", open)
+					open = p
+				case p.Direction == punchIn:
+					open = p
+				case open == nil:
+					reject("attendance/import-missing-check-in", p)
+				default:
+					pairs = append(pairs, punchPair{Employee: p.Employee, In: open, Out: p})
+					open = nil
+				}
+			}
+			if open != nil {
+				reject("attendance/import-missing-check-out", open)
+			}
+			continue
+		}
+
+		for i := 0; i < len(employeePunches); {
+			first := employeePunches[i]
+			day := timezone.DateOf(first.Time, first.Time.Location())
+
+			j := i
+			for j+1 < len(employeePunches) && timezone.DateOf(employeePunches[j+1].Time, first.Time.Location()).Equal(day) {
+				j++
+			}
+
+			if i == j {
+				reject("attendance/import-unpaired-punch", first)
+			} else {
+				pairs = append(pairs, punchPair{Employee: first.Employee, In: first, Out: employeePunches[j]})
+			}
+			i = j + 1
+		}
+	}
+
+	return pairs
+}
+
+// resolveColumn finds a column by its name in the header, or by its 1-based number
+func resolveColumn(header []string, column string) (int, bool) {
+	column = strings.TrimSpace(column)
+	if number, err := strconv.Atoi(column); err == nil {
+		return number - 1, number >= 1
+	}
+
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), column) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// directionValues returns the lower-cased direction values of the request, falling back to the configured values
+func directionValues(values string, defaults []string) map[string]bool {
+	list := defaults
+	if values != "" {
+		list = strings.Split(values, ",")
+	}
+
+	set := make(map[string]bool, len(list))
+	for _, value := range list {
+		set[strings.ToLower(strings.TrimSpace(value))] = true
+	}
+	return set
+}
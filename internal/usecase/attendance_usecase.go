@@ -2,33 +2,65 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"payslip-generator-service/config"
 	"payslip-generator-service/internal/entity"
 	"payslip-generator-service/internal/model"
 	"payslip-generator-service/internal/repository"
+	"payslip-generator-service/internal/vm"
+	"payslip-generator-service/pkg/geofence"
 	"payslip-generator-service/pkg/logger"
 	"time"
 
 	ulid "payslip-generator-service/pkg/database/gorm"
 
+	v2 "github.com/oklog/ulid/v2"
 	"gorm.io/gorm"
 )
 
+// maxAnalyticsDays is the longest date range of the attendance analytics
+const maxAnalyticsDays = 366
+
+// attendanceLocationPolicyReject rejects check-ins outside every office instead of marking them remote
+const attendanceLocationPolicyReject = "reject"
+
 type AttendanceUseCase struct {
-	DB                   *gorm.DB
-	Log                  *logger.ContextLogger
-	AttendanceRepository *repository.AttendanceRepository
+	DB                      *gorm.DB
+	Log                     *logger.ContextLogger
+	Config                  *config.Config
+	AttendanceRepository    *repository.AttendanceRepository
+	BreakRepository         *repository.AttendanceBreakRepository
+	RevisionRepository      *repository.AttendanceRevisionRepository
+	CorrectionRepository    *repository.AttendanceCorrectionRequestRepository
+	EmployeeRepository      *repository.EmployeeRepository
+	WorkScheduleRepository  *repository.WorkScheduleRepository
+	PayrollPeriodRepository *repository.PayrollPeriodRepository
 }
 
 func NewAttendanceUseCase(
 	db *gorm.DB,
 	log *logger.ContextLogger,
+	config *config.Config,
 	attendanceRepository *repository.AttendanceRepository,
+	breakRepository *repository.AttendanceBreakRepository,
+	revisionRepository *repository.AttendanceRevisionRepository,
+	correctionRepository *repository.AttendanceCorrectionRequestRepository,
+	employeeRepository *repository.EmployeeRepository,
+	workScheduleRepository *repository.WorkScheduleRepository,
+	payrollPeriodRepository *repository.PayrollPeriodRepository,
 ) *AttendanceUseCase {
 	return &AttendanceUseCase{
-		DB:                   db,
-		Log:                  log,
-		AttendanceRepository: attendanceRepository,
+		DB:                      db,
+		Log:                     log,
+		Config:                  config,
+		AttendanceRepository:    attendanceRepository,
+		BreakRepository:         breakRepository,
+		RevisionRepository:      revisionRepository,
+		CorrectionRepository:    correctionRepository,
+		EmployeeRepository:      employeeRepository,
+		WorkScheduleRepository:  workScheduleRepository,
+		PayrollPeriodRepository: payrollPeriodRepository,
 	}
 }
 
@@ -55,23 +87,47 @@ func (a *AttendanceUseCase) Create(
 
 	attendance := entity.NewAttendance(&entity.CreateAttendanceProps{
 		StartTime: startTime,
-		EndTime:   endTime,
+		EndTime:   &endTime,
+		Location:  auth.Location,
 		CreatedBy: auth.ID,
 	})
 
-	if !attendance.IsSameDay() {
-		return fmt.Errorf("attendance/must-same-day")
-	} else if !attendance.IsWeekday() {
-		return fmt.Errorf("attendance/must-weekday")
+	schedule := a.resolveWorkSchedule(db, auth.ID)
+	if err := validateSchedule(attendance, schedule); err != nil {
+		return err
 	} else if !attendance.IsEndTimeGreaterThanStartTime() {
 		return fmt.Errorf("attendance/invalid-time-order")
-	} else if !attendance.IsToday() {
+	} else if !attendance.IsCurrentShift(schedule, time.Now()) {
 		return fmt.Errorf("attendance/must-today")
 	}
 
+	for _, b := range request.Breaks {
+		breakStart, breakEnd, err := parseAttendanceTimes(b.StartTime, b.EndTime)
+		if err != nil {
+			return fmt.Errorf("attendance/invalid-break")
+		}
+		breakEnd = breakEnd.In(auth.Location)
+		attendance.Breaks = append(attendance.Breaks, *entity.NewAttendanceBreak(&entity.CreateAttendanceBreakProps{
+			AttendanceID: attendance.ID,
+			StartTime:    breakStart.In(auth.Location),
+			EndTime:      &breakEnd,
+			Paid:         b.Paid,
+			CreatedBy:    auth.ID,
+		}))
+	}
+	if !attendance.HasValidBreaks() {
+		return fmt.Errorf("attendance/invalid-break")
+	}
+
+	origin, err := a.resolveOrigin(ctx, request.Latitude, request.Longitude, request.IPAddress)
+	if err != nil {
+		return err
+	}
+	attendance.Locate(origin)
+
 	a.Log.WithContext(ctx).Debug("attendance - ", method, attendance)
 
-	todayAttendance, err := a.AttendanceRepository.FindByDate(db, attendance.StartTime)
+	todayAttendance, err := a.AttendanceRepository.FindByDate(db, auth.ID, attendance.StartTime)
 	if err != nil && err != gorm.ErrRecordNotFound {
 		panic(err)
 	}
@@ -81,6 +137,9 @@ func (a *AttendanceUseCase) Create(
 	}
 
 	if err := a.AttendanceRepository.Create(db, attendance); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return fmt.Errorf("attendance/already-exists")
+		}
 		panic(err)
 	}
 
@@ -89,6 +148,195 @@ func (a *AttendanceUseCase) Create(
 	return nil
 }
 
+func (a *AttendanceUseCase) CheckIn(ctx context.Context, request *model.CheckInRequest, auth *model.Auth) (*entity.Attendance, error) {
+	method := "AttendanceUseCase.CheckIn"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+	now := time.Now().In(auth.Location)
+
+	a.closeStaleSession(ctx, auth.ID, now)
+
+	attendance := entity.NewAttendance(&entity.CreateAttendanceProps{
+		StartTime: now,
+		Location:  auth.Location,
+		CreatedBy: auth.ID,
+	})
+
+	if !attendance.IsWorkDay(a.resolveWorkSchedule(db, auth.ID)) {
+		return nil, fmt.Errorf("attendance/not-work-day")
+	}
+
+	todayAttendance, err := a.AttendanceRepository.FindByDate(db, auth.ID, now)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		panic(err)
+	}
+
+	if todayAttendance != nil {
+		if todayAttendance.IsOpen() {
+			return nil, fmt.Errorf("attendance/already-checked-in")
+		}
+		return nil, fmt.Errorf("attendance/already-exists")
+	}
+
+	origin, err := a.resolveOrigin(ctx, request.Latitude, request.Longitude, request.IPAddress)
+	if err != nil {
+		return nil, err
+	}
+	attendance.Locate(origin)
+
+	if err := a.AttendanceRepository.Create(db, attendance); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fmt.Errorf("attendance/already-exists")
+		}
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return attendance, nil
+}
+
+func (a *AttendanceUseCase) CheckOut(ctx context.Context, auth *model.Auth) (*entity.Attendance, error) {
+	method := "AttendanceUseCase.CheckOut"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	db := a.DB.WithContext(ctx)
+	now := time.Now().In(auth.Location)
+
+	a.closeStaleSession(ctx, auth.ID, now)
+
+	attendance, err := a.AttendanceRepository.FindOpenByEmployee(db, auth.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("attendance/not-checked-in")
+		}
+		panic(err)
+	}
+
+	closedBreak := attendance.OpenBreak()
+	attendance.CheckOut(now, false, auth.ID)
+	if !attendance.IsEndTimeGreaterThanStartTime() {
+		return nil, fmt.Errorf("attendance/invalid-time-order")
+	}
+
+	a.saveCheckOut(db, attendance, closedBreak)
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return attendance, nil
+}
+
+// GetOpenSession returns the session the employee is checked in to, or nil when checked out
+func (a *AttendanceUseCase) GetOpenSession(ctx context.Context, auth *model.Auth) (*entity.Attendance, error) {
+	method := "AttendanceUseCase.GetOpenSession"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	db := a.DB.WithContext(ctx)
+
+	a.closeStaleSession(ctx, auth.ID, time.Now().In(auth.Location))
+
+	attendance, err := a.AttendanceRepository.FindOpenByEmployee(db, auth.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return attendance, nil
+}
+
+// closeStaleSession auto closes a session left open past its shift at the shift end of the employee's schedule,
+// now must be in the employee's time zone
+func (a *AttendanceUseCase) closeStaleSession(ctx context.Context, employeeID ulid.ULID, now time.Time) {
+	db := a.DB.WithContext(ctx)
+
+	attendance, err := a.AttendanceRepository.FindOpenByEmployee(db, employeeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return
+		}
+		panic(err)
+	}
+
+	a.closeIfStale(ctx, db, attendance, now)
+}
+
+// CloseStaleSessions auto closes the sessions of the employees left open past their shift, so a payroll processed
+// before the employee checks in or out again does not pay an open session
+func (a *AttendanceUseCase) CloseStaleSessions(ctx context.Context, employees []entity.Employee) {
+	method := "AttendanceUseCase.CloseStaleSessions"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	db := a.DB.WithContext(ctx)
+
+	attendances, err := a.AttendanceRepository.FindAllOpen(db)
+	if err != nil {
+		panic(err)
+	}
+
+	locations := make(map[ulid.ULID]*time.Location, len(employees))
+	for _, employee := range employees {
+		locations[employee.ID] = employee.GetLocation(a.Config.App.TimeZone)
+	}
+
+	now := time.Now()
+	for i := range attendances {
+		location, ok := locations[attendances[i].CreatedBy]
+		if !ok {
+			continue
+		}
+		a.closeIfStale(ctx, db, &attendances[i], now.In(location))
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+}
+
+// closeIfStale closes the open session at the shift end when it is stale, now must be in the employee's time zone
+func (a *AttendanceUseCase) closeIfStale(ctx context.Context, db *gorm.DB, attendance *entity.Attendance, now time.Time) {
+	method := "AttendanceUseCase.closeIfStale"
+	employeeID := attendance.CreatedBy
+
+	attendance.In(now.Location())
+	schedule := a.resolveWorkSchedule(db, employeeID)
+	if !attendance.IsStale(schedule, now) {
+		return
+	}
+
+	// checked in after the shift end, close at the end of the last day the shift may end on
+	endTime := schedule.ShiftEndAt(attendance.StartTime)
+	if !endTime.After(attendance.StartTime) {
+		endTime = schedule.LastShiftDay(attendance.StartTime).Add(24*time.Hour - time.Second)
+	}
+
+	// a break started after the shift end is closed as it started, together with the session
+	closedBreak := attendance.OpenBreak()
+	if closedBreak != nil && closedBreak.StartTime.After(endTime) {
+		endTime = closedBreak.StartTime
+	}
+
+	attendance.CheckOut(endTime, true, employeeID)
+	a.saveCheckOut(db, attendance, closedBreak)
+
+	a.Log.WithContext(ctx).WithField("method", method).Info("auto checked out attendance ", attendance.ID, " at ", endTime)
+}
+
+// saveCheckOut saves a checked out attendance together with the break its check-out closed, if any
+func (a *AttendanceUseCase) saveCheckOut(db *gorm.DB, attendance *entity.Attendance, closedBreak *entity.AttendanceBreak) {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if closedBreak != nil {
+			if err := a.BreakRepository.Update(tx, closedBreak); err != nil {
+				return err
+			}
+		}
+		return a.AttendanceRepository.Update(tx, attendance)
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
 func (a *AttendanceUseCase) ListByPeriod(
 	ctx context.Context,
 	employeeID ulid.ULID,
@@ -110,3 +358,796 @@ func (a *AttendanceUseCase) ListByPeriod(
 
 	return attendances, nil
 }
+
+func (a *AttendanceUseCase) List(
+	ctx context.Context,
+	request *model.ListAttendanceRequest,
+	auth *model.Auth,
+) ([]entity.Attendance, int64, error) {
+	method := "AttendanceUseCase.List"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	filter, err := resolveDateRangeFilter(db, a.PayrollPeriodRepository, request.StartDate, request.EndDate, request.PeriodID, request.EmployeeID, auth)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dateScope := filter.Scope("work_date")
+	scope := func(tx *gorm.DB) *gorm.DB {
+		return dateScope(tx).Where("voided_at IS NULL")
+	}
+	data, total, err := a.AttendanceRepository.FindAllWithPagination(db, &model.PaginationOptions{
+		Page:     request.Page,
+		PageSize: request.PageSize,
+		Filter:   &scope,
+		Order: []model.OrderBy{
+			{
+				Column:    "start_time",
+				Direction: model.OrderDirectionDesc,
+			},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	if len(data) > 0 {
+		attendanceIDs := make([]ulid.ULID, len(data))
+		for i := range data {
+			attendanceIDs[i] = data[i].ID
+		}
+		breaks, err := a.BreakRepository.FindAllByAttendanceIds(db, attendanceIDs)
+		if err != nil {
+			panic(err)
+		}
+		for i := range data {
+			for _, b := range breaks {
+				if b.AttendanceID == data[i].ID {
+					data[i].Breaks = append(data[i].Breaks, b)
+				}
+			}
+		}
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return data, total, nil
+}
+
+// CreateCorrection lets an admin record attendance for an employee on a past date
+func (a *AttendanceUseCase) CreateCorrection(
+	ctx context.Context,
+	request *model.CreateAttendanceCorrectionRequest,
+	auth *model.Auth,
+) (*entity.Attendance, error) {
+	method := "AttendanceUseCase.CreateCorrection"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	startTime, endTime, err := parseAttendanceTimes(request.StartTime, request.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	employeeID := ulid.ULID(v2.MustParse(request.EmployeeID))
+	employee := new(entity.Employee)
+	if err := a.EmployeeRepository.FindById(db, employee, employeeID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("employee/not-found")
+		}
+		panic(err)
+	}
+
+	attendance, err := a.createCorrection(db, employeeID, startTime, endTime, &correctionSource{
+		Reason:    request.Reason,
+		CreatedBy: auth.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return attendance, nil
+}
+
+// AmendCorrection lets an admin change the times of an attendance record, keeping the original values
+func (a *AttendanceUseCase) AmendCorrection(
+	ctx context.Context,
+	request *model.AmendAttendanceCorrectionRequest,
+	auth *model.Auth,
+) (*entity.Attendance, error) {
+	method := "AttendanceUseCase.AmendCorrection"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	startTime, endTime, err := parseAttendanceTimes(request.StartTime, request.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	attendance, err := a.findCorrectable(db, ulid.ULID(v2.MustParse(request.ID)))
+	if err != nil {
+		return nil, err
+	}
+
+	attendance, err = a.amendCorrection(db, attendance, startTime, endTime, &correctionSource{
+		Reason:    request.Reason,
+		CreatedBy: auth.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return attendance, nil
+}
+
+// VoidCorrection lets an admin void an attendance record so it is no longer paid
+func (a *AttendanceUseCase) VoidCorrection(
+	ctx context.Context,
+	request *model.VoidAttendanceCorrectionRequest,
+	auth *model.Auth,
+) (*entity.Attendance, error) {
+	method := "AttendanceUseCase.VoidCorrection"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	attendance, err := a.findCorrectable(db, ulid.ULID(v2.MustParse(request.ID)))
+	if err != nil {
+		return nil, err
+	}
+
+	previousStartTime := attendance.StartTime
+	revision := entity.NewAttendanceRevision(&entity.CreateAttendanceRevisionProps{
+		Attendance:        attendance,
+		Action:            entity.AttendanceRevisionActionVoid,
+		PreviousStartTime: &previousStartTime,
+		PreviousEndTime:   attendance.EndTime,
+		Reason:            request.Reason,
+		CreatedBy:         auth.ID,
+	})
+
+	attendance.Void(auth.ID)
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := a.AttendanceRepository.Update(tx, attendance); err != nil {
+			return err
+		}
+		return a.RevisionRepository.Create(tx, revision)
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return attendance, nil
+}
+
+// SubmitCorrectionRequest queues an employee's request to fix a missed or wrong attendance day for admin review
+func (a *AttendanceUseCase) SubmitCorrectionRequest(
+	ctx context.Context,
+	request *model.SubmitAttendanceCorrectionRequest,
+	auth *model.Auth,
+) (*entity.AttendanceCorrectionRequest, error) {
+	method := "AttendanceUseCase.SubmitCorrectionRequest"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	startTime, endTime, err := parseAttendanceTimes(request.StartTime, request.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime = startTime.In(auth.Location)
+	endTime = endTime.In(auth.Location)
+
+	var attendanceID *ulid.ULID
+	if request.AttendanceID != "" {
+		attendance, err := a.findCorrectable(db, ulid.ULID(v2.MustParse(request.AttendanceID)))
+		if err != nil {
+			return nil, err
+		}
+		if attendance.CreatedBy != auth.ID {
+			return nil, fmt.Errorf("attendance/not-found")
+		}
+		attendanceID = &attendance.ID
+	} else {
+		// a request for a day that already has attendance amends that record
+		existing, err := a.AttendanceRepository.FindByDate(db, auth.ID, startTime)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			panic(err)
+		}
+		if existing != nil {
+			attendanceID = &existing.ID
+		}
+	}
+
+	proposed := entity.NewAttendance(&entity.CreateAttendanceProps{
+		StartTime: startTime,
+		EndTime:   &endTime,
+		Location:  auth.Location,
+		CreatedBy: auth.ID,
+	})
+	if err := a.validateCorrection(db, proposed); err != nil {
+		return nil, err
+	}
+
+	pending, err := a.CorrectionRepository.FindPendingByDate(db, auth.ID, startTime)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		panic(err)
+	}
+
+	if pending != nil {
+		return nil, fmt.Errorf("attendance/correction-request-already-pending")
+	}
+
+	correction := entity.NewAttendanceCorrectionRequest(&entity.CreateAttendanceCorrectionRequestProps{
+		AttendanceID: attendanceID,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		Reason:       request.Reason,
+		CreatedBy:    auth.ID,
+	})
+
+	if err := a.CorrectionRepository.Create(db, correction); err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return correction, nil
+}
+
+// ListCorrectionRequest lists correction requests, latest first; employees only see their own
+func (a *AttendanceUseCase) ListCorrectionRequest(
+	ctx context.Context,
+	request *model.ListAttendanceCorrectionRequest,
+	auth *model.Auth,
+) ([]entity.AttendanceCorrectionRequest, int64, error) {
+	method := "AttendanceUseCase.ListCorrectionRequest"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	scope := func(tx *gorm.DB) *gorm.DB {
+		if request.Status != "" {
+			tx = tx.Where("status = ?", request.Status)
+		}
+		if !auth.IsAdmin {
+			tx = tx.Where("created_by = ?", auth.ID)
+		} else if request.EmployeeID != "" {
+			tx = tx.Where("created_by = ?", ulid.ULID(v2.MustParse(request.EmployeeID)))
+		}
+		return tx
+	}
+
+	data, total, err := a.CorrectionRepository.FindAllWithPagination(db, &model.PaginationOptions{
+		Page:     request.Page,
+		PageSize: request.PageSize,
+		Filter:   &scope,
+		Order: []model.OrderBy{
+			{
+				Column:    "created_at",
+				Direction: model.OrderDirectionDesc,
+			},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return data, total, nil
+}
+
+// ApproveCorrectionRequest applies a pending correction request to the employee's attendance
+func (a *AttendanceUseCase) ApproveCorrectionRequest(
+	ctx context.Context,
+	request *model.ReviewAttendanceCorrectionRequest,
+	auth *model.Auth,
+) (*entity.AttendanceCorrectionRequest, error) {
+	method := "AttendanceUseCase.ApproveCorrectionRequest"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	// the request stays locked until it is approved, a concurrent review waits and finds it decided
+	var correction *entity.AttendanceCorrectionRequest
+	err := runTransaction(db, func(tx *gorm.DB) error {
+		var err error
+		correction, err = a.findPendingCorrectionRequest(tx, request.ID)
+		if err != nil {
+			return abort(err)
+		}
+
+		source := &correctionSource{
+			Reason:              correction.Reason,
+			CreatedBy:           auth.ID,
+			CorrectionRequestID: &correction.ID,
+		}
+
+		// helpers panic on database errors, so any error returned here is a rejected correction
+		var attendance *entity.Attendance
+		if correction.AttendanceID != nil {
+			attendance, err = a.findCorrectable(tx, *correction.AttendanceID)
+			if err != nil {
+				return abort(err)
+			}
+			attendance, err = a.amendCorrection(tx, attendance, correction.StartTime, correction.EndTime, source)
+		} else {
+			attendance, err = a.createCorrection(tx, correction.CreatedBy, correction.StartTime, correction.EndTime, source)
+		}
+		if err != nil {
+			return abort(err)
+		}
+
+		correction.Approve(attendance.ID, auth.ID, optionalComment(request.Comment))
+		return a.CorrectionRepository.Update(tx, correction)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return correction, nil
+}
+
+// RejectCorrectionRequest closes a pending correction request without touching attendance
+func (a *AttendanceUseCase) RejectCorrectionRequest(
+	ctx context.Context,
+	request *model.ReviewAttendanceCorrectionRequest,
+	auth *model.Auth,
+) (*entity.AttendanceCorrectionRequest, error) {
+	method := "AttendanceUseCase.RejectCorrectionRequest"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	if request.Comment == "" {
+		return nil, fmt.Errorf("attendance/rejection-comment-required")
+	}
+
+	var correction *entity.AttendanceCorrectionRequest
+	err := runTransaction(db, func(tx *gorm.DB) error {
+		var err error
+		correction, err = a.findPendingCorrectionRequest(tx, request.ID)
+		if err != nil {
+			return abort(err)
+		}
+
+		correction.Reject(auth.ID, &request.Comment)
+		return a.CorrectionRepository.Update(tx, correction)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return correction, nil
+}
+
+// findPendingCorrectionRequest loads a pending correction request and locks its row until the transaction of db ends
+func (a *AttendanceUseCase) findPendingCorrectionRequest(db *gorm.DB, id string) (*entity.AttendanceCorrectionRequest, error) {
+	correction := new(entity.AttendanceCorrectionRequest)
+	if err := a.CorrectionRepository.FindByIdForUpdate(db, correction, ulid.ULID(v2.MustParse(id))); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("attendance/correction-request-not-found")
+		}
+		panic(err)
+	}
+
+	if !correction.IsPending() {
+		return nil, fmt.Errorf("attendance/correction-request-already-reviewed")
+	}
+
+	return correction, nil
+}
+
+func optionalComment(comment string) *string {
+	if comment == "" {
+		return nil
+	}
+	return &comment
+}
+
+// correctionSource describes who made a correction and why
+type correctionSource struct {
+	Reason              string
+	CreatedBy           ulid.ULID
+	CorrectionRequestID *ulid.ULID
+}
+
+// createCorrection records a new attendance for the employee together with its revision
+func (a *AttendanceUseCase) createCorrection(
+	db *gorm.DB,
+	employeeID ulid.ULID,
+	startTime, endTime time.Time,
+	source *correctionSource,
+) (*entity.Attendance, error) {
+	attendance := entity.NewAttendance(&entity.CreateAttendanceProps{
+		StartTime: startTime,
+		EndTime:   &endTime,
+		Location:  a.employeeLocation(db, employeeID),
+		CreatedBy: employeeID,
+	})
+
+	if err := a.validateCorrection(db, attendance); err != nil {
+		return nil, err
+	}
+
+	existing, err := a.AttendanceRepository.FindByDate(db, employeeID, attendance.StartTime)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		panic(err)
+	}
+
+	if existing != nil {
+		return nil, fmt.Errorf("attendance/already-exists")
+	}
+
+	revision := entity.NewAttendanceRevision(&entity.CreateAttendanceRevisionProps{
+		Attendance:          attendance,
+		Action:              entity.AttendanceRevisionActionCreate,
+		Reason:              source.Reason,
+		CreatedBy:           source.CreatedBy,
+		CorrectionRequestID: source.CorrectionRequestID,
+	})
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := a.AttendanceRepository.Create(tx, attendance); err != nil {
+			return err
+		}
+		return a.RevisionRepository.Create(tx, revision)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fmt.Errorf("attendance/already-exists")
+		}
+		panic(err)
+	}
+
+	attendance.Revisions = []entity.AttendanceRevision{*revision}
+
+	return attendance, nil
+}
+
+// amendCorrection changes the times of an attendance and records the original values in a revision
+func (a *AttendanceUseCase) amendCorrection(
+	db *gorm.DB,
+	attendance *entity.Attendance,
+	startTime, endTime time.Time,
+	source *correctionSource,
+) (*entity.Attendance, error) {
+	previousStartTime := attendance.StartTime
+	previousEndTime := attendance.EndTime
+
+	breaks, err := a.BreakRepository.FindAllByAttendanceIds(db, []ulid.ULID{attendance.ID})
+	if err != nil {
+		panic(err)
+	}
+	attendance.Breaks = breaks
+
+	location := a.employeeLocation(db, attendance.CreatedBy)
+	attendance.Update(startTime.In(location), endTime.In(location), source.CreatedBy)
+	if err := a.validateCorrection(db, attendance); err != nil {
+		return nil, err
+	} else if !attendance.HasValidBreaks() {
+		return nil, fmt.Errorf("attendance/breaks-outside-shift")
+	}
+
+	existing, err := a.AttendanceRepository.FindByDate(db, attendance.CreatedBy, attendance.StartTime)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		panic(err)
+	}
+
+	if existing != nil && existing.ID != attendance.ID {
+		return nil, fmt.Errorf("attendance/already-exists")
+	}
+
+	revision := entity.NewAttendanceRevision(&entity.CreateAttendanceRevisionProps{
+		Attendance:          attendance,
+		Action:              entity.AttendanceRevisionActionAmend,
+		PreviousStartTime:   &previousStartTime,
+		PreviousEndTime:     previousEndTime,
+		Reason:              source.Reason,
+		CreatedBy:           source.CreatedBy,
+		CorrectionRequestID: source.CorrectionRequestID,
+	})
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := a.AttendanceRepository.Update(tx, attendance); err != nil {
+			return err
+		}
+		return a.RevisionRepository.Create(tx, revision)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fmt.Errorf("attendance/already-exists")
+		}
+		panic(err)
+	}
+
+	return attendance, nil
+}
+
+// findCorrectable loads a non-voided attendance record whose payroll period is still open
+func (a *AttendanceUseCase) findCorrectable(db *gorm.DB, id ulid.ULID) (*entity.Attendance, error) {
+	attendance := new(entity.Attendance)
+	if err := a.AttendanceRepository.FindById(db, attendance, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("attendance/not-found")
+		}
+		panic(err)
+	}
+
+	if attendance.IsVoided() {
+		return nil, fmt.Errorf("attendance/already-voided")
+	}
+
+	if err := a.ensurePeriodNotProcessed(db, attendance.WorkDate); err != nil {
+		return nil, err
+	}
+
+	return attendance, nil
+}
+
+// validateCorrection checks the corrected times and that they fall in a payroll period still open
+func (a *AttendanceUseCase) validateCorrection(db *gorm.DB, attendance *entity.Attendance) error {
+	if err := validateSchedule(attendance, a.resolveWorkSchedule(db, attendance.CreatedBy)); err != nil {
+		return err
+	} else if !attendance.IsEndTimeGreaterThanStartTime() {
+		return fmt.Errorf("attendance/invalid-time-order")
+	} else if attendance.EndTime.After(time.Now()) {
+		return fmt.Errorf("attendance/must-not-future")
+	}
+
+	return a.ensurePeriodNotProcessed(db, attendance.WorkDate)
+}
+
+// GetAnalytics reports the attendance rate, hours worked, late arrivals, absences and missing check-outs
+// of the employees and of the company over a date range
+func (a *AttendanceUseCase) GetAnalytics(
+	ctx context.Context,
+	request *model.GetAttendanceAnalyticsRequest,
+) (*vm.AttendanceAnalytics, int64, error) {
+	method := "AttendanceUseCase.GetAnalytics"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	startDate, err := time.Parse(time.DateOnly, request.StartDate)
+	if err != nil {
+		return nil, 0, fmt.Errorf("attendance/invalid-start-date")
+	}
+	endDate, err := time.Parse(time.DateOnly, request.EndDate)
+	if err != nil {
+		return nil, 0, fmt.Errorf("attendance/invalid-end-date")
+	}
+
+	if endDate.Before(startDate) {
+		return nil, 0, fmt.Errorf("attendance/invalid-date-range")
+	} else if endDate.After(startDate.AddDate(0, 0, maxAnalyticsDays-1)) {
+		return nil, 0, fmt.Errorf("attendance/analytics-range-too-long")
+	}
+
+	var employees []entity.Employee
+	if request.EmployeeID != "" {
+		employee := new(entity.Employee)
+		if err := a.EmployeeRepository.FindById(db, employee, ulid.ULID(v2.MustParse(request.EmployeeID))); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, 0, fmt.Errorf("employee/not-found")
+			}
+			panic(err)
+		}
+		employees = []entity.Employee{*employee}
+	} else if employees, err = a.EmployeeRepository.FindAllByFilter(db, "", request.Department); err != nil {
+		panic(err)
+	}
+
+	employeeIDs := make([]ulid.ULID, len(employees))
+	for i, employee := range employees {
+		employeeIDs[i] = employee.ID
+	}
+
+	attendances := make([]entity.Attendance, 0)
+	if len(employeeIDs) > 0 {
+		if attendances, err = a.AttendanceRepository.FindAllByWorkDateRange(db, employeeIDs, startDate, endDate); err != nil {
+			panic(err)
+		}
+	}
+
+	bucket := vm.AnalyticsBucket(request.Bucket)
+	if bucket == "" {
+		bucket = vm.AnalyticsBucketWeek
+	}
+
+	analytics := vm.NewAttendanceAnalytics(&vm.CreateAttendanceAnalyticsProps{
+		Employees:       employees,
+		WorkSchedules:   a.GetWorkSchedules(ctx, employees),
+		Attendances:     attendances,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		Bucket:          bucket,
+		LateGracePeriod: time.Duration(a.Config.Attendance.Deduction.LateGracePeriod) * time.Minute,
+		DefaultTimeZone: a.Config.App.TimeZone,
+		Now:             time.Now(),
+	})
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return analytics.Paginate(request.Page, request.PageSize), int64(len(analytics.Employees)), nil
+}
+
+// GetWorkSchedule returns the work schedule the employee follows
+func (a *AttendanceUseCase) GetWorkSchedule(ctx context.Context, employeeID ulid.ULID) *entity.WorkSchedule {
+	return a.resolveWorkSchedule(a.DB.WithContext(ctx), employeeID)
+}
+
+// GetWorkSchedules returns the work schedule each employee follows, keyed by employee ID
+func (a *AttendanceUseCase) GetWorkSchedules(ctx context.Context, employees []entity.Employee) map[ulid.ULID]*entity.WorkSchedule {
+	schedules, err := a.WorkScheduleRepository.FindAllOrderByName(a.DB.WithContext(ctx))
+	if err != nil {
+		panic(err)
+	}
+	schedulesByID := make(map[ulid.ULID]*entity.WorkSchedule, len(schedules))
+	for i := range schedules {
+		schedulesByID[schedules[i].ID] = &schedules[i]
+	}
+
+	defaultSchedule := a.defaultWorkSchedule(ctx)
+	employeeSchedules := make(map[ulid.ULID]*entity.WorkSchedule, len(employees))
+	for _, employee := range employees {
+		employeeSchedules[employee.ID] = defaultSchedule
+		if employee.WorkScheduleID != nil {
+			if schedule, ok := schedulesByID[*employee.WorkScheduleID]; ok {
+				employeeSchedules[employee.ID] = schedule
+			}
+		}
+	}
+
+	return employeeSchedules
+}
+
+// resolveWorkSchedule loads the employee's work schedule, falling back to the default day shift
+// which ends at the configured auto check-out time
+func (a *AttendanceUseCase) resolveWorkSchedule(db *gorm.DB, employeeID ulid.ULID) *entity.WorkSchedule {
+	employee := new(entity.Employee)
+	if err := a.EmployeeRepository.FindById(db, employee, employeeID); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		panic(err)
+	}
+
+	if employee.WorkScheduleID != nil {
+		schedule := new(entity.WorkSchedule)
+		err := a.WorkScheduleRepository.FindById(db, schedule, *employee.WorkScheduleID)
+		if err == nil {
+			return schedule
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			panic(err)
+		}
+	}
+
+	return a.defaultWorkSchedule(db.Statement.Context)
+}
+
+// defaultWorkSchedule returns the default day shift which ends at the configured default shift end
+func (a *AttendanceUseCase) defaultWorkSchedule(ctx context.Context) *entity.WorkSchedule {
+	schedule := entity.NewDefaultWorkSchedule(a.Config.Attendance.DefaultShiftEnd)
+	if !schedule.IsValidShift() {
+		a.Log.WithContext(ctx).Warn("invalid default shift end, the default shift ends at 17:00: ", a.Config.Attendance.DefaultShiftEnd)
+		schedule = entity.NewDefaultWorkSchedule("17:00")
+	}
+	return schedule
+}
+
+// validateSchedule checks the attendance falls on a work day and within the shift span of the schedule
+func validateSchedule(attendance *entity.Attendance, schedule *entity.WorkSchedule) error {
+	if !attendance.IsWorkDay(schedule) {
+		return fmt.Errorf("attendance/not-work-day")
+	} else if !attendance.IsWithinShiftSpan(schedule) {
+		if schedule.IsCrossMidnight() {
+			return fmt.Errorf("attendance/exceeds-shift-span")
+		}
+		return fmt.Errorf("attendance/must-same-day")
+	}
+	return nil
+}
+
+// resolveOrigin checks the coordinates against the office geofences and the caller IP against the office networks.
+// The IP must come from the connection or a trusted proxy, never from a header the caller controls. A check-in
+// outside every office is marked remote, or rejected when the policy is reject
+func (a *AttendanceUseCase) resolveOrigin(ctx context.Context, latitude, longitude *float64, ipAddress string) (*entity.AttendanceOrigin, error) {
+	origin := &entity.AttendanceOrigin{
+		Latitude:  latitude,
+		Longitude: longitude,
+		Status:    entity.AttendanceLocationStatusUnverified,
+	}
+	if ipAddress != "" {
+		origin.IPAddress = &ipAddress
+	}
+
+	policy := a.Config.Attendance.Location
+	if len(policy.Offices) == 0 {
+		return origin, nil
+	}
+
+	for _, office := range policy.Offices {
+		site, invalid := geofence.NewSite(office.Name, office.Latitude, office.Longitude, office.Radius, office.AllowedCIDRs)
+		if len(invalid) > 0 {
+			a.Log.WithContext(ctx).Warn("invalid allowed CIDRs of office ", office.Name, ": ", invalid)
+		}
+
+		if (latitude != nil && longitude != nil && site.Contains(*latitude, *longitude)) ||
+			(origin.IPAddress != nil && site.Allows(*origin.IPAddress)) {
+			origin.Status = entity.AttendanceLocationStatusOffice
+			origin.Office = &office.Name
+			return origin, nil
+		}
+	}
+
+	if policy.Policy == attendanceLocationPolicyReject {
+		return nil, fmt.Errorf("attendance/outside-office")
+	}
+
+	origin.Status = entity.AttendanceLocationStatusRemote
+	return origin, nil
+}
+
+// employeeLocation returns the time zone the employee works in
+func (a *AttendanceUseCase) employeeLocation(db *gorm.DB, employeeID ulid.ULID) *time.Location {
+	employee := new(entity.Employee)
+	if err := a.EmployeeRepository.FindById(db, employee, employeeID); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		panic(err)
+	}
+	return employee.GetLocation(a.Config.App.TimeZone)
+}
+
+// ensurePeriodNotProcessed rejects changes to a date whose payroll period was already processed
+func (a *AttendanceUseCase) ensurePeriodNotProcessed(db *gorm.DB, date time.Time) error {
+	payrollPeriod, err := a.PayrollPeriodRepository.FindByDate(db, date)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		panic(err)
+	}
+
+	if payrollPeriod.IsProcessed() {
+		return fmt.Errorf("attendance/period-already-processed")
+	}
+
+	return nil
+}
+
+func parseAttendanceTimes(start, end string) (time.Time, time.Time, error) {
+	startTime, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("attendance/invalid-start-time")
+	}
+
+	endTime, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("attendance/invalid-end-time")
+	}
+
+	return startTime, endTime, nil
+}
@@ -5,28 +5,39 @@ import (
 	"errors"
 	"fmt"
 	"payslip-generator-service/internal/entity"
+	"payslip-generator-service/internal/model"
 	"payslip-generator-service/internal/repository"
 	ulid "payslip-generator-service/pkg/database/gorm"
 	"payslip-generator-service/pkg/logger"
+	"payslip-generator-service/pkg/timezone"
+	"strings"
+	"time"
 
+	v2 "github.com/oklog/ulid/v2"
 	"gorm.io/gorm"
 )
 
 type EmployeeUseCase struct {
-	DB                 *gorm.DB
-	Log                *logger.ContextLogger
-	EmployeeRepository *repository.EmployeeRepository
+	DB                            *gorm.DB
+	Log                           *logger.ContextLogger
+	EmployeeRepository            *repository.EmployeeRepository
+	OvertimeEligibilityRepository *repository.OvertimeEligibilityRepository
+	PayrollPeriodRepository       *repository.PayrollPeriodRepository
 }
 
 func NewEmployeeUseCase(
 	db *gorm.DB,
 	log *logger.ContextLogger,
 	employeeRepository *repository.EmployeeRepository,
+	overtimeEligibilityRepository *repository.OvertimeEligibilityRepository,
+	payrollPeriodRepository *repository.PayrollPeriodRepository,
 ) *EmployeeUseCase {
 	return &EmployeeUseCase{
-		DB:                 db,
-		Log:                log,
-		EmployeeRepository: employeeRepository,
+		DB:                            db,
+		Log:                           log,
+		EmployeeRepository:            employeeRepository,
+		OvertimeEligibilityRepository: overtimeEligibilityRepository,
+		PayrollPeriodRepository:       payrollPeriodRepository,
 	}
 }
 
@@ -64,3 +75,208 @@ func (a *EmployeeUseCase) List(ctx context.Context) ([]entity.Employee, error) {
 	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
 	return employees, nil
 }
+
+func (a *EmployeeUseCase) ListByFilter(ctx context.Context, search, department string) ([]entity.Employee, error) {
+	method := "EmployeeUseCase.ListByFilter"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("search", search).WithField("department", department).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	employees, err := a.EmployeeRepository.FindAllByFilter(db, search, department)
+	if err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+	return employees, nil
+}
+
+// UpdateTimezone sets the time zone the employee's attendance and payroll days are evaluated in
+func (a *EmployeeUseCase) UpdateTimezone(ctx context.Context, request *model.UpdateEmployeeTimezoneRequest) (*entity.Employee, error) {
+	method := "EmployeeUseCase.UpdateTimezone"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	var name *string
+	if request.Timezone != "" {
+		if _, err := timezone.Load(request.Timezone); err != nil {
+			return nil, fmt.Errorf("employee/invalid-timezone")
+		}
+		name = &request.Timezone
+	}
+
+	employee := new(entity.Employee)
+	if err := a.EmployeeRepository.FindById(db, employee, ulid.ULID(v2.MustParse(request.ID))); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("employee/not-found")
+		}
+		panic(err)
+	}
+
+	employee.SetTimezone(name)
+	if err := a.EmployeeRepository.Update(db, employee); err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+	return employee, nil
+}
+
+// UpdateDeviceUser links the employee to the user ID it is enrolled with on terminals, used to match imported punches
+func (a *EmployeeUseCase) UpdateDeviceUser(ctx context.Context, request *model.UpdateEmployeeDeviceUserRequest) (*entity.Employee, error) {
+	method := "EmployeeUseCase.UpdateDeviceUser"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	var deviceUserID *string
+	if trimmed := strings.TrimSpace(request.DeviceUserID); trimmed != "" {
+		deviceUserID = &trimmed
+	}
+
+	employee := new(entity.Employee)
+	if err := a.EmployeeRepository.FindById(db, employee, ulid.ULID(v2.MustParse(request.ID))); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("employee/not-found")
+		}
+		panic(err)
+	}
+
+	employee.SetDeviceUserID(deviceUserID)
+	if err := a.EmployeeRepository.Update(db, employee); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fmt.Errorf("employee/device-user-id-already-exists")
+		}
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+	return employee, nil
+}
+
+// UpdateManager sets the manager of the employee, the manager may approve or reject the employee's overtime
+func (a *EmployeeUseCase) UpdateManager(ctx context.Context, request *model.UpdateEmployeeManagerRequest) (*entity.Employee, error) {
+	method := "EmployeeUseCase.UpdateManager"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	employee := new(entity.Employee)
+	if err := a.EmployeeRepository.FindById(db, employee, ulid.ULID(v2.MustParse(request.ID))); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("employee/not-found")
+		}
+		panic(err)
+	}
+
+	var managerID *ulid.ULID
+	if request.ManagerID != "" {
+		id := ulid.ULID(v2.MustParse(request.ManagerID))
+		if id == employee.ID {
+			return nil, fmt.Errorf("employee/manager-is-self")
+		}
+
+		count, err := a.EmployeeRepository.CountById(db, id)
+		if err != nil {
+			panic(err)
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("employee/manager-not-found")
+		}
+		managerID = &id
+	}
+
+	employee.SetManager(managerID)
+	if err := a.EmployeeRepository.Update(db, employee); err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+	return employee, nil
+}
+
+// UpdateOvertimeEligibility records a change of the employee's overtime entitlement from the effective date on.
+// Processed payroll periods are left untouched, so the change must take effect after the last of them
+func (a *EmployeeUseCase) UpdateOvertimeEligibility(
+	ctx context.Context,
+	request *model.UpdateEmployeeOvertimeEligibilityRequest,
+	auth *model.Auth,
+) (*entity.OvertimeEligibility, error) {
+	method := "EmployeeUseCase.UpdateOvertimeEligibility"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	effectiveDate, err := time.Parse(time.DateOnly, request.EffectiveDate)
+	if err != nil {
+		return nil, fmt.Errorf("employee/invalid-effective-date")
+	}
+
+	employee := new(entity.Employee)
+	if err := a.EmployeeRepository.FindById(db, employee, ulid.ULID(v2.MustParse(request.ID))); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("employee/not-found")
+		}
+		panic(err)
+	}
+
+	lastProcessed, err := a.PayrollPeriodRepository.FindLastProcessed(db)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		panic(err)
+	}
+	if lastProcessed != nil && !effectiveDate.After(lastProcessed.EndDate) {
+		return nil, fmt.Errorf("employee/period-already-processed")
+	}
+
+	eligibility := entity.NewOvertimeEligibility(&entity.CreateOvertimeEligibilityProps{
+		EmployeeID:    employee.ID,
+		Eligible:      *request.Eligible,
+		EffectiveDate: effectiveDate,
+		Reason:        request.Reason,
+		CreatedBy:     auth.ID,
+	})
+	if err := a.OvertimeEligibilityRepository.Create(db, eligibility); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fmt.Errorf("employee/overtime-eligibility-already-exists")
+		}
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+	return eligibility, nil
+}
+
+// ListOvertimeEligibility returns the overtime entitlement history of the employee, oldest change first
+func (a *EmployeeUseCase) ListOvertimeEligibility(
+	ctx context.Context,
+	request *model.ListEmployeeOvertimeEligibilityRequest,
+) (entity.OvertimeEligibilities, error) {
+	method := "EmployeeUseCase.ListOvertimeEligibility"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	employeeID := ulid.ULID(v2.MustParse(request.ID))
+	count, err := a.EmployeeRepository.CountById(db, employeeID)
+	if err != nil {
+		panic(err)
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("employee/not-found")
+	}
+
+	eligibilities, err := a.OvertimeEligibilityRepository.FindAllByEmployeeId(db, employeeID)
+	if err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+	return eligibilities, nil
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"payslip-generator-service/internal/entity"
+	"payslip-generator-service/internal/model"
+	"payslip-generator-service/internal/repository"
+	ulid "payslip-generator-service/pkg/database/gorm"
+	"time"
+
+	v2 "github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+)
+
+// dateRangeFilter is the resolved date range and employee of a history listing
+type dateRangeFilter struct {
+	StartDate  *time.Time
+	EndDate    *time.Time
+	EmployeeID *ulid.ULID
+}
+
+// resolveDateRangeFilter parses the date range of a history listing, narrowing it to the payroll
+// period when one is given, and restricts non-admin callers to their own records
+func resolveDateRangeFilter(
+	db *gorm.DB,
+	payrollPeriodRepository *repository.PayrollPeriodRepository,
+	startDate, endDate, periodID, employeeID string,
+	auth *model.Auth,
+) (*dateRangeFilter, error) {
+	filter := new(dateRangeFilter)
+
+	if startDate != "" {
+		date, err := time.Parse(time.DateOnly, startDate)
+		if err != nil {
+			return nil, fmt.Errorf("filter/invalid-start-date")
+		}
+		filter.StartDate = &date
+	}
+
+	if endDate != "" {
+		date, err := time.Parse(time.DateOnly, endDate)
+		if err != nil {
+			return nil, fmt.Errorf("filter/invalid-end-date")
+		}
+		filter.EndDate = &date
+	}
+
+	if periodID != "" {
+		payrollPeriod := new(entity.PayrollPeriod)
+		err := payrollPeriodRepository.FindById(db, payrollPeriod, ulid.ULID(v2.MustParse(periodID)))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("payroll/period-not-found")
+			}
+			panic(err)
+		}
+
+		if filter.StartDate == nil || filter.StartDate.Before(payrollPeriod.StartDate) {
+			filter.StartDate = &payrollPeriod.StartDate
+		}
+		if filter.EndDate == nil || filter.EndDate.After(payrollPeriod.EndDate) {
+			filter.EndDate = &payrollPeriod.EndDate
+		}
+	}
+
+	if filter.StartDate != nil && filter.EndDate != nil && filter.StartDate.After(*filter.EndDate) {
+		return nil, fmt.Errorf("filter/invalid-date-range")
+	}
+
+	if !auth.IsAdmin {
+		filter.EmployeeID = &auth.ID
+	} else if employeeID != "" {
+		id := ulid.ULID(v2.MustParse(employeeID))
+		filter.EmployeeID = &id
+	}
+
+	return filter, nil
+}
+
+// Scope returns a query scope applying the filter, dateColumn is the SQL expression of the record date
+// and args are the values of its placeholders
+func (f *dateRangeFilter) Scope(dateColumn string, args ...any) func(tx *gorm.DB) *gorm.DB {
+	return func(tx *gorm.DB) *gorm.DB {
+		if f.StartDate != nil {
+			tx = tx.Where(dateColumn+" >= ?", append(args[:len(args):len(args)], f.StartDate.Format(time.DateOnly))...)
+		}
+		if f.EndDate != nil {
+			tx = tx.Where(dateColumn+" <= ?", append(args[:len(args):len(args)], f.EndDate.Format(time.DateOnly))...)
+		}
+		if f.EmployeeID != nil {
+			tx = tx.Where("created_by = ?", *f.EmployeeID)
+		}
+		return tx
+	}
+}
@@ -0,0 +1,419 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payslip-generator-service/internal/entity"
+	"payslip-generator-service/internal/model"
+	"time"
+
+	ulid "payslip-generator-service/pkg/database/gorm"
+
+	v2 "github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+)
+
+// CreatePlan requests overtime in advance for today or a later day, to be approved by an admin or the manager
+// of the employee before it is worked
+func (a *OvertimeUseCase) CreatePlan(
+	ctx context.Context,
+	request *model.CreateOvertimePlanRequest,
+	auth *model.Auth,
+) (*entity.OvertimePlan, error) {
+	method := "OvertimeUseCase.CreatePlan"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	if err := a.ensureClaimsEnabled(); err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse(time.DateOnly, request.Date)
+	if err != nil {
+		return nil, fmt.Errorf("overtime/invalid-date")
+	}
+
+	plan := entity.NewOvertimePlan(&entity.CreateOvertimePlanProps{
+		Date:         date,
+		PlannedHours: request.PlannedHours,
+		Reason:       request.Reason,
+		CreatedBy:    auth.ID,
+	})
+
+	if !plan.IsValidDuration() {
+		return nil, fmt.Errorf("overtime/invalid-duration")
+	} else if plan.IsPast(auth.Location) {
+		return nil, fmt.Errorf("overtime/plan-must-not-past")
+	}
+
+	overtime, err := a.OvertimeRepository.FindByDate(db, auth.ID, date)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		panic(err)
+	}
+	if overtime != nil {
+		return nil, fmt.Errorf("overtime/already-exists")
+	}
+
+	if err := a.ensureEligible(db, auth.ID, date); err != nil {
+		return nil, err
+	}
+
+	if err := a.ensurePeriodOpen(db, date); err != nil {
+		return nil, err
+	}
+
+	err = runTransaction(db, func(tx *gorm.DB) error {
+		if err := a.lockEmployee(tx, auth.ID); err != nil {
+			return err
+		}
+		if err := a.ensureWithinCaps(tx, auth.ID, date, plan.PlannedHours, nil); err != nil {
+			return abort(err)
+		}
+
+		if err := a.OvertimePlanRepository.Create(tx, plan); err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return abort(fmt.Errorf("overtime/plan-already-exists"))
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return plan, nil
+}
+
+// ListPlans lists overtime plans, latest first. Employees see their own plans and the plans of their direct
+// reports, admins see every plan and can filter by employee
+func (a *OvertimeUseCase) ListPlans(
+	ctx context.Context,
+	request *model.ListOvertimePlanRequest,
+	auth *model.Auth,
+) ([]entity.OvertimePlan, int64, error) {
+	method := "OvertimeUseCase.ListPlans"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	filter, err := resolveDateRangeFilter(db, a.PayrollPeriodRepository, request.StartDate, request.EndDate, "", request.EmployeeID, auth)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !auth.IsAdmin {
+		// managers also see the plans they approve
+		filter.EmployeeID = nil
+	}
+
+	dateScope := filter.Scope("date")
+	scope := func(tx *gorm.DB) *gorm.DB {
+		tx = dateScope(tx)
+		if !auth.IsAdmin {
+			tx = tx.Where("created_by = ? OR created_by IN (?)", auth.ID, db.Model(new(entity.Employee)).Select("id").Where("manager_id = ?", auth.ID))
+		}
+		if request.Status != "" {
+			tx = tx.Where("status = ?", request.Status)
+		}
+		return tx
+	}
+	data, total, err := a.OvertimePlanRepository.FindAllWithPagination(db, &model.PaginationOptions{
+		Page:     request.Page,
+		PageSize: request.PageSize,
+		Filter:   &scope,
+		Order: []model.OrderBy{
+			{
+				Column:    "date",
+				Direction: model.OrderDirectionDesc,
+			},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return data, total, nil
+}
+
+// ApprovePlan approves a pending overtime plan, by an admin or the manager of the employee
+func (a *OvertimeUseCase) ApprovePlan(
+	ctx context.Context,
+	request *model.ReviewOvertimeRequest,
+	auth *model.Auth,
+) (*entity.OvertimePlan, error) {
+	method := "OvertimeUseCase.ApprovePlan"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	plan, err := a.findReviewablePlan(db, request.ID, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Approve(auth.ID, optionalComment(request.Comment))
+	if err := a.OvertimePlanRepository.Update(db, plan); err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return plan, nil
+}
+
+// RejectPlan rejects a pending overtime plan with a comment for the employee, by an admin or the manager of the employee
+func (a *OvertimeUseCase) RejectPlan(
+	ctx context.Context,
+	request *model.ReviewOvertimeRequest,
+	auth *model.Auth,
+) (*entity.OvertimePlan, error) {
+	method := "OvertimeUseCase.RejectPlan"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	if request.Comment == "" {
+		return nil, fmt.Errorf("overtime/rejection-comment-required")
+	}
+
+	plan, err := a.findReviewablePlan(db, request.ID, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.Reject(auth.ID, &request.Comment)
+	if err := a.OvertimePlanRepository.Update(db, plan); err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return plan, nil
+}
+
+// CancelPlan withdraws a pending or approved overtime plan of the authenticated employee
+func (a *OvertimeUseCase) CancelPlan(
+	ctx context.Context,
+	request *model.CancelOvertimeRequest,
+	auth *model.Auth,
+) (*entity.OvertimePlan, error) {
+	method := "OvertimeUseCase.CancelPlan"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	plan, err := a.findOwnPlan(db, request.ID, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	if !plan.IsPending() && !plan.IsApproved() {
+		return nil, fmt.Errorf("overtime/plan-not-cancellable")
+	}
+
+	plan.Cancel(auth.ID)
+	if err := a.OvertimePlanRepository.Update(db, plan); err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return plan, nil
+}
+
+// ConfirmPlan records the actual hours of an approved overtime plan once its day has come. The overtime is
+// recorded as approved and paid for the lesser of the planned and actual hours
+func (a *OvertimeUseCase) ConfirmPlan(
+	ctx context.Context,
+	request *model.ConfirmOvertimePlanRequest,
+	auth *model.Auth,
+) (*entity.Overtime, error) {
+	method := "OvertimeUseCase.ConfirmPlan"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	if err := a.ensureClaimsEnabled(); err != nil {
+		return nil, err
+	}
+
+	plan, err := a.findOwnPlan(db, request.ID, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	if !plan.IsApproved() {
+		return nil, fmt.Errorf("overtime/plan-not-approved")
+	} else if !plan.IsDue(auth.Location) {
+		return nil, fmt.Errorf("overtime/plan-not-due")
+	}
+
+	attendance, err := a.AttendanceRepository.FindWithBreaksByDate(db, auth.ID, plan.Date)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("attendance/not-found")
+		}
+		panic(err)
+	}
+
+	if err := a.ensureEligible(db, auth.ID, plan.Date); err != nil {
+		return nil, err
+	}
+
+	if err := a.ensurePeriodOpen(db, plan.Date); err != nil {
+		return nil, err
+	}
+
+	overtime := plan.Confirm(request.TotalHours, entity.OvertimeCompensation(request.Compensation), auth.ID)
+	if !overtime.IsValidDuration() {
+		return nil, fmt.Errorf("overtime/invalid-duration")
+	} else if err := a.checkClaim(db, attendance, overtime.TotalHours); err != nil {
+		return nil, err
+	}
+
+	err = runTransaction(db, func(tx *gorm.DB) error {
+		if err := a.lockEmployee(tx, auth.ID); err != nil {
+			return err
+		}
+		if err := a.ensureWithinCaps(tx, auth.ID, plan.Date, overtime.PaidHours(), &plan.ID); err != nil {
+			return abort(err)
+		}
+		if err := a.ensureToilRoom(tx, overtime); err != nil {
+			return abort(err)
+		}
+
+		if err := a.OvertimePlanRepository.Update(tx, plan); err != nil {
+			return err
+		}
+		if err := a.OvertimeRepository.Create(tx, overtime); err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return abort(fmt.Errorf("overtime/already-exists"))
+			}
+			return err
+		}
+		return a.bankOvertime(tx, overtime, auth)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return overtime, nil
+}
+
+// OverridePaidHours sets the hours paid for overtime confirmed from a plan, replacing the lesser of the
+// planned and actual hours, while its payroll period is not processed
+func (a *OvertimeUseCase) OverridePaidHours(
+	ctx context.Context,
+	request *model.OverrideOvertimeHoursRequest,
+	auth *model.Auth,
+) (*entity.Overtime, error) {
+	method := "OvertimeUseCase.OverridePaidHours"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	overtime := new(entity.Overtime)
+	if err := a.OvertimeRepository.FindById(db, overtime, ulid.ULID(v2.MustParse(request.ID))); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("overtime/not-found")
+		}
+		panic(err)
+	}
+
+	if !overtime.IsPlanned() {
+		return nil, fmt.Errorf("overtime/not-planned")
+	} else if !overtime.IsApproved() {
+		return nil, fmt.Errorf("overtime/not-approved")
+	} else if *request.PaidHours > overtime.TotalHours {
+		return nil, fmt.Errorf("overtime/paid-hours-exceed-actual")
+	}
+
+	if err := a.ensurePeriodOpen(db, overtime.Date); err != nil {
+		return nil, err
+	}
+
+	err := runTransaction(db, func(tx *gorm.DB) error {
+		if err := a.lockEmployee(tx, overtime.CreatedBy); err != nil {
+			return err
+		}
+		accrual, err := a.findRebankable(tx, overtime, *request.PaidHours)
+		if err != nil {
+			return abort(err)
+		}
+
+		overtime.OverridePaidHours(*request.PaidHours, request.Comment, auth.ID)
+		if err := a.OvertimeRepository.Update(tx, overtime); err != nil {
+			return err
+		}
+		if accrual == nil {
+			return nil
+		}
+		accrual.Hours = overtime.PaidHours()
+		return a.ToilEntryRepository.Update(tx, accrual)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return overtime, nil
+}
+
+// findReviewablePlan loads a pending overtime plan the caller may decide on
+func (a *OvertimeUseCase) findReviewablePlan(db *gorm.DB, id string, auth *model.Auth) (*entity.OvertimePlan, error) {
+	plan, err := a.findPlan(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !plan.IsPending() {
+		return nil, fmt.Errorf("overtime/plan-already-reviewed")
+	}
+
+	if err := a.ensureApprover(db, plan.CreatedBy, auth); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// findOwnPlan loads an overtime plan of the authenticated employee
+func (a *OvertimeUseCase) findOwnPlan(db *gorm.DB, id string, auth *model.Auth) (*entity.OvertimePlan, error) {
+	plan, err := a.findPlan(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan.CreatedBy != auth.ID {
+		return nil, fmt.Errorf("overtime/plan-not-found")
+	}
+
+	return plan, nil
+}
+
+func (a *OvertimeUseCase) findPlan(db *gorm.DB, id string) (*entity.OvertimePlan, error) {
+	plan := new(entity.OvertimePlan)
+	if err := a.OvertimePlanRepository.FindById(db, plan, ulid.ULID(v2.MustParse(id))); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("overtime/plan-not-found")
+		}
+		panic(err)
+	}
+	return plan, nil
+}
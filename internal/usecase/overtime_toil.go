@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"payslip-generator-service/internal/entity"
+	"payslip-generator-service/internal/model"
+	"payslip-generator-service/internal/vm"
+	"payslip-generator-service/pkg/timezone"
+	"time"
+
+	ulid "payslip-generator-service/pkg/database/gorm"
+
+	v2 "github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+)
+
+// GetToilBalance returns the time-off-in-lieu balance and ledger of an employee, lapsing the banked hours
+// expired so far. Employees get their own balance, admins the balance of any employee
+func (a *OvertimeUseCase) GetToilBalance(
+	ctx context.Context,
+	request *model.GetToilBalanceRequest,
+	auth *model.Auth,
+) (*vm.ToilBalance, error) {
+	method := "OvertimeUseCase.GetToilBalance"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	employeeID := auth.ID
+	if auth.IsAdmin && request.EmployeeID != "" {
+		employeeID = ulid.ULID(v2.MustParse(request.EmployeeID))
+
+		count, err := a.EmployeeRepository.CountById(db, employeeID)
+		if err != nil {
+			panic(err)
+		}
+		if count == 0 {
+			return nil, fmt.Errorf("employee/not-found")
+		}
+	}
+
+	ledger := a.toilLedger(db, employeeID)
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return vm.NewToilBalance(&vm.CreateToilBalanceProps{
+		EmployeeID: employeeID,
+		Ledger:     ledger,
+		MaxBalance: a.Config.Overtime.Toil.MaxBalance,
+	}), nil
+}
+
+// RecordToilUsage takes time off in lieu from the balance of an employee, by an admin
+func (a *OvertimeUseCase) RecordToilUsage(
+	ctx context.Context,
+	request *model.RecordToilUsageRequest,
+	auth *model.Auth,
+) (*entity.ToilEntry, error) {
+	method := "OvertimeUseCase.RecordToilUsage"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	date, err := time.Parse(time.DateOnly, request.Date)
+	if err != nil {
+		return nil, fmt.Errorf("overtime/invalid-date")
+	}
+
+	employeeID := ulid.ULID(v2.MustParse(request.EmployeeID))
+	count, err := a.EmployeeRepository.CountById(db, employeeID)
+	if err != nil {
+		panic(err)
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("employee/not-found")
+	}
+
+	usage := entity.NewToilUsage(&entity.CreateToilUsageProps{
+		EmployeeID: employeeID,
+		Date:       date,
+		Hours:      request.Hours,
+		Reason:     request.Reason,
+		CreatedBy:  auth.ID,
+	})
+
+	// the balance stays locked until the usage is recorded, a concurrent usage or banking waits for it
+	err = runTransaction(db, func(tx *gorm.DB) error {
+		if err := a.lockEmployee(tx, employeeID); err != nil {
+			return err
+		}
+		if a.toilLedger(tx, employeeID).Balance() < usage.Hours {
+			return abort(fmt.Errorf("overtime/toil-balance-insufficient"))
+		}
+
+		return a.ToilEntryRepository.Create(tx, usage)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return usage, nil
+}
+
+// toilLedger loads the time-off-in-lieu ledger of the employee, recording the expiry of the banked hours lapsed
+// since it was last read. Concurrent reads record each expiry once
+func (a *OvertimeUseCase) toilLedger(tx *gorm.DB, employeeID ulid.ULID) entity.ToilLedger {
+	ledger, err := a.ToilEntryRepository.FindAllByEmployeeId(tx, employeeID)
+	if err != nil {
+		panic(err)
+	}
+
+	today := timezone.DateOf(time.Now(), timezone.LoadOrDefault(a.Config.App.TimeZone))
+	expiries := ledger.Expire(today)
+	reload := false
+	for i := range expiries {
+		recorded, err := a.ToilEntryRepository.CreateExpiry(tx, &expiries[i])
+		if err != nil {
+			panic(err)
+		}
+		reload = reload || !recorded
+	}
+
+	if !reload {
+		return append(ledger, expiries...)
+	}
+
+	// another read recorded some of the expiries first, the ledger holds its entries instead
+	ledger, err = a.ToilEntryRepository.FindAllByEmployeeId(tx, employeeID)
+	if err != nil {
+		panic(err)
+	}
+	return ledger
+}
+
+// ensureToilRoom checks the paid hours of an overtime compensated with time off in lieu fit in the maximum
+// balance of the employee, whose row tx must lock until the overtime is recorded
+func (a *OvertimeUseCase) ensureToilRoom(db *gorm.DB, overtime *entity.Overtime) error {
+	if !overtime.IsBanked() {
+		return nil
+	}
+
+	return a.ensureToilBalanceAllows(a.toilLedger(db, overtime.CreatedBy), overtime.PaidHours())
+}
+
+// ensureToilBalanceAllows checks hours more of banked overtime fit in the maximum balance
+func (a *OvertimeUseCase) ensureToilBalanceAllows(ledger entity.ToilLedger, hours int) error {
+	maxBalance := a.Config.Overtime.Toil.MaxBalance
+	if maxBalance > 0 && ledger.Balance()+hours > maxBalance {
+		return fmt.Errorf("overtime/toil-balance-exceeded")
+	}
+
+	return nil
+}
+
+// bankOvertime adds the paid hours of an approved overtime compensated with time off in lieu to the balance
+// of the employee, the hours lapse once the configured expiry has passed since the overtime day
+func (a *OvertimeUseCase) bankOvertime(tx *gorm.DB, overtime *entity.Overtime, auth *model.Auth) error {
+	if !overtime.IsBanked() {
+		return nil
+	}
+
+	var expiresOn *time.Time
+	if days := a.Config.Overtime.Toil.ExpiryDays; days > 0 {
+		date := overtime.Date.AddDate(0, 0, days)
+		expiresOn = &date
+	}
+
+	return a.ToilEntryRepository.Create(tx, entity.NewToilAccrual(overtime, expiresOn, auth.ID))
+}
+
+// findRebankable returns the accrual banking an overtime whose paid hours change to hours, nil when the overtime
+// is not banked. The balance must hold any added hours and still cover the hours already taken
+func (a *OvertimeUseCase) findRebankable(db *gorm.DB, overtime *entity.Overtime, hours int) (*entity.ToilEntry, error) {
+	if !overtime.IsBanked() {
+		return nil, nil
+	}
+
+	ledger := a.toilLedger(db, overtime.CreatedBy)
+	accrual := ledger.FindAccrual(overtime.ID)
+	if accrual == nil {
+		return nil, nil
+	}
+
+	if change := hours - accrual.Hours; change > 0 {
+		if err := a.ensureToilBalanceAllows(ledger, change); err != nil {
+			return nil, err
+		}
+	} else if ledger.Balance()+change < 0 {
+		return nil, fmt.Errorf("overtime/toil-already-used")
+	}
+
+	return accrual, nil
+}
@@ -2,36 +2,69 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"payslip-generator-service/config"
 	"payslip-generator-service/internal/entity"
 	"payslip-generator-service/internal/model"
 	"payslip-generator-service/internal/repository"
+	"payslip-generator-service/internal/vm"
 	"payslip-generator-service/pkg/logger"
+	"payslip-generator-service/pkg/timezone"
 	"time"
 
 	ulid "payslip-generator-service/pkg/database/gorm"
 
+	v2 "github.com/oklog/ulid/v2"
 	"gorm.io/gorm"
 )
 
+const (
+	// overtimeModeAttendance derives overtime from the hours worked beyond the shift instead of the claims of the employees
+	overtimeModeAttendance = "attendance"
+	// overtimeExcessClaimPolicyReject rejects claims exceeding the hours worked beyond the shift instead of flagging them
+	overtimeExcessClaimPolicyReject = "reject"
+)
+
 type OvertimeUseCase struct {
-	DB                   *gorm.DB
-	Log                  *logger.ContextLogger
-	OvertimeRepository   *repository.OvertimeRepository
-	AttendanceRepository *repository.AttendanceRepository
+	DB                            *gorm.DB
+	Log                           *logger.ContextLogger
+	Config                        *config.Config
+	OvertimeRepository            *repository.OvertimeRepository
+	OvertimePlanRepository        *repository.OvertimePlanRepository
+	PayrollPeriodRepository       *repository.PayrollPeriodRepository
+	AttendanceRepository          *repository.AttendanceRepository
+	EmployeeRepository            *repository.EmployeeRepository
+	OvertimeEligibilityRepository *repository.OvertimeEligibilityRepository
+	ToilEntryRepository           *repository.ToilEntryRepository
+	attendanceUseCase             *AttendanceUseCase
 }
 
 func NewOvertimeUseCase(
 	db *gorm.DB,
 	log *logger.ContextLogger,
+	config *config.Config,
 	overtimeRepository *repository.OvertimeRepository,
+	overtimePlanRepository *repository.OvertimePlanRepository,
 	attendanceRepository *repository.AttendanceRepository,
+	employeeRepository *repository.EmployeeRepository,
+	payrollPeriodRepository *repository.PayrollPeriodRepository,
+	overtimeEligibilityRepository *repository.OvertimeEligibilityRepository,
+	toilEntryRepository *repository.ToilEntryRepository,
+	attendanceUseCase *AttendanceUseCase,
 ) *OvertimeUseCase {
 	return &OvertimeUseCase{
-		DB:                   db,
-		Log:                  log,
-		OvertimeRepository:   overtimeRepository,
-		AttendanceRepository: attendanceRepository,
+		DB:                            db,
+		Log:                           log,
+		Config:                        config,
+		OvertimeRepository:            overtimeRepository,
+		OvertimePlanRepository:        overtimePlanRepository,
+		AttendanceRepository:          attendanceRepository,
+		EmployeeRepository:            employeeRepository,
+		PayrollPeriodRepository:       payrollPeriodRepository,
+		OvertimeEligibilityRepository: overtimeEligibilityRepository,
+		ToilEntryRepository:           toilEntryRepository,
+		attendanceUseCase:             attendanceUseCase,
 	}
 }
 
@@ -46,12 +79,20 @@ func (a *OvertimeUseCase) Create(
 
 	db := a.DB.WithContext(ctx)
 
+	if err := a.ensureClaimsEnabled(); err != nil {
+		return err
+	}
+
 	date, err := time.Parse(time.DateOnly, request.Date)
 	if err != nil {
 		return fmt.Errorf("overtime/invalid-date")
 	}
 
-	_, err = a.AttendanceRepository.FindByDate(db, date)
+	if err := a.ensureEligible(db, auth.ID, date); err != nil {
+		return err
+	}
+
+	attendance, err := a.AttendanceRepository.FindWithBreaksByDate(db, auth.ID, date)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return fmt.Errorf("attendance/not-found")
@@ -59,7 +100,7 @@ func (a *OvertimeUseCase) Create(
 		panic(err)
 	}
 
-	todayOvertime, err := a.OvertimeRepository.FindByDate(db, date)
+	todayOvertime, err := a.OvertimeRepository.FindByDate(db, auth.ID, date)
 	if err != nil && err != gorm.ErrRecordNotFound {
 		panic(err)
 	}
@@ -68,20 +109,52 @@ func (a *OvertimeUseCase) Create(
 		return fmt.Errorf("overtime/already-exists")
 	}
 
+	// planned overtime is recorded by confirming its plan
+	plan, err := a.OvertimePlanRepository.FindByDate(db, auth.ID, date)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		panic(err)
+	}
+
+	if plan != nil {
+		return fmt.Errorf("overtime/plan-exists")
+	}
+
 	overtime := entity.NewOvertime(&entity.CreateOvertimeProps{
-		Date:       date,
-		TotalHours: request.TotalHours,
-		CreatedBy:  auth.ID,
+		Date:         date,
+		TotalHours:   request.TotalHours,
+		Compensation: entity.OvertimeCompensation(request.Compensation),
+		CreatedBy:    auth.ID,
 	})
 
 	if !overtime.IsValidDuration() {
 		return fmt.Errorf("overtime/invalid-duration")
-	} else if !overtime.IsToday() {
+	} else if !overtime.IsToday(auth.Location) {
 		return fmt.Errorf("overtime/must-today")
+	} else if err := a.checkClaim(db, attendance, overtime.TotalHours); err != nil {
+		return err
 	}
 
-	if err := a.OvertimeRepository.Create(db, overtime); err != nil {
-		panic(err)
+	err = runTransaction(db, func(tx *gorm.DB) error {
+		if err := a.lockEmployee(tx, auth.ID); err != nil {
+			return err
+		}
+		if err := a.ensureWithinCaps(tx, auth.ID, date, overtime.TotalHours, nil); err != nil {
+			return abort(err)
+		}
+		if err := a.ensureToilRoom(tx, overtime); err != nil {
+			return abort(err)
+		}
+
+		if err := a.OvertimeRepository.Create(tx, overtime); err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return abort(fmt.Errorf("overtime/already-exists"))
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
@@ -89,6 +162,7 @@ func (a *OvertimeUseCase) Create(
 	return nil
 }
 
+// ListByPeriod returns the approved overtime of the employee in the period, the overtime paid in the payslip
 func (a *OvertimeUseCase) ListByPeriod(
 	ctx context.Context,
 	employeeID ulid.ULID,
@@ -101,7 +175,7 @@ func (a *OvertimeUseCase) ListByPeriod(
 
 	db := a.DB.WithContext(ctx)
 
-	overtimes, err := a.OvertimeRepository.FindByPeriod(db, employeeID, startDate, endDate)
+	overtimes, err := a.OvertimeRepository.FindApprovedByPeriod(db, employeeID, startDate, endDate)
 	if err != nil {
 		panic(err)
 	}
@@ -110,3 +184,490 @@ func (a *OvertimeUseCase) ListByPeriod(
 
 	return overtimes, nil
 }
+
+func (a *OvertimeUseCase) List(
+	ctx context.Context,
+	request *model.ListOvertimeRequest,
+	auth *model.Auth,
+) ([]entity.Overtime, int64, error) {
+	method := "OvertimeUseCase.List"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	filter, err := resolveDateRangeFilter(db, a.PayrollPeriodRepository, request.StartDate, request.EndDate, request.PeriodID, request.EmployeeID, auth)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dateScope := filter.Scope("date")
+	scope := func(tx *gorm.DB) *gorm.DB {
+		tx = dateScope(tx)
+		if request.Status != "" {
+			tx = tx.Where("status = ?", request.Status)
+		}
+		return tx
+	}
+	data, total, err := a.OvertimeRepository.FindAllWithPagination(db, &model.PaginationOptions{
+		Page:     request.Page,
+		PageSize: request.PageSize,
+		Filter:   &scope,
+		Order: []model.OrderBy{
+			{
+				Column:    "date",
+				Direction: model.OrderDirectionDesc,
+			},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return data, total, nil
+}
+
+// ListForApproval lists the pending overtime the caller may decide on, oldest first: every pending overtime
+// for admins, the pending overtime of their direct reports for managers
+func (a *OvertimeUseCase) ListForApproval(
+	ctx context.Context,
+	request *model.ListOvertimeApprovalRequest,
+	auth *model.Auth,
+) ([]entity.Overtime, int64, error) {
+	method := "OvertimeUseCase.ListForApproval"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	scope := func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Where("status = ?", entity.OvertimeStatusPending)
+		if !auth.IsAdmin {
+			tx = tx.Where("created_by IN (?)", db.Model(new(entity.Employee)).Select("id").Where("manager_id = ?", auth.ID))
+		}
+		return tx
+	}
+
+	data, total, err := a.OvertimeRepository.FindAllWithPagination(db, &model.PaginationOptions{
+		Page:     request.Page,
+		PageSize: request.PageSize,
+		Filter:   &scope,
+		Order: []model.OrderBy{
+			{
+				Column:    "created_at",
+				Direction: model.OrderDirectionAsc,
+			},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return data, total, nil
+}
+
+// Approve approves a pending overtime to be paid, by an admin or the manager of the employee
+func (a *OvertimeUseCase) Approve(
+	ctx context.Context,
+	request *model.ReviewOvertimeRequest,
+	auth *model.Auth,
+) (*entity.Overtime, error) {
+	method := "OvertimeUseCase.Approve"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	// the overtime stays locked until it is approved, a concurrent review or cancellation waits and finds it decided
+	var overtime *entity.Overtime
+	err := runTransaction(db, func(tx *gorm.DB) error {
+		var err error
+		overtime, err = a.findReviewable(tx, request.ID, auth)
+		if err != nil {
+			return abort(err)
+		}
+
+		attendance, err := a.AttendanceRepository.FindWithBreaksByDate(tx, overtime.CreatedBy, overtime.Date)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if attendance != nil {
+			if err := a.checkClaim(tx, attendance, overtime.TotalHours); err != nil {
+				return abort(err)
+			}
+		}
+
+		if err := a.lockEmployee(tx, overtime.CreatedBy); err != nil {
+			return err
+		}
+		if err := a.ensureToilRoom(tx, overtime); err != nil {
+			return abort(err)
+		}
+
+		overtime.Approve(auth.ID, optionalComment(request.Comment))
+		if err := a.OvertimeRepository.Update(tx, overtime); err != nil {
+			return err
+		}
+		return a.bankOvertime(tx, overtime, auth)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return overtime, nil
+}
+
+// Reject rejects a pending overtime with a comment for the employee, by an admin or the manager of the employee
+func (a *OvertimeUseCase) Reject(
+	ctx context.Context,
+	request *model.ReviewOvertimeRequest,
+	auth *model.Auth,
+) (*entity.Overtime, error) {
+	method := "OvertimeUseCase.Reject"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	if request.Comment == "" {
+		return nil, fmt.Errorf("overtime/rejection-comment-required")
+	}
+
+	var overtime *entity.Overtime
+	err := runTransaction(db, func(tx *gorm.DB) error {
+		var err error
+		overtime, err = a.findReviewable(tx, request.ID, auth)
+		if err != nil {
+			return abort(err)
+		}
+
+		overtime.Reject(auth.ID, &request.Comment)
+		return a.OvertimeRepository.Update(tx, overtime)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return overtime, nil
+}
+
+// Cancel withdraws a pending overtime of the authenticated employee
+func (a *OvertimeUseCase) Cancel(
+	ctx context.Context,
+	request *model.CancelOvertimeRequest,
+	auth *model.Auth,
+) (*entity.Overtime, error) {
+	method := "OvertimeUseCase.Cancel"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	var overtime *entity.Overtime
+	err := runTransaction(db, func(tx *gorm.DB) error {
+		var err error
+		overtime, err = a.findPending(tx, request.ID)
+		if err != nil {
+			return abort(err)
+		}
+
+		if overtime.CreatedBy != auth.ID {
+			return abort(fmt.Errorf("overtime/not-found"))
+		}
+
+		overtime.Cancel(auth.ID)
+		return a.OvertimeRepository.Update(tx, overtime)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return overtime, nil
+}
+
+// findReviewable loads a pending overtime the caller may decide on, in a payroll period still open
+func (a *OvertimeUseCase) findReviewable(db *gorm.DB, id string, auth *model.Auth) (*entity.Overtime, error) {
+	overtime, err := a.findPending(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.ensureApprover(db, overtime.CreatedBy, auth); err != nil {
+		return nil, err
+	}
+
+	if err := a.ensurePeriodOpen(db, overtime.Date); err != nil {
+		return nil, err
+	}
+
+	return overtime, nil
+}
+
+// ReconcilePeriod compares the overtime claimed by the employees in a payroll period with the whole hours they
+// worked beyond their shift, day by day
+func (a *OvertimeUseCase) ReconcilePeriod(
+	ctx context.Context,
+	request *model.ReconcileOvertimeRequest,
+) (*vm.OvertimeReconciliation, int64, error) {
+	method := "OvertimeUseCase.ReconcilePeriod"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	payrollPeriod := new(entity.PayrollPeriod)
+	if err := a.PayrollPeriodRepository.FindById(db, payrollPeriod, ulid.ULID(v2.MustParse(request.PeriodID))); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, fmt.Errorf("payroll/period-not-found")
+		}
+		panic(err)
+	}
+
+	var (
+		employees []entity.Employee
+		err       error
+	)
+	if request.EmployeeID != "" {
+		employee := new(entity.Employee)
+		if err := a.EmployeeRepository.FindById(db, employee, ulid.ULID(v2.MustParse(request.EmployeeID))); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, 0, fmt.Errorf("employee/not-found")
+			}
+			panic(err)
+		}
+		employees = []entity.Employee{*employee}
+	} else if employees, err = a.EmployeeRepository.FindAllByFilter(db, "", request.Department); err != nil {
+		panic(err)
+	}
+
+	employeeIDs := make([]ulid.ULID, len(employees))
+	for i, employee := range employees {
+		employeeIDs[i] = employee.ID
+	}
+
+	attendances := make([]entity.Attendance, 0)
+	overtimes := make([]entity.Overtime, 0)
+	if len(employeeIDs) > 0 {
+		if attendances, err = a.AttendanceRepository.FindAllByWorkDateRange(db, employeeIDs, payrollPeriod.StartDate, payrollPeriod.EndDate); err != nil {
+			panic(err)
+		}
+		if overtimes, err = a.OvertimeRepository.FindAllActiveByDateRange(db, employeeIDs, payrollPeriod.StartDate, payrollPeriod.EndDate); err != nil {
+			panic(err)
+		}
+	}
+
+	reconciliation := vm.NewOvertimeReconciliation(&vm.CreateOvertimeReconciliationProps{
+		PayrollPeriod:  *payrollPeriod,
+		Employees:      employees,
+		WorkSchedules:  a.attendanceUseCase.GetWorkSchedules(ctx, employees),
+		Attendances:    attendances,
+		Overtimes:      overtimes,
+		BreakAllowance: a.breakAllowance(),
+	})
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return reconciliation.Paginate(request.Page, request.PageSize), int64(len(reconciliation.Employees)), nil
+}
+
+// GetUsage reports the overtime hours the authenticated employee used against the caps of the day, week and month
+// holding the date
+func (a *OvertimeUseCase) GetUsage(
+	ctx context.Context,
+	request *model.GetOvertimeUsageRequest,
+	auth *model.Auth,
+) (*vm.OvertimeUsage, error) {
+	method := "OvertimeUseCase.GetUsage"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	date := timezone.DateOf(time.Now(), auth.Location)
+	if request.Date != "" {
+		var err error
+		if date, err = time.Parse(time.DateOnly, request.Date); err != nil {
+			return nil, fmt.Errorf("overtime/invalid-date")
+		}
+	}
+
+	usage := a.usage(db, auth.ID, date, nil)
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return usage, nil
+}
+
+// usage counts the overtime and overtime plans of the employee against the caps of the day, week and month holding
+// the date, leaving out the plan being confirmed
+func (a *OvertimeUseCase) usage(db *gorm.DB, employeeID ulid.ULID, date time.Time, excludedPlanID *ulid.ULID) *vm.OvertimeUsage {
+	// the week holding the first or last days of the month may stretch into the previous or next month
+	monthStart := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	startDate := monthStart.AddDate(0, 0, -6)
+	endDate := monthStart.AddDate(0, 1, 5)
+
+	overtimes, err := a.OvertimeRepository.FindAllActiveByDateRange(db, []ulid.ULID{employeeID}, startDate, endDate)
+	if err != nil {
+		panic(err)
+	}
+
+	plans, err := a.OvertimePlanRepository.FindAllUnconfirmedByDateRange(db, employeeID, startDate, endDate)
+	if err != nil {
+		panic(err)
+	}
+	if excludedPlanID != nil {
+		kept := make([]entity.OvertimePlan, 0, len(plans))
+		for _, plan := range plans {
+			if plan.ID != *excludedPlanID {
+				kept = append(kept, plan)
+			}
+		}
+		plans = kept
+	}
+
+	caps := a.Config.Overtime.Caps
+	return vm.NewOvertimeUsage(&vm.CreateOvertimeUsageProps{
+		Date:      date,
+		Caps:      vm.OvertimeCaps{Daily: caps.Daily, Weekly: caps.Weekly, Monthly: caps.Monthly},
+		Overtimes: overtimes,
+		Plans:     plans,
+	})
+}
+
+// lockEmployee locks the employee row until the transaction of tx ends, so the overtime and time-off-in-lieu balance
+// of the employee are checked against the caps and the maximum balance and recorded by one request at a time
+func (a *OvertimeUseCase) lockEmployee(tx *gorm.DB, employeeID ulid.ULID) error {
+	return a.EmployeeRepository.FindByIdForUpdate(tx, new(entity.Employee), employeeID)
+}
+
+// ensureWithinCaps checks hours more of overtime on the date stay within the daily, weekly and monthly caps,
+// the error reports the hours left under the exceeded cap
+func (a *OvertimeUseCase) ensureWithinCaps(db *gorm.DB, employeeID ulid.ULID, date time.Time, hours int, excludedPlanID *ulid.ULID) error {
+	if err := a.usage(db, employeeID, date, excludedPlanID).Check(hours); err != nil {
+		return err
+	}
+	return nil
+}
+
+// OvertimePolicy builds the rules for paying overtime from the configuration
+func (a *OvertimeUseCase) OvertimePolicy() vm.OvertimePolicy {
+	return vm.OvertimePolicy{
+		FromAttendance:     a.Config.Overtime.Mode == overtimeModeAttendance,
+		RejectExcessClaims: a.Config.Overtime.ExcessClaimPolicy == overtimeExcessClaimPolicyReject,
+		RequireNetHours:    true,
+		Caps: vm.OvertimeCaps{
+			Daily:   a.Config.Overtime.Caps.Daily,
+			Weekly:  a.Config.Overtime.Caps.Weekly,
+			Monthly: a.Config.Overtime.Caps.Monthly,
+		},
+	}
+}
+
+// ensureClaimsEnabled checks employees claim their overtime, it is derived from attendance otherwise
+func (a *OvertimeUseCase) ensureClaimsEnabled() error {
+	if a.OvertimePolicy().FromAttendance {
+		return fmt.Errorf("overtime/derived-from-attendance")
+	}
+	return nil
+}
+
+// checkClaim rejects a claim exceeding the whole hours worked beyond the shift of a closed attendance, when excess
+// claims are rejected; the claim of an open attendance is checked again when approved and at payroll
+func (a *OvertimeUseCase) checkClaim(db *gorm.DB, attendance *entity.Attendance, hours int) error {
+	if !a.OvertimePolicy().RejectExcessClaims || attendance.IsOpen() {
+		return nil
+	}
+
+	schedule := a.attendanceUseCase.resolveWorkSchedule(db, attendance.CreatedBy)
+	if observed := int(attendance.OvertimeBy(schedule, a.breakAllowance()).Hours()); hours > observed {
+		return fmt.Errorf("overtime/exceeds-worked-time")
+	}
+
+	return nil
+}
+
+// breakAllowance returns the unpaid break time per day included in the shift
+func (a *OvertimeUseCase) breakAllowance() time.Duration {
+	return time.Duration(a.Config.Attendance.BreakAllowance) * time.Minute
+}
+
+// ensureApprover checks the caller may decide on the overtime of the employee: an admin or the manager of the employee
+func (a *OvertimeUseCase) ensureApprover(db *gorm.DB, employeeID ulid.ULID, auth *model.Auth) error {
+	if employeeID == auth.ID {
+		return fmt.Errorf("overtime/cannot-review-own")
+	}
+
+	if !auth.IsAdmin {
+		employee := new(entity.Employee)
+		if err := a.EmployeeRepository.FindById(db, employee, employeeID); err != nil {
+			panic(err)
+		}
+		if !employee.IsManagedBy(auth.ID) {
+			return fmt.Errorf("overtime/not-approver")
+		}
+	}
+
+	return nil
+}
+
+// GetEligibility returns the overtime entitlement history of the employee, oldest change first
+func (a *OvertimeUseCase) GetEligibility(ctx context.Context, employeeID ulid.ULID) entity.OvertimeEligibilities {
+	eligibilities, err := a.OvertimeEligibilityRepository.FindAllByEmployeeId(a.DB.WithContext(ctx), employeeID)
+	if err != nil {
+		panic(err)
+	}
+	return eligibilities
+}
+
+// ensureEligible rejects overtime of an employee not entitled to overtime on the date
+func (a *OvertimeUseCase) ensureEligible(db *gorm.DB, employeeID ulid.ULID, date time.Time) error {
+	eligibilities, err := a.OvertimeEligibilityRepository.FindAllByEmployeeId(db, employeeID)
+	if err != nil {
+		panic(err)
+	}
+	if !eligibilities.IsEligibleOn(date) {
+		return fmt.Errorf("overtime/not-eligible")
+	}
+
+	return nil
+}
+
+// ensurePeriodOpen checks the payroll period of the date is not processed, a processed payslip must not change
+func (a *OvertimeUseCase) ensurePeriodOpen(db *gorm.DB, date time.Time) error {
+	payrollPeriod, err := a.PayrollPeriodRepository.FindByDate(db, date)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		panic(err)
+	}
+	if payrollPeriod != nil && payrollPeriod.IsProcessed() {
+		return fmt.Errorf("overtime/period-already-processed")
+	}
+
+	return nil
+}
+
+// findPending loads a pending overtime and locks its row until the transaction of db ends
+func (a *OvertimeUseCase) findPending(db *gorm.DB, id string) (*entity.Overtime, error) {
+	overtime := new(entity.Overtime)
+	if err := a.OvertimeRepository.FindByIdForUpdate(db, overtime, ulid.ULID(v2.MustParse(id))); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("overtime/not-found")
+		}
+		panic(err)
+	}
+
+	if !overtime.IsPending() {
+		return nil, fmt.Errorf("overtime/already-reviewed")
+	}
+
+	return overtime, nil
+}
@@ -2,14 +2,17 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"payslip-generator-service/config"
 	"payslip-generator-service/internal/entity"
 	"payslip-generator-service/internal/model"
 	"payslip-generator-service/internal/repository"
 	"payslip-generator-service/internal/vm"
 	ulid "payslip-generator-service/pkg/database/gorm"
 	"payslip-generator-service/pkg/logger"
+	"sync"
 	"time"
 
 	v2 "github.com/oklog/ulid/v2"
@@ -20,7 +23,9 @@ import (
 type PayrollUseCase struct {
 	DB                      *gorm.DB
 	Log                     *logger.ContextLogger
+	Config                  *config.Config
 	payrollPeriodRepository *repository.PayrollPeriodRepository
+	payslipTotalRepository  *repository.PayslipTotalRepository
 	attendanceUseCase       *AttendanceUseCase
 	overtimeUseCase         *OvertimeUseCase
 	reimbursementUseCase    *ReimbursementUseCase
@@ -30,7 +35,9 @@ type PayrollUseCase struct {
 func NewPayrollUseCase(
 	db *gorm.DB,
 	log *logger.ContextLogger,
+	config *config.Config,
 	payrollPeriodRepository *repository.PayrollPeriodRepository,
+	payslipTotalRepository *repository.PayslipTotalRepository,
 	attendanceUseCase *AttendanceUseCase,
 	overtimeUseCase *OvertimeUseCase,
 	reimbursementUseCase *ReimbursementUseCase,
@@ -39,7 +46,9 @@ func NewPayrollUseCase(
 	return &PayrollUseCase{
 		DB:                      db,
 		Log:                     log,
+		Config:                  config,
 		payrollPeriodRepository: payrollPeriodRepository,
+		payslipTotalRepository:  payslipTotalRepository,
 		attendanceUseCase:       attendanceUseCase,
 		overtimeUseCase:         overtimeUseCase,
 		reimbursementUseCase:    reimbursementUseCase,
@@ -148,10 +157,41 @@ func (a *PayrollUseCase) ProcessPayroll(ctx context.Context, request *model.Proc
 	}
 
 	payrollPeriod.Process(auth.ID)
-	if err := a.payrollPeriodRepository.Update(db, payrollPeriod); err != nil {
+	// the rules the period is processed with are kept, its payslips are never regenerated with rules introduced later
+	payrollPeriod.AttendanceDeduction = a.Config.Attendance.Deduction.Enabled
+	payrollPeriod.OvertimeNetHours = true
+	payrollPeriod.ReimbursementWindow = true
+
+	employees, err := a.employeeUseCase.ListByFilter(ctx, "", "")
+	if err != nil {
 		panic(err)
 	}
 
+	// sessions left open past their shift are closed first, a session still open is not paid
+	a.attendanceUseCase.CloseStaleSessions(ctx, employees)
+
+	// store the totals of every payslip with the period, the payslip history lists them without regenerating
+	totals := a.newPayslipTotals(ctx, *payrollPeriod, employees)
+
+	// the period stays locked until it is marked processed, a concurrent run waits and finds it processed
+	err = runTransaction(db, func(tx *gorm.DB) error {
+		locked := new(entity.PayrollPeriod)
+		if err := a.payrollPeriodRepository.FindByIdForUpdate(tx, locked, payrollPeriod.ID); err != nil {
+			return err
+		}
+		if locked.IsProcessed() {
+			return abort(fmt.Errorf("payroll/already-processed"))
+		}
+
+		if err := a.payrollPeriodRepository.Update(tx, payrollPeriod); err != nil {
+			return err
+		}
+		return a.payslipTotalRepository.CreateAll(tx, totals)
+	})
+	if err != nil {
+		return err
+	}
+
 	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
 
 	return nil
@@ -161,7 +201,20 @@ func (a *PayrollUseCase) generatePayslip(
 	ctx context.Context,
 	params model.GeneratePayslipRequest,
 ) (*vm.Payslip, error) {
-	method := "PayrollUseCase.generatePayslip"
+	props, err := a.collectPayslipProps(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return vm.NewPayslip(props), nil
+}
+
+// collectPayslipProps loads the attendance, overtime and reimbursement records a payslip is computed from
+func (a *PayrollUseCase) collectPayslipProps(
+	ctx context.Context,
+	params model.GeneratePayslipRequest,
+) (*vm.CreatePayslipProps, error) {
+	method := "PayrollUseCase.collectPayslipProps"
 	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
 	a.Log.WithContext(ctx).WithField("method", method).WithField("request", params).Debug("request")
 
@@ -170,10 +223,12 @@ func (a *PayrollUseCase) generatePayslip(
 	}
 
 	var (
-		attendance    []entity.Attendance
-		overtime      []entity.Overtime
-		reimbursement []entity.Reimbursement
+		attendance      []entity.Attendance
+		priorAttendance []entity.Attendance
+		overtime        []entity.Overtime
+		reimbursement   []entity.Reimbursement
 	)
+	overtimePolicy := a.overtimePolicy(params.Period)
 
 	g, ctx := errgroup.WithContext(ctx)
 
@@ -194,6 +249,31 @@ func (a *PayrollUseCase) generatePayslip(
 		return err
 	})
 
+	// overtime derived from attendance counts against the caps of the weeks and months the period shares with the
+	// days before it
+	if overtimePolicy.FromAttendance {
+		g.Go(func() (returnErr error) {
+			defer func() {
+				if r := recover(); r != nil {
+					a.Log.WithContext(ctx).Error("Panic in prior attendance goroutine:", r)
+					if err, ok := r.(error); ok {
+						returnErr = err
+					} else {
+						returnErr = fmt.Errorf("panic: %v", r)
+					}
+				}
+			}()
+
+			capsStart := vm.OvertimeCapsStart(params.Period.StartDate)
+			if !capsStart.Before(params.Period.StartDate) {
+				return nil
+			}
+			var err error
+			priorAttendance, err = a.attendanceUseCase.ListByPeriod(ctx, params.EmployeeID, capsStart, params.Period.StartDate.AddDate(0, 0, -1))
+			return err
+		})
+	}
+
 	g.Go(func() (returnErr error) {
 		defer func() {
 			if r := recover(); r != nil {
@@ -224,7 +304,7 @@ func (a *PayrollUseCase) generatePayslip(
 		}()
 
 		var err error
-		reimbursement, err = a.reimbursementUseCase.ListByPeriod(ctx, params.EmployeeID, params.Period.StartDate, *params.Period.ProcessedAt)
+		reimbursement, err = a.reimbursementUseCase.ListByPeriod(ctx, params.EmployeeID, params.Period, params.Location)
 		return err
 	})
 
@@ -234,17 +314,168 @@ func (a *PayrollUseCase) generatePayslip(
 
 	a.Log.WithContext(ctx).Info("Generate payslip for period: ", params.Period.StartDate, " to ", params.Period.EndDate)
 
-	payslip := vm.NewPayslip(&vm.CreatePayslipProps{
-		EmployeeID:    params.EmployeeID,
-		Attendance:    attendance,
-		Overtime:      overtime,
-		Reimbursement: reimbursement,
-		PayrollPeriod: params.Period,
-		Salary:        params.Salary,
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+	return &vm.CreatePayslipProps{
+		EmployeeID:          params.EmployeeID,
+		Attendance:          attendance,
+		Overtime:            overtime,
+		Reimbursement:       reimbursement,
+		PayrollPeriod:       params.Period,
+		Salary:              params.Salary,
+		WorkSchedule:        a.attendanceUseCase.GetWorkSchedule(ctx, params.EmployeeID),
+		Location:            params.Location,
+		DeductionPolicy:     a.deductionPolicy(params.Period),
+		BreakAllowance:      time.Duration(a.Config.Attendance.BreakAllowance) * time.Minute,
+		OvertimePolicy:      overtimePolicy,
+		OvertimeEligibility: a.overtimeUseCase.GetEligibility(ctx, params.EmployeeID),
+		PriorAttendance:     priorAttendance,
+	}, nil
+}
+
+// newPayslipTotals generates the payslips of the employees for the period and keeps their totals
+func (a *PayrollUseCase) newPayslipTotals(ctx context.Context, period entity.PayrollPeriod, employees []entity.Employee) []entity.PayslipTotal {
+	departments := make(map[ulid.ULID]*string, len(employees))
+	for _, employee := range employees {
+		departments[employee.ID] = employee.Department
+	}
+
+	payslips := a.generatePayslips(ctx, period, employees)
+	totals := make([]entity.PayslipTotal, 0, len(payslips))
+	for _, payslip := range payslips {
+		totals = append(totals, *newPayslipTotal(period, payslip, departments[payslip.EmployeeID]))
+	}
+	return totals
+}
+
+// newPayslipTotal keeps the totals of a payslip of the processed period, along with the payslip as it is issued
+func newPayslipTotal(period entity.PayrollPeriod, payslip vm.Payslip, department *string) *entity.PayslipTotal {
+	issued, err := json.Marshal(payslip)
+	if err != nil {
+		panic(err)
+	}
+	document := string(issued)
+
+	return entity.NewPayslipTotal(&entity.CreatePayslipTotalProps{
+		PayrollPeriodID:     period.ID,
+		EmployeeID:          payslip.EmployeeID,
+		Department:          department,
+		BasicSalary:         payslip.BasicSalary,
+		Salary:              payslip.Salary,
+		OvertimeAmount:      payslip.Overtime.TotalAmount,
+		ReimbursementAmount: payslip.Reimbursement.TotalAmount,
+		TakeHomePay:         payslip.TakeHomePay,
+		Payslip:             &document,
 	})
+}
 
-	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
-	return payslip, nil
+// processedPayslip returns the payslip of the employee issued when the period was processed, so later salary,
+// schedule or configuration changes do not alter it. Periods processed before payslips were kept are regenerated
+func (a *PayrollUseCase) processedPayslip(ctx context.Context, db *gorm.DB, period entity.PayrollPeriod, employee *entity.Employee) (*vm.Payslip, error) {
+	total, err := a.payslipTotalRepository.FindByEmployeeIdAndPeriodId(db, employee.ID, period.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		panic(err)
+	}
+
+	if total != nil && total.Payslip != nil {
+		payslip := new(vm.Payslip)
+		if err := json.Unmarshal([]byte(*total.Payslip), payslip); err != nil {
+			panic(err)
+		}
+		return payslip, nil
+	}
+
+	if total == nil {
+		hasTotals, err := a.payslipTotalRepository.HasTotals(db, period.ID)
+		if err != nil {
+			panic(err)
+		}
+		if hasTotals {
+			// the employee was not on the payroll when the period was processed
+			return nil, fmt.Errorf("payroll/payslip-not-found")
+		}
+	}
+
+	return a.generatePayslip(ctx, model.GeneratePayslipRequest{
+		EmployeeID: employee.ID,
+		Salary:     employee.Salary,
+		Period:     period,
+		Location:   employee.GetLocation(a.Config.App.TimeZone),
+	})
+}
+
+// deductionPolicy builds the late arrival and early leave deduction rules from the configuration, a processed
+// period deducts only when deductions were enabled as it was processed
+func (a *PayrollUseCase) deductionPolicy(period entity.PayrollPeriod) vm.AttendanceDeductionPolicy {
+	c := a.Config.Attendance.Deduction
+	enabled := c.Enabled
+	if period.IsProcessed() {
+		enabled = period.AttendanceDeduction
+	}
+
+	return vm.AttendanceDeductionPolicy{
+		Enabled:               enabled,
+		LateGracePeriod:       time.Duration(c.LateGracePeriod) * time.Minute,
+		EarlyLeaveGracePeriod: time.Duration(c.EarlyLeaveGracePeriod) * time.Minute,
+	}
+}
+
+// overtimePolicy builds the overtime rules from the configuration, a processed period checks the net worked hours
+// of the claims only when it was processed with the check
+func (a *PayrollUseCase) overtimePolicy(period entity.PayrollPeriod) vm.OvertimePolicy {
+	policy := a.overtimeUseCase.OvertimePolicy()
+	if period.IsProcessed() {
+		policy.RequireNetHours = period.OvertimeNetHours
+	}
+	return policy
+}
+
+func (a *PayrollUseCase) generatePayslips(
+	ctx context.Context,
+	period entity.PayrollPeriod,
+	employees []entity.Employee,
+) []vm.Payslip {
+	var (
+		mu       sync.Mutex
+		payslips = make([]vm.Payslip, 0, len(employees))
+	)
+
+	g, ctx := errgroup.WithContext(ctx)
+
+	for _, employee := range employees {
+		g.Go(func() (returnErr error) {
+			defer func() {
+				if r := recover(); r != nil {
+					a.Log.WithContext(ctx).Error("Panic in payslip goroutine:", r)
+					if err, ok := r.(error); ok {
+						returnErr = err
+					} else {
+						returnErr = fmt.Errorf("panic: %v", r)
+					}
+				}
+			}()
+
+			payslip, err := a.generatePayslip(ctx, model.GeneratePayslipRequest{
+				EmployeeID: employee.ID,
+				Salary:     employee.Salary,
+				Period:     period,
+				Location:   employee.GetLocation(a.Config.App.TimeZone),
+			})
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			payslips = append(payslips, *payslip)
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		panic(err)
+	}
+
+	return payslips
 }
 
 func (a *PayrollUseCase) GetPayslip(ctx context.Context, request *model.GetPayslipRequest, auth *model.Auth) (*vm.Payslip, error) {
@@ -272,21 +503,226 @@ func (a *PayrollUseCase) GetPayslip(ctx context.Context, request *model.GetPaysl
 		panic(err)
 	}
 
-	payslip, err := a.generatePayslip(ctx, model.GeneratePayslipRequest{
+	payslip, err := a.processedPayslip(ctx, db, *payrollPeriod, employee)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return payslip, nil
+}
+
+func (a *PayrollUseCase) ListPayslip(ctx context.Context, request *model.ListPayslipRequest, auth *model.Auth) ([]vm.PayslipSummary, int64, error) {
+	method := "PayrollUseCase.ListPayslip"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	employee, err := a.employeeUseCase.GetById(ctx, auth.ID)
+	if err != nil {
+		panic(err)
+	}
+
+	// a period processed with its totals stored is listed to the employees on its payroll
+	processed := func(tx *gorm.DB) *gorm.DB {
+		return tx.Where("processed_at IS NOT NULL").
+			Where("(EXISTS (SELECT 1 FROM payslip_total t WHERE t.payroll_period_id = payroll_period.id AND t.employee_id = ?) "+
+				"OR NOT EXISTS (SELECT 1 FROM payslip_total t WHERE t.payroll_period_id = payroll_period.id))", employee.ID)
+	}
+
+	periods, total, err := a.payrollPeriodRepository.FindAllWithPagination(db, &model.PaginationOptions{
+		Page:     request.Page,
+		PageSize: request.PageSize,
+		Filter:   &processed,
+		Order: []model.OrderBy{
+			{
+				Column:    "end_date",
+				Direction: model.OrderDirectionDesc,
+			},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	periodIDs := make([]ulid.ULID, 0, len(periods))
+	for _, period := range periods {
+		periodIDs = append(periodIDs, period.ID)
+	}
+
+	stored, err := a.payslipTotalRepository.FindAllByEmployeeIdAndPeriodIds(db, employee.ID, periodIDs)
+	if err != nil {
+		panic(err)
+	}
+
+	totals := make(map[ulid.ULID]entity.PayslipTotal, len(stored))
+	for _, total := range stored {
+		totals[total.PayrollPeriodID] = total
+	}
+
+	summaries := make([]vm.PayslipSummary, 0, len(periods))
+	for _, period := range periods {
+		total, ok := totals[period.ID]
+		if !ok {
+			// periods processed before the totals were stored are regenerated
+			payslip, err := a.generatePayslip(ctx, model.GeneratePayslipRequest{
+				EmployeeID: employee.ID,
+				Salary:     employee.Salary,
+				Period:     period,
+				Location:   employee.GetLocation(a.Config.App.TimeZone),
+			})
+			if err != nil {
+				panic(err)
+			}
+			total = *newPayslipTotal(period, *payslip, employee.Department)
+		}
+
+		summaries = append(summaries, *vm.NewPayslipSummary(&vm.CreatePayslipSummaryProps{
+			PayrollPeriod: period,
+			Total:         total,
+		}))
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return summaries, total, nil
+}
+
+func (a *PayrollUseCase) GetPayslipPDF(ctx context.Context, request *model.GetPayslipRequest, auth *model.Auth) ([]byte, error) {
+	method := "PayrollUseCase.GetPayslipPDF"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	payrollPeriod := new(entity.PayrollPeriod)
+	err := a.payrollPeriodRepository.FindById(db, payrollPeriod, ulid.ULID(v2.MustParse(request.PeriodID)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("payroll/period-not-found")
+		}
+		panic(err)
+	}
+
+	if !payrollPeriod.IsProcessed() {
+		return nil, fmt.Errorf("payroll/not-processed")
+	}
+
+	employee, err := a.employeeUseCase.GetById(ctx, auth.ID)
+	if err != nil {
+		panic(err)
+	}
+
+	payslip, err := a.processedPayslip(ctx, db, *payrollPeriod, employee)
+	if err != nil {
+		return nil, err
+	}
+
+	document := vm.NewPayslipPDF(&vm.CreatePayslipPDFProps{
+		Employee:      *employee,
+		PayrollPeriod: *payrollPeriod,
+		Payslip:       *payslip,
+	})
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return document, nil
+}
+
+func (a *PayrollUseCase) ExplainPayslip(ctx context.Context, request *model.GetPayslipRequest, auth *model.Auth) (*vm.PayslipTrace, error) {
+	method := "PayrollUseCase.ExplainPayslip"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	payrollPeriod := new(entity.PayrollPeriod)
+	err := a.payrollPeriodRepository.FindById(db, payrollPeriod, ulid.ULID(v2.MustParse(request.PeriodID)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("payroll/period-not-found")
+		}
+		panic(err)
+	}
+
+	if !payrollPeriod.IsProcessed() {
+		return nil, fmt.Errorf("payroll/not-processed")
+	}
+
+	employee, err := a.employeeUseCase.GetById(ctx, auth.ID)
+	if err != nil {
+		panic(err)
+	}
+
+	props, err := a.collectPayslipProps(ctx, model.GeneratePayslipRequest{
 		EmployeeID: employee.ID,
 		Salary:     employee.Salary,
 		Period:     *payrollPeriod,
+		Location:   employee.GetLocation(a.Config.App.TimeZone),
 	})
 	if err != nil {
 		panic(err)
 	}
 
+	_, trace := vm.NewPayslipWithTrace(props)
+
 	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
 
-	return payslip, nil
+	return trace, nil
+}
+
+func (a *PayrollUseCase) GetPayslipEstimate(ctx context.Context, auth *model.Auth) (*vm.PayslipEstimate, error) {
+	method := "PayrollUseCase.GetPayslipEstimate"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	db := a.DB.WithContext(ctx)
+	now := time.Now().In(auth.Location)
+
+	payrollPeriod, err := a.payrollPeriodRepository.FindByDate(db, now)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("payroll/current-period-not-found")
+		}
+		panic(err)
+	}
+
+	if payrollPeriod.IsProcessed() {
+		return nil, fmt.Errorf("payroll/already-processed")
+	}
+
+	employee, err := a.employeeUseCase.GetById(ctx, auth.ID)
+	if err != nil {
+		panic(err)
+	}
+
+	// records are collected up to now, the same cutoff the provisional payslip uses
+	period := *payrollPeriod
+	period.ProcessedAt = &now
+
+	props, err := a.collectPayslipProps(ctx, model.GeneratePayslipRequest{
+		EmployeeID: employee.ID,
+		Salary:     employee.Salary,
+		Period:     period,
+		Location:   employee.GetLocation(a.Config.App.TimeZone),
+	})
+	if err != nil {
+		panic(err)
+	}
+	props.PayrollPeriod = *payrollPeriod
+
+	estimate := vm.NewPayslipEstimate(&vm.CreatePayslipEstimateProps{
+		Payslip: *props,
+		Now:     now,
+	})
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return estimate, nil
 }
 
-func (a *PayrollUseCase) GetPayslipReport(ctx context.Context, request *model.GetPayslipRequest, auth *model.Auth) (*vm.PayslipReport, error) {
+func (a *PayrollUseCase) GetPayslipReport(ctx context.Context, request *model.GetPayslipReportRequest, auth *model.Auth) (*vm.PayslipReport, int64, error) {
 	method := "PayrollUseCase.GetPayslipReport"
 	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
 	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
@@ -297,57 +733,116 @@ func (a *PayrollUseCase) GetPayslipReport(ctx context.Context, request *model.Ge
 	err := a.payrollPeriodRepository.FindById(db, payrollPeriod, ulid.ULID(v2.MustParse(request.PeriodID)))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("payroll/period-not-found")
+			return nil, 0, fmt.Errorf("payroll/period-not-found")
 		}
 		panic(err)
 	}
 
 	if !payrollPeriod.IsProcessed() {
-		return nil, fmt.Errorf("payroll/not-processed")
+		return nil, 0, fmt.Errorf("payroll/not-processed")
 	}
 
-	employees, err := a.employeeUseCase.List(ctx)
+	employees, err := a.employeeUseCase.ListByFilter(ctx, request.Search, request.Department)
 	if err != nil {
 		panic(err)
 	}
 
-	var payslips []vm.Payslip
-	g, ctx := errgroup.WithContext(ctx)
+	// the report shows what the period paid, later salary changes do not alter it
+	totals, err := a.payslipTotalRepository.FindAllByPeriodId(db, payrollPeriod.ID)
+	if err != nil {
+		panic(err)
+	}
 
-	for _, employee := range employees {
-		g.Go(func() (returnErr error) {
-			defer func() {
-				if r := recover(); r != nil {
-					a.Log.WithContext(ctx).Error("Panic in payslip goroutine:", r)
-					if err, ok := r.(error); ok {
-						returnErr = err
-					} else {
-						returnErr = fmt.Errorf("panic: %v", r)
-					}
-				}
-			}()
+	if len(totals) == 0 {
+		// periods processed before the totals were stored are regenerated
+		totals = a.newPayslipTotals(ctx, *payrollPeriod, employees)
+	}
 
-			var err error
-			payslip, err := a.generatePayslip(ctx, model.GeneratePayslipRequest{
-				EmployeeID: employee.ID,
-				Salary:     employee.Salary,
-				Period:     *payrollPeriod,
-			})
-			payslips = append(payslips, *payslip)
-			return err
-		})
+	payslipReport := vm.NewPayslipReport(&vm.CreatePayslipReportProps{
+		Employees:      employees,
+		Totals:         totals,
+		MinTakeHomePay: request.MinTakeHomePay,
+		MaxTakeHomePay: request.MaxTakeHomePay,
+		SortBy:         request.SortBy,
+		SortDesc:       request.SortOrder == "desc",
+	})
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return payslipReport.Paginate(request.Page, request.PageSize), int64(len(payslipReport.Employees)), nil
+}
+
+func (a *PayrollUseCase) GetJournal(ctx context.Context, request *model.GetJournalRequest, auth *model.Auth) (*vm.Journal, error) {
+	method := "PayrollUseCase.GetJournal"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	payrollPeriod := new(entity.PayrollPeriod)
+	err := a.payrollPeriodRepository.FindById(db, payrollPeriod, ulid.ULID(v2.MustParse(request.PeriodID)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("payroll/period-not-found")
+		}
+		panic(err)
 	}
 
-	if err := g.Wait(); err != nil {
+	if !payrollPeriod.IsProcessed() {
+		return nil, fmt.Errorf("payroll/not-processed")
+	}
+
+	// the journal books what the period paid, later salary or department changes do not alter it
+	totals, err := a.payslipTotalRepository.FindAllByPeriodId(db, payrollPeriod.ID)
+	if err != nil {
 		panic(err)
 	}
 
-	payslipReport := vm.NewPayslipReport(&vm.CreatePayslipReportProps{
-		Employees: employees,
-		Payslips:  payslips,
+	if len(totals) == 0 {
+		// periods processed before the totals were stored are regenerated
+		employees, err := a.employeeUseCase.List(ctx)
+		if err != nil {
+			panic(err)
+		}
+		totals = a.newPayslipTotals(ctx, *payrollPeriod, employees)
+	}
+
+	journal := vm.NewJournal(&vm.CreateJournalProps{
+		PayrollPeriod: *payrollPeriod,
+		Totals:        totals,
+		Accounts:      a.journalAccountMapping(),
 	})
 
+	if !journal.IsBalanced() {
+		a.Log.WithContext(ctx).WithField("method", method).Error("journal is not balanced: ", journal.TotalDebit, " != ", journal.TotalCredit)
+		return nil, fmt.Errorf("payroll/journal-not-balanced")
+	}
+
 	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
 
-	return payslipReport, nil
+	return journal, nil
+}
+
+func (a *PayrollUseCase) journalAccountMapping() vm.JournalAccountMapping {
+	toAccounts := func(salary, overtime, reimbursement, tax, bpjs, netSalary string) vm.JournalAccounts {
+		return vm.JournalAccounts{
+			vm.JournalComponentSalaryExpense:        salary,
+			vm.JournalComponentOvertimeExpense:      overtime,
+			vm.JournalComponentReimbursementExpense: reimbursement,
+			vm.JournalComponentTaxPayable:           tax,
+			vm.JournalComponentBpjsPayable:          bpjs,
+			vm.JournalComponentNetSalaryPayable:     netSalary,
+		}
+	}
+
+	defaults := a.Config.Accounting.Accounts
+	departments := make(map[string]vm.JournalAccounts, len(a.Config.Accounting.Departments))
+	for department, c := range a.Config.Accounting.Departments {
+		departments[department] = toAccounts(c.SalaryExpense, c.OvertimeExpense, c.ReimbursementExpense, c.TaxPayable, c.BpjsPayable, c.NetSalaryPayable)
+	}
+
+	return vm.JournalAccountMapping{
+		Default:     toAccounts(defaults.SalaryExpense, defaults.OvertimeExpense, defaults.ReimbursementExpense, defaults.TaxPayable, defaults.BpjsPayable, defaults.NetSalaryPayable),
+		Departments: departments,
+	}
 }
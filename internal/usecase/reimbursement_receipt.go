@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"payslip-generator-service/internal/entity"
+	"payslip-generator-service/internal/model"
+	"payslip-generator-service/pkg/storage"
+	"slices"
+
+	ulid "payslip-generator-service/pkg/database/gorm"
+
+	v2 "github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+)
+
+// maxReceiptFileNameLength is the most characters of an uploaded file name kept
+const maxReceiptFileNameLength = 255
+
+// receiptUpload is an uploaded receipt read and checked, waiting to be stored
+type receiptUpload struct {
+	fileName    string
+	contentType string
+	data        []byte
+}
+
+// ListReceipts returns the receipts attached to a reimbursement, in upload order. Visible to the employee, their
+// manager and admins
+func (a *ReimbursementUseCase) ListReceipts(
+	ctx context.Context,
+	request *model.ListReimbursementReceiptRequest,
+	auth *model.Auth,
+) ([]entity.ReimbursementReceipt, error) {
+	method := "ReimbursementUseCase.ListReceipts"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	reimbursement, err := a.findVisible(db, request.ID, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts, err := a.ReimbursementReceiptRepository.FindAllByReimbursementId(db, reimbursement.ID)
+	if err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return receipts, nil
+}
+
+// GetReceipt opens a receipt attached to a reimbursement for download, the caller closes the content. Visible to
+// the employee, their manager and admins
+func (a *ReimbursementUseCase) GetReceipt(
+	ctx context.Context,
+	request *model.GetReimbursementReceiptRequest,
+	auth *model.Auth,
+) (*entity.ReimbursementReceipt, io.ReadCloser, error) {
+	method := "ReimbursementUseCase.GetReceipt"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	reimbursement, err := a.findVisible(db, request.ID, auth)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	receipt, err := a.ReimbursementReceiptRepository.FindByReimbursementId(db, reimbursement.ID, ulid.ULID(v2.MustParse(request.ReceiptID)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, fmt.Errorf("reimbursement/receipt-not-found")
+		}
+		panic(err)
+	}
+
+	content, err := a.Storage.Get(ctx, receipt.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil, fmt.Errorf("reimbursement/receipt-not-found")
+		}
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return receipt, content, nil
+}
+
+// readReceipts reads the uploaded receipts and checks them before anything is stored: at most the configured
+// number of receipts along with the attached ones, within the size limit, of an allowed type declared by the
+// upload and confirmed by the magic bytes of the content
+func (a *ReimbursementUseCase) readReceipts(files []*multipart.FileHeader, attached int) ([]receiptUpload, error) {
+	limits := a.Config.Reimbursement.Receipt
+	if limits.MaxFiles > 0 && attached+len(files) > limits.MaxFiles {
+		return nil, fmt.Errorf("reimbursement/too-many-receipts")
+	}
+
+	uploads := make([]receiptUpload, 0, len(files))
+	for _, file := range files {
+		if limits.MaxFileSize > 0 && file.Size > int64(limits.MaxFileSize) {
+			return nil, fmt.Errorf("reimbursement/receipt-too-large")
+		}
+
+		declared, _, err := mime.ParseMediaType(file.Header.Get("Content-Type"))
+		if err != nil || !slices.Contains(limits.AllowedTypes, declared) {
+			return nil, fmt.Errorf("reimbursement/receipt-type-not-allowed")
+		}
+
+		data, err := readReceipt(file, limits.MaxFileSize)
+		if err != nil {
+			return nil, err
+		}
+
+		// the declared type is chosen by the client, only trust it when the content agrees
+		sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+		if sniffed != declared {
+			return nil, fmt.Errorf("reimbursement/receipt-content-mismatch")
+		}
+
+		uploads = append(uploads, receiptUpload{
+			fileName:    receiptFileName(file.Filename),
+			contentType: sniffed,
+			data:        data,
+		})
+	}
+
+	return uploads, nil
+}
+
+// readReceipt reads the content of an uploaded receipt, without trusting the size reported by the form
+func readReceipt(file *multipart.FileHeader, maxSize int) ([]byte, error) {
+	content, err := file.Open()
+	if err != nil {
+		panic(err)
+	}
+	defer content.Close()
+
+	var reader io.Reader = content
+	if maxSize > 0 {
+		reader = io.LimitReader(content, int64(maxSize)+1)
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		panic(err)
+	}
+
+	switch {
+	case len(data) == 0:
+		return nil, fmt.Errorf("reimbursement/receipt-empty")
+	case maxSize > 0 && len(data) > maxSize:
+		return nil, fmt.Errorf("reimbursement/receipt-too-large")
+	}
+
+	return data, nil
+}
+
+// receiptFileName keeps the base name of an uploaded file, the name is only shown back on download
+func receiptFileName(name string) string {
+	name = filepath.Base(filepath.ToSlash(name))
+	if name == "." || name == "/" {
+		name = "receipt"
+	}
+	if runes := []rune(name); len(runes) > maxReceiptFileNameLength {
+		name = string(runes[len(runes)-maxReceiptFileNameLength:])
+	}
+	return name
+}
+
+// storeReceipts stores the checked receipts of the reimbursement and returns their records, still to be created.
+// The receipts already stored are removed again when one fails
+func (a *ReimbursementUseCase) storeReceipts(
+	ctx context.Context,
+	reimbursement *entity.Reimbursement,
+	uploads []receiptUpload,
+	uploadedBy ulid.ULID,
+) []entity.ReimbursementReceipt {
+	receipts := make([]entity.ReimbursementReceipt, 0, len(uploads))
+	for _, upload := range uploads {
+		receipt := entity.NewReimbursementReceipt(&entity.CreateReimbursementReceiptProps{
+			ReimbursementID: reimbursement.ID,
+			FileName:        upload.fileName,
+			ContentType:     upload.contentType,
+			Size:            len(upload.data),
+			CreatedBy:       uploadedBy,
+		})
+
+		if err := a.Storage.Put(ctx, receipt.StorageKey, upload.data, receipt.ContentType); err != nil {
+			a.discardReceipts(ctx, receipts)
+			panic(err)
+		}
+		receipts = append(receipts, *receipt)
+	}
+
+	return receipts
+}
+
+func (a *ReimbursementUseCase) createReceipts(tx *gorm.DB, receipts []entity.ReimbursementReceipt) error {
+	for i := range receipts {
+		if err := a.ReimbursementReceiptRepository.Create(tx, &receipts[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// discardReceipts removes stored receipts whose records could not be created, a failure only leaves an
+// unreferenced object behind
+func (a *ReimbursementUseCase) discardReceipts(ctx context.Context, receipts []entity.ReimbursementReceipt) {
+	for _, receipt := range receipts {
+		if err := a.Storage.Delete(ctx, receipt.StorageKey); err != nil {
+			a.Log.WithContext(ctx).WithField("key", receipt.StorageKey).Error("failed discard receipt: ", err.Error())
+		}
+	}
+}
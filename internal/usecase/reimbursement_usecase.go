@@ -2,32 +2,55 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"payslip-generator-service/config"
 	"payslip-generator-service/internal/entity"
 	"payslip-generator-service/internal/model"
 	"payslip-generator-service/internal/repository"
 	"payslip-generator-service/pkg/logger"
+	"payslip-generator-service/pkg/storage"
 	"time"
 
 	ulid "payslip-generator-service/pkg/database/gorm"
 
+	v2 "github.com/oklog/ulid/v2"
 	"gorm.io/gorm"
 )
 
 type ReimbursementUseCase struct {
-	DB                      *gorm.DB
-	Log                     *logger.ContextLogger
-	ReimbursementRepository *repository.ReimbursementRepository
+	DB                             *gorm.DB
+	Log                            *logger.ContextLogger
+	Config                         *config.Config
+	Storage                        storage.Storage
+	ReimbursementRepository        *repository.ReimbursementRepository
+	PayrollPeriodRepository        *repository.PayrollPeriodRepository
+	ReimbursementReviewRepository  *repository.ReimbursementReviewRepository
+	EmployeeRepository             *repository.EmployeeRepository
+	ReimbursementReceiptRepository *repository.ReimbursementReceiptRepository
 }
 
 func NewReimbursementUseCase(
 	db *gorm.DB,
 	log *logger.ContextLogger,
+	config *config.Config,
+	storage storage.Storage,
 	reimbursementRepository *repository.ReimbursementRepository,
+	payrollPeriodRepository *repository.PayrollPeriodRepository,
+	reimbursementReviewRepository *repository.ReimbursementReviewRepository,
+	employeeRepository *repository.EmployeeRepository,
+	reimbursementReceiptRepository *repository.ReimbursementReceiptRepository,
 ) *ReimbursementUseCase {
 	return &ReimbursementUseCase{
-		DB:                      db,
-		Log:                     log,
-		ReimbursementRepository: reimbursementRepository,
+		DB:                             db,
+		Log:                            log,
+		Config:                         config,
+		Storage:                        storage,
+		ReimbursementRepository:        reimbursementRepository,
+		PayrollPeriodRepository:        payrollPeriodRepository,
+		ReimbursementReviewRepository:  reimbursementReviewRepository,
+		EmployeeRepository:             employeeRepository,
+		ReimbursementReceiptRepository: reimbursementReceiptRepository,
 	}
 }
 
@@ -50,7 +73,20 @@ func (a *ReimbursementUseCase) Create(
 
 	a.Log.WithContext(ctx).Debug("reimbursement - ", method, reimbursement)
 
-	if err := a.ReimbursementRepository.Create(db, reimbursement); err != nil {
+	uploads, err := a.readReceipts(request.Receipts, 0)
+	if err != nil {
+		return err
+	}
+
+	receipts := a.storeReceipts(ctx, reimbursement, uploads, auth.ID)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := a.ReimbursementRepository.Create(tx, reimbursement); err != nil {
+			return err
+		}
+		return a.createReceipts(tx, receipts)
+	})
+	if err != nil {
+		a.discardReceipts(ctx, receipts)
 		panic(err)
 	}
 
@@ -59,19 +95,53 @@ func (a *ReimbursementUseCase) Create(
 	return nil
 }
 
+// ListByPeriod returns the reimbursements of the employee paid in the payslip of the processed period, the ones
+// approved by finance after the previous period was processed until the period was. A period processed before this
+// rule pays the ones approved from its start date until its processing day, taking the approval day in the location
 func (a *ReimbursementUseCase) ListByPeriod(
 	ctx context.Context,
 	employeeID ulid.ULID,
-	startDate time.Time,
-	endDate time.Time,
+	period entity.PayrollPeriod,
+	location *time.Location,
 ) ([]entity.Reimbursement, error) {
 	method := "ReimbursementUseCase.ListByPeriod"
 	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
-	a.Log.WithContext(ctx).WithField("method", method).WithField("request", startDate).WithField("request", endDate).Debug("request")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", period.ID).Debug("request")
 
 	db := a.DB.WithContext(ctx)
 
-	reimbursements, err := a.ReimbursementRepository.FindByPeriod(db, employeeID, startDate, endDate)
+	if period.ProcessedAt == nil {
+		return nil, fmt.Errorf("payroll/period-not-processed")
+	}
+
+	if !period.ReimbursementWindow {
+		reimbursements, err := a.ReimbursementRepository.FindApprovedByPeriod(db, employeeID, period.StartDate, *period.ProcessedAt, location)
+		if err != nil {
+			panic(err)
+		}
+
+		a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+		return reimbursements, nil
+	}
+
+	// a period pays what finance approved since the previous period was processed, whatever the approval day
+	var after *time.Time
+	previous, err := a.PayrollPeriodRepository.FindProcessedBefore(db, *period.ProcessedAt)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		panic(err)
+	}
+	if previous != nil {
+		after = previous.ProcessedAt
+		if !previous.ReimbursementWindow {
+			// the previous period paid its whole processing day, timestamps are stored to the microsecond
+			year, month, day := previous.ProcessedAt.In(location).Date()
+			endOfDay := time.Date(year, month, day+1, 0, 0, 0, 0, location).Add(-time.Microsecond)
+			after = &endOfDay
+		}
+	}
+
+	reimbursements, err := a.ReimbursementRepository.FindApprovedBetween(db, employeeID, after, *period.ProcessedAt)
 	if err != nil {
 		panic(err)
 	}
@@ -80,3 +150,337 @@ func (a *ReimbursementUseCase) ListByPeriod(
 
 	return reimbursements, nil
 }
+
+func (a *ReimbursementUseCase) List(
+	ctx context.Context,
+	request *model.ListReimbursementRequest,
+	auth *model.Auth,
+) ([]entity.Reimbursement, int64, error) {
+	method := "ReimbursementUseCase.List"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	filter, err := resolveDateRangeFilter(db, a.PayrollPeriodRepository, request.StartDate, request.EndDate, request.PeriodID, request.EmployeeID, auth)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	scope := filter.Scope("DATE(created_at AT TIME ZONE ?)", auth.Location.String())
+	data, total, err := a.ReimbursementRepository.FindAllWithPagination(db, &model.PaginationOptions{
+		Page:     request.Page,
+		PageSize: request.PageSize,
+		Filter:   &scope,
+		Order: []model.OrderBy{
+			{
+				Column:    "created_at",
+				Direction: model.OrderDirectionDesc,
+			},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return data, total, nil
+}
+
+// ListForApproval returns the pending reimbursements waiting for the caller's decision: every pending
+// reimbursement for admins, the reimbursements of their direct reports at the manager stage for managers
+func (a *ReimbursementUseCase) ListForApproval(
+	ctx context.Context,
+	request *model.ListReimbursementApprovalRequest,
+	auth *model.Auth,
+) ([]entity.Reimbursement, int64, error) {
+	method := "ReimbursementUseCase.ListForApproval"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	scope := func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Where("status = ?", entity.ReimbursementStatusPending)
+		if !auth.IsAdmin {
+			tx = tx.Where("stage = ?", entity.ReimbursementStageManager).
+				Where("created_by IN (?)", db.Model(new(entity.Employee)).Select("id").Where("manager_id = ?", auth.ID))
+		}
+		return tx
+	}
+
+	data, total, err := a.ReimbursementRepository.FindAllWithPagination(db, &model.PaginationOptions{
+		Page:     request.Page,
+		PageSize: request.PageSize,
+		Filter:   &scope,
+		Order: []model.OrderBy{
+			{
+				Column:    "created_at",
+				Direction: model.OrderDirectionAsc,
+			},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return data, total, nil
+}
+
+// Approve approves a pending reimbursement at its current stage, in full or in part. The manager stage is decided
+// by an admin or the manager of the employee and passes the reimbursement on to finance, the finance stage is
+// decided by an admin and makes the approved amount payable
+func (a *ReimbursementUseCase) Approve(
+	ctx context.Context,
+	request *model.ReviewReimbursementRequest,
+	auth *model.Auth,
+) (*entity.Reimbursement, error) {
+	method := "ReimbursementUseCase.Approve"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	// the reimbursement stays locked until it is decided, a concurrent review waits and finds it decided
+	var reimbursement *entity.Reimbursement
+	err := runTransaction(db, func(tx *gorm.DB) error {
+		var err error
+		reimbursement, err = a.findReviewable(tx, request.ID, auth)
+		if err != nil {
+			return abort(err)
+		}
+
+		amount := reimbursement.ApprovableAmount()
+		if request.Amount != nil {
+			if *request.Amount > amount {
+				return abort(fmt.Errorf("reimbursement/approved-amount-exceeded"))
+			}
+			amount = *request.Amount
+		}
+
+		review := entity.NewReimbursementReview(&entity.CreateReimbursementReviewProps{
+			Reimbursement: reimbursement,
+			Decision:      entity.ReimbursementDecisionApproved,
+			Amount:        &amount,
+			Comment:       optionalComment(request.Comment),
+			CreatedBy:     auth.ID,
+		})
+		reimbursement.Approve(amount, auth.ID)
+
+		return a.saveReview(tx, reimbursement, review)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return reimbursement, nil
+}
+
+// Reject rejects a pending reimbursement at its current stage with a reason for the employee, by the approvers
+// of the stage
+func (a *ReimbursementUseCase) Reject(
+	ctx context.Context,
+	request *model.ReviewReimbursementRequest,
+	auth *model.Auth,
+) (*entity.Reimbursement, error) {
+	method := "ReimbursementUseCase.Reject"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	if request.Comment == "" {
+		return nil, fmt.Errorf("reimbursement/rejection-reason-required")
+	}
+
+	var reimbursement *entity.Reimbursement
+	err := runTransaction(db, func(tx *gorm.DB) error {
+		var err error
+		reimbursement, err = a.findReviewable(tx, request.ID, auth)
+		if err != nil {
+			return abort(err)
+		}
+
+		review := entity.NewReimbursementReview(&entity.CreateReimbursementReviewProps{
+			Reimbursement: reimbursement,
+			Decision:      entity.ReimbursementDecisionRejected,
+			Comment:       &request.Comment,
+			CreatedBy:     auth.ID,
+		})
+		reimbursement.Reject(request.Comment, auth.ID)
+
+		return a.saveReview(tx, reimbursement, review)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return reimbursement, nil
+}
+
+// Resubmit submits a rejected reimbursement of the authenticated employee again with a corrected amount and
+// description, and any receipts added, it goes through both stages again
+func (a *ReimbursementUseCase) Resubmit(
+	ctx context.Context,
+	request *model.ResubmitReimbursementRequest,
+	auth *model.Auth,
+) (*entity.Reimbursement, error) {
+	method := "ReimbursementUseCase.Resubmit"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	reimbursement, err := a.find(db, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reimbursement.CreatedBy != auth.ID {
+		return nil, fmt.Errorf("reimbursement/not-found")
+	}
+
+	if !reimbursement.IsRejected() {
+		return nil, fmt.Errorf("reimbursement/not-rejected")
+	}
+
+	attached, err := a.ReimbursementReceiptRepository.FindAllByReimbursementId(db, reimbursement.ID)
+	if err != nil {
+		panic(err)
+	}
+
+	uploads, err := a.readReceipts(request.Receipts, len(attached))
+	if err != nil {
+		return nil, err
+	}
+
+	reimbursement.Resubmit(request.Amount, request.Description, auth.ID)
+
+	receipts := a.storeReceipts(ctx, reimbursement, uploads, auth.ID)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := a.ReimbursementRepository.Update(tx, reimbursement); err != nil {
+			return err
+		}
+		return a.createReceipts(tx, receipts)
+	})
+	if err != nil {
+		a.discardReceipts(ctx, receipts)
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return reimbursement, nil
+}
+
+// ListReviews returns the decisions made on a reimbursement across its stages and submissions, oldest first.
+// Visible to the employee, their manager and admins
+func (a *ReimbursementUseCase) ListReviews(
+	ctx context.Context,
+	request *model.ListReimbursementReviewRequest,
+	auth *model.Auth,
+) ([]entity.ReimbursementReview, error) {
+	method := "ReimbursementUseCase.ListReviews"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	reimbursement, err := a.findVisible(db, request.ID, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := a.ReimbursementReviewRepository.FindAllByReimbursementId(db, reimbursement.ID)
+	if err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return reviews, nil
+}
+
+// saveReview stores a decision along with the reimbursement it changed, in the transaction of tx
+func (a *ReimbursementUseCase) saveReview(tx *gorm.DB, reimbursement *entity.Reimbursement, review *entity.ReimbursementReview) error {
+	if err := a.ReimbursementRepository.Update(tx, reimbursement); err != nil {
+		return err
+	}
+	return a.ReimbursementReviewRepository.Create(tx, review)
+}
+
+// findReviewable loads a pending reimbursement the caller may decide on at its current stage, and locks its row
+// until the transaction of db ends
+func (a *ReimbursementUseCase) findReviewable(db *gorm.DB, id string, auth *model.Auth) (*entity.Reimbursement, error) {
+	reimbursement := new(entity.Reimbursement)
+	if err := a.ReimbursementRepository.FindByIdForUpdate(db, reimbursement, ulid.ULID(v2.MustParse(id))); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("reimbursement/not-found")
+		}
+		panic(err)
+	}
+
+	if !reimbursement.IsPending() {
+		return nil, fmt.Errorf("reimbursement/already-reviewed")
+	}
+
+	if reimbursement.CreatedBy == auth.ID {
+		return nil, fmt.Errorf("reimbursement/cannot-review-own")
+	}
+
+	switch reimbursement.Stage {
+	case entity.ReimbursementStageManager:
+		if !auth.IsAdmin && !a.isManagerOf(db, reimbursement.CreatedBy, auth) {
+			return nil, fmt.Errorf("reimbursement/not-approver")
+		}
+	case entity.ReimbursementStageFinance:
+		if !auth.IsAdmin {
+			return nil, fmt.Errorf("reimbursement/not-approver")
+		}
+	}
+
+	return reimbursement, nil
+}
+
+// findVisible loads a reimbursement the caller may look into: their own, one of their direct reports, or any for
+// admins. The others are reported as not found
+func (a *ReimbursementUseCase) findVisible(db *gorm.DB, id string, auth *model.Auth) (*entity.Reimbursement, error) {
+	reimbursement, err := a.find(db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !auth.IsAdmin && reimbursement.CreatedBy != auth.ID && !a.isManagerOf(db, reimbursement.CreatedBy, auth) {
+		return nil, fmt.Errorf("reimbursement/not-found")
+	}
+
+	return reimbursement, nil
+}
+
+// isManagerOf checks if the caller is the manager of the employee
+func (a *ReimbursementUseCase) isManagerOf(db *gorm.DB, employeeID ulid.ULID, auth *model.Auth) bool {
+	employee := new(entity.Employee)
+	if err := a.EmployeeRepository.FindById(db, employee, employeeID); err != nil {
+		panic(err)
+	}
+	return employee.IsManagedBy(auth.ID)
+}
+
+func (a *ReimbursementUseCase) find(db *gorm.DB, id string) (*entity.Reimbursement, error) {
+	reimbursement := new(entity.Reimbursement)
+	if err := a.ReimbursementRepository.FindById(db, reimbursement, ulid.ULID(v2.MustParse(id))); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("reimbursement/not-found")
+		}
+		panic(err)
+	}
+
+	return reimbursement, nil
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// abortedTransaction carries a business error out of a transaction, rolling the transaction back
+type abortedTransaction struct {
+	err error
+}
+
+func (e *abortedTransaction) Error() string {
+	return e.err.Error()
+}
+
+// abort rolls the running transaction back with a business error, a nil error lets the transaction go on
+func abort(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &abortedTransaction{err: err}
+}
+
+// runTransaction runs fn in a transaction and returns the business error it aborted with, any other error is a
+// database error and panics like outside a transaction
+func runTransaction(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	err := db.Transaction(fn)
+
+	var aborted *abortedTransaction
+	if errors.As(err, &aborted) {
+		return aborted.err
+	} else if err != nil {
+		panic(err)
+	}
+
+	return nil
+}
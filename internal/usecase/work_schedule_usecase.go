@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"payslip-generator-service/internal/entity"
+	"payslip-generator-service/internal/model"
+	"payslip-generator-service/internal/repository"
+	ulid "payslip-generator-service/pkg/database/gorm"
+	"payslip-generator-service/pkg/logger"
+
+	v2 "github.com/oklog/ulid/v2"
+	"gorm.io/gorm"
+)
+
+type WorkScheduleUseCase struct {
+	DB                     *gorm.DB
+	Log                    *logger.ContextLogger
+	WorkScheduleRepository *repository.WorkScheduleRepository
+	EmployeeRepository     *repository.EmployeeRepository
+}
+
+func NewWorkScheduleUseCase(
+	db *gorm.DB,
+	log *logger.ContextLogger,
+	workScheduleRepository *repository.WorkScheduleRepository,
+	employeeRepository *repository.EmployeeRepository,
+) *WorkScheduleUseCase {
+	return &WorkScheduleUseCase{
+		DB:                     db,
+		Log:                    log,
+		WorkScheduleRepository: workScheduleRepository,
+		EmployeeRepository:     employeeRepository,
+	}
+}
+
+func (a *WorkScheduleUseCase) Create(
+	ctx context.Context,
+	request *model.CreateWorkScheduleRequest,
+	auth *model.Auth,
+) (*entity.WorkSchedule, error) {
+	method := "WorkScheduleUseCase.Create"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	workDays, ok := entity.NewWorkDays(request.WorkDays)
+	if !ok {
+		return nil, fmt.Errorf("work-schedule/invalid-work-days")
+	}
+
+	schedule := entity.NewWorkSchedule(&entity.CreateWorkScheduleProps{
+		Name:       request.Name,
+		WorkDays:   workDays,
+		ShiftStart: request.ShiftStart,
+		ShiftEnd:   request.ShiftEnd,
+		CreatedBy:  auth.ID,
+	})
+
+	if !schedule.IsValidShift() {
+		return nil, fmt.Errorf("work-schedule/invalid-shift")
+	}
+
+	if err := a.WorkScheduleRepository.Create(db, schedule); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fmt.Errorf("work-schedule/name-already-exists")
+		}
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return schedule, nil
+}
+
+func (a *WorkScheduleUseCase) Update(
+	ctx context.Context,
+	request *model.UpdateWorkScheduleRequest,
+	auth *model.Auth,
+) (*entity.WorkSchedule, error) {
+	method := "WorkScheduleUseCase.Update"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	workDays, ok := entity.NewWorkDays(request.WorkDays)
+	if !ok {
+		return nil, fmt.Errorf("work-schedule/invalid-work-days")
+	}
+
+	schedule := new(entity.WorkSchedule)
+	if err := a.WorkScheduleRepository.FindById(db, schedule, ulid.ULID(v2.MustParse(request.ID))); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("work-schedule/not-found")
+		}
+		panic(err)
+	}
+
+	schedule.Update(request.Name, workDays, request.ShiftStart, request.ShiftEnd, auth.ID)
+	if !schedule.IsValidShift() {
+		return nil, fmt.Errorf("work-schedule/invalid-shift")
+	}
+
+	if err := a.WorkScheduleRepository.Update(db, schedule); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fmt.Errorf("work-schedule/name-already-exists")
+		}
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return schedule, nil
+}
+
+func (a *WorkScheduleUseCase) List(ctx context.Context) ([]entity.WorkSchedule, error) {
+	method := "WorkScheduleUseCase.List"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+
+	db := a.DB.WithContext(ctx)
+
+	schedules, err := a.WorkScheduleRepository.FindAllOrderByName(db)
+	if err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return schedules, nil
+}
+
+// Assign sets the work schedule of the employees, without a schedule they follow the default day shift
+func (a *WorkScheduleUseCase) Assign(
+	ctx context.Context,
+	request *model.AssignWorkScheduleRequest,
+) error {
+	method := "WorkScheduleUseCase.Assign"
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[BEGIN]")
+	a.Log.WithContext(ctx).WithField("method", method).WithField("request", request).Debug("request")
+
+	db := a.DB.WithContext(ctx)
+
+	var scheduleID *ulid.ULID
+	if request.WorkScheduleID != "" {
+		id := ulid.ULID(v2.MustParse(request.WorkScheduleID))
+		total, err := a.WorkScheduleRepository.CountById(db, id)
+		if err != nil {
+			panic(err)
+		}
+		if total == 0 {
+			return fmt.Errorf("work-schedule/not-found")
+		}
+		scheduleID = &id
+	}
+
+	employeeIDs := make([]ulid.ULID, 0, len(request.EmployeeIDs))
+	seen := make(map[ulid.ULID]bool, len(request.EmployeeIDs))
+	for _, id := range request.EmployeeIDs {
+		employeeID := ulid.ULID(v2.MustParse(id))
+		if !seen[employeeID] {
+			seen[employeeID] = true
+			employeeIDs = append(employeeIDs, employeeID)
+		}
+	}
+
+	total, err := a.EmployeeRepository.CountByIds(db, employeeIDs)
+	if err != nil {
+		panic(err)
+	}
+	if total != int64(len(employeeIDs)) {
+		return fmt.Errorf("employee/not-found")
+	}
+
+	if err := a.EmployeeRepository.AssignWorkSchedule(db, employeeIDs, scheduleID); err != nil {
+		panic(err)
+	}
+
+	a.Log.WithContext(ctx).WithField("method", method).Trace("[END]")
+
+	return nil
+}
@@ -0,0 +1,412 @@
+package vm
+
+import (
+	"math"
+	"payslip-generator-service/internal/entity"
+	ulid "payslip-generator-service/pkg/database/gorm"
+	"payslip-generator-service/pkg/timezone"
+	"time"
+)
+
+// AnalyticsBucket is the size of the time buckets of the attendance analytics
+type AnalyticsBucket string
+
+const (
+	AnalyticsBucketDay   AnalyticsBucket = "day"
+	AnalyticsBucketWeek  AnalyticsBucket = "week"
+	AnalyticsBucketMonth AnalyticsBucket = "month"
+)
+
+// AttendanceMetrics represents the attendance figures of an employee or of the company over a date range
+// swagger:model AttendanceMetrics
+type AttendanceMetrics struct {
+	// Work days of the schedule in the range, up to today
+	// example: 20
+	ScheduledDays int `json:"scheduled_days"`
+
+	// Days with an attendance, including days outside the schedule
+	// example: 19
+	AttendedDays int `json:"attended_days"`
+
+	// Scheduled days without an attendance
+	// example: 1
+	AbsentDays int `json:"absent_days"`
+
+	// Percentage of the scheduled days with an attendance
+	// example: 95
+	AttendanceRate float64 `json:"attendance_rate"`
+
+	// Average hours between check-in and check-out without the unpaid breaks, over the days with a check-out
+	// example: 8.75
+	AverageHoursWorked float64 `json:"average_hours_worked"`
+
+	// Days the check-in was later than the shift start plus the late grace period
+	// example: 3
+	LateArrivals int `json:"late_arrivals"`
+
+	// Percentage of the attended days with a late arrival
+	// example: 15.79
+	LateArrivalRate float64 `json:"late_arrival_rate"`
+
+	// Days the employee did not check out: still open or closed automatically
+	// example: 1
+	NoCheckOutDays int `json:"no_check_out_days"`
+}
+
+// AbsenceStreak represents consecutive scheduled days without an attendance, days off in between do not break it
+// swagger:model AbsenceStreak
+type AbsenceStreak struct {
+	// Number of scheduled days in the streak
+	// example: 3
+	Days int `json:"days"`
+
+	// First absent day
+	// example: "2024-01-10T00:00:00Z"
+	StartDate time.Time `json:"start_date"`
+
+	// Last absent day
+	// example: "2024-01-12T00:00:00Z"
+	EndDate time.Time `json:"end_date"`
+}
+
+// AttendanceAnalyticsBucket represents the attendance figures of a day, week or month
+// swagger:model AttendanceAnalyticsBucket
+type AttendanceAnalyticsBucket struct {
+	// First day of the bucket within the range
+	// example: "2024-01-01T00:00:00Z"
+	StartDate time.Time `json:"start_date"`
+
+	// Last day of the bucket within the range
+	// example: "2024-01-07T00:00:00Z"
+	EndDate time.Time `json:"end_date"`
+
+	AttendanceMetrics
+}
+
+// AttendanceAnalyticsEmployee represents the attendance figures of an employee
+// swagger:model AttendanceAnalyticsEmployee
+type AttendanceAnalyticsEmployee struct {
+	// Unique identifier of the employee
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	EmployeeID ulid.ULID `json:"id"`
+
+	// Username of the employee
+	// example: "john.doe"
+	EmployeeUsername string `json:"username"`
+
+	// Department of the employee, empty when unassigned
+	// example: "engineering"
+	Department string `json:"department"`
+
+	AttendanceMetrics
+
+	// Longest absence streak of the employee in the range, empty without absences
+	LongestAbsenceStreak *AbsenceStreak `json:"longest_absence_streak"`
+
+	// Figures per bucket
+	Buckets []AttendanceAnalyticsBucket `json:"buckets"`
+}
+
+// AttendanceAnalytics represents the attendance figures of the employees and of the company over a date range
+// swagger:model AttendanceAnalytics
+type AttendanceAnalytics struct {
+	// First day of the range
+	// example: "2024-01-01T00:00:00Z"
+	StartDate time.Time `json:"start_date"`
+
+	// Last day of the range
+	// example: "2024-01-31T00:00:00Z"
+	EndDate time.Time `json:"end_date"`
+
+	// Size of the buckets
+	// example: "week"
+	Bucket AnalyticsBucket `json:"bucket"`
+
+	// Company-wide figures over every employee of the filtered set
+	Company AttendanceMetrics `json:"company"`
+
+	// Company-wide figures per bucket
+	CompanyBuckets []AttendanceAnalyticsBucket `json:"company_buckets"`
+
+	// Number of employees of the filtered set with an absence streak of at least 3 scheduled days
+	// example: 2
+	EmployeesWithAbsenceStreak int `json:"employees_with_absence_streak"`
+
+	// Figures per employee
+	Employees []AttendanceAnalyticsEmployee `json:"employees"`
+}
+
+// CreateAttendanceAnalyticsProps represents the properties needed to create new attendance analytics
+// swagger:model CreateAttendanceAnalyticsProps
+type CreateAttendanceAnalyticsProps struct {
+	// Employees to report on
+	Employees []entity.Employee
+	// Work schedule of each employee, keyed by employee ID
+	WorkSchedules map[ulid.ULID]*entity.WorkSchedule
+	// Attendance records of the employees in the range
+	Attendances []entity.Attendance
+	// First day of the range
+	StartDate time.Time
+	// Last day of the range
+	EndDate time.Time
+	// Size of the buckets
+	Bucket AnalyticsBucket
+	// Lateness up to this duration is not counted as a late arrival
+	LateGracePeriod time.Duration
+	// Default time zone of employees without one
+	DefaultTimeZone string
+	// Time the analytics are computed at, scheduled days after today are not counted
+	Now time.Time
+}
+
+// absenceStreakThreshold is the number of scheduled days an absence streak must last to be reported company-wide
+const absenceStreakThreshold = 3
+
+// attendanceTally accumulates the attendance figures of a set of days
+type attendanceTally struct {
+	scheduled  int
+	attended   int
+	attendedOn int // attended days that were scheduled
+	late       int
+	noCheckOut int
+	closed     int
+	hours      float64
+}
+
+func (t *attendanceTally) add(other *attendanceTally) {
+	t.scheduled += other.scheduled
+	t.attended += other.attended
+	t.attendedOn += other.attendedOn
+	t.late += other.late
+	t.noCheckOut += other.noCheckOut
+	t.closed += other.closed
+	t.hours += other.hours
+}
+
+func (t *attendanceTally) metrics() AttendanceMetrics {
+	m := AttendanceMetrics{
+		ScheduledDays:  t.scheduled,
+		AttendedDays:   t.attended,
+		AbsentDays:     t.scheduled - t.attendedOn,
+		LateArrivals:   t.late,
+		NoCheckOutDays: t.noCheckOut,
+	}
+	if t.scheduled > 0 {
+		m.AttendanceRate = roundTwo(float64(t.attendedOn) / float64(t.scheduled) * 100)
+	}
+	if t.attended > 0 {
+		m.LateArrivalRate = roundTwo(float64(t.late) / float64(t.attended) * 100)
+	}
+	if t.closed > 0 {
+		m.AverageHoursWorked = roundTwo(t.hours / float64(t.closed))
+	}
+	return m
+}
+
+func NewAttendanceAnalytics(props *CreateAttendanceAnalyticsProps) *AttendanceAnalytics {
+	startDate := dateOnly(props.StartDate)
+	endDate := dateOnly(props.EndDate)
+
+	attendances := make(map[ulid.ULID]map[time.Time]entity.Attendance, len(props.Employees))
+	for _, a := range props.Attendances {
+		if _, ok := attendances[a.CreatedBy]; !ok {
+			attendances[a.CreatedBy] = make(map[time.Time]entity.Attendance)
+		}
+		attendances[a.CreatedBy][dateOnly(a.WorkDate)] = a
+	}
+
+	bucketStarts := make([]time.Time, 0)
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		if start := bucketStart(day, props.Bucket); len(bucketStarts) == 0 || !bucketStarts[len(bucketStarts)-1].Equal(start) {
+			bucketStarts = append(bucketStarts, start)
+		}
+	}
+
+	company := new(attendanceTally)
+	companyBuckets := make([]attendanceTally, len(bucketStarts))
+	employees := make([]AttendanceAnalyticsEmployee, 0, len(props.Employees))
+	withStreak := 0
+
+	for _, employee := range props.Employees {
+		schedule := props.WorkSchedules[employee.ID]
+		location := employee.GetLocation(props.DefaultTimeZone)
+		today := timezone.DateOf(props.Now, location)
+
+		total := new(attendanceTally)
+		buckets := make([]attendanceTally, len(bucketStarts))
+		var longest, current *AbsenceStreak
+
+		bucket := 0
+		for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+			for bucket+1 < len(bucketStarts) && !day.Before(bucketStarts[bucket+1]) {
+				bucket++
+			}
+
+			counted := tallyDay(schedule, attendances[employee.ID], day, today, location, props.LateGracePeriod)
+			total.add(&counted.tally)
+			buckets[bucket].add(&counted.tally)
+
+			switch {
+			case counted.absent && current == nil:
+				current = &AbsenceStreak{Days: 1, StartDate: day, EndDate: day}
+			case counted.absent:
+				current.Days++
+				current.EndDate = day
+			case counted.tally.attendedOn > 0:
+				current = nil
+			}
+			if current != nil && (longest == nil || current.Days > longest.Days) {
+				streak := *current
+				longest = &streak
+			}
+		}
+
+		if longest != nil && longest.Days >= absenceStreakThreshold {
+			withStreak++
+		}
+
+		company.add(total)
+		for i := range buckets {
+			companyBuckets[i].add(&buckets[i])
+		}
+
+		employees = append(employees, AttendanceAnalyticsEmployee{
+			EmployeeID:           employee.ID,
+			EmployeeUsername:     employee.Username,
+			Department:           employee.GetDepartment(),
+			AttendanceMetrics:    total.metrics(),
+			LongestAbsenceStreak: longest,
+			Buckets:              newAnalyticsBuckets(bucketStarts, buckets, startDate, endDate, props.Bucket),
+		})
+	}
+
+	return &AttendanceAnalytics{
+		StartDate:                  startDate,
+		EndDate:                    endDate,
+		Bucket:                     props.Bucket,
+		Company:                    company.metrics(),
+		CompanyBuckets:             newAnalyticsBuckets(bucketStarts, companyBuckets, startDate, endDate, props.Bucket),
+		EmployeesWithAbsenceStreak: withStreak,
+		Employees:                  employees,
+	}
+}
+
+// Paginate returns a copy of the analytics holding only the requested page of employees;
+// company-wide figures are kept over the full set
+func (r *AttendanceAnalytics) Paginate(page, pageSize int) *AttendanceAnalytics {
+	start := min((page-1)*pageSize, len(r.Employees))
+	end := min(start+pageSize, len(r.Employees))
+
+	analytics := *r
+	analytics.Employees = r.Employees[start:end]
+	return &analytics
+}
+
+// analyticsDay is the tally of a single day of an employee
+type analyticsDay struct {
+	absent bool
+	tally  attendanceTally
+}
+
+// tallyDay counts a day of an employee; days after today are neither scheduled nor absent
+func tallyDay(
+	schedule *entity.WorkSchedule,
+	attendances map[time.Time]entity.Attendance,
+	date, today time.Time,
+	location *time.Location,
+	lateGracePeriod time.Duration,
+) analyticsDay {
+	var day analyticsDay
+	scheduled := schedule.IsWorkDay(date) && !date.After(today)
+	if scheduled {
+		day.tally.scheduled = 1
+	}
+
+	attendance, ok := attendances[date]
+	if !ok {
+		// today is not absent yet, the employee may still check in
+		day.absent = scheduled && date.Before(today)
+		return day
+	}
+
+	attendance.In(location)
+	day.tally.attended = 1
+	if scheduled {
+		day.tally.attendedOn = 1
+	}
+	if attendance.LateBy(schedule) > lateGracePeriod {
+		day.tally.late = 1
+	}
+	switch {
+	case attendance.AutoCheckedOut:
+		day.tally.noCheckOut = 1
+	case attendance.IsOpen() && date.Before(today):
+		// a session of today is still in progress
+		day.tally.noCheckOut = 1
+	}
+	if !attendance.IsOpen() {
+		day.tally.closed = 1
+		day.tally.hours = attendance.GetNetDurationInHours()
+	}
+
+	return day
+}
+
+func newAnalyticsBuckets(starts []time.Time, tallies []attendanceTally, startDate, endDate time.Time, size AnalyticsBucket) []AttendanceAnalyticsBucket {
+	buckets := make([]AttendanceAnalyticsBucket, len(starts))
+	for i, start := range starts {
+		end := bucketEnd(start, size)
+		buckets[i] = AttendanceAnalyticsBucket{
+			StartDate:         latest(start, startDate),
+			EndDate:           earliest(end, endDate),
+			AttendanceMetrics: tallies[i].metrics(),
+		}
+	}
+	return buckets
+}
+
+// bucketStart returns the first day of the bucket holding the day, weeks start on Monday
+func bucketStart(day time.Time, size AnalyticsBucket) time.Time {
+	switch size {
+	case AnalyticsBucketWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case AnalyticsBucketMonth:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// bucketEnd returns the last day of the bucket starting on the day
+func bucketEnd(start time.Time, size AnalyticsBucket) time.Time {
+	switch size {
+	case AnalyticsBucketWeek:
+		return start.AddDate(0, 0, 6)
+	case AnalyticsBucketMonth:
+		return start.AddDate(0, 1, -1)
+	default:
+		return start
+	}
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func roundTwo(value float64) float64 {
+	return math.Round(value*100) / 100
+}
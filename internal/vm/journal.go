@@ -0,0 +1,222 @@
+package vm
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"payslip-generator-service/internal/entity"
+	ulid "payslip-generator-service/pkg/database/gorm"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JournalComponent identifies which part of the payroll a journal line books
+type JournalComponent string
+
+const (
+	JournalComponentSalaryExpense        JournalComponent = "salary_expense"
+	JournalComponentOvertimeExpense      JournalComponent = "overtime_expense"
+	JournalComponentReimbursementExpense JournalComponent = "reimbursement_expense"
+	JournalComponentTaxPayable           JournalComponent = "tax_payable"
+	JournalComponentBpjsPayable          JournalComponent = "bpjs_payable"
+	JournalComponentNetSalaryPayable     JournalComponent = "net_salary_payable"
+)
+
+// JournalAccounts maps each journal component to a general ledger account code
+type JournalAccounts map[JournalComponent]string
+
+// JournalAccountMapping holds the default account mapping and optional per-department overrides
+type JournalAccountMapping struct {
+	// Default account codes used when a department has no override
+	Default JournalAccounts
+	// Account overrides keyed by lower-cased department name
+	Departments map[string]JournalAccounts
+}
+
+// Resolve returns the account code for a component, preferring the department override
+func (m JournalAccountMapping) Resolve(department string, component JournalComponent) string {
+	if accounts, ok := m.Departments[strings.ToLower(department)]; ok {
+		if code := accounts[component]; code != "" {
+			return code
+		}
+	}
+	return m.Default[component]
+}
+
+// JournalLine represents a single debit or credit line of a payroll journal
+// swagger:model JournalLine
+type JournalLine struct {
+	// General ledger account code
+	// example: "6100"
+	AccountCode string `json:"account_code"`
+
+	// Payroll component booked on this line
+	// example: "salary_expense"
+	Component JournalComponent `json:"component"`
+
+	// Department dimension of the line, empty when unassigned
+	// example: "engineering"
+	Department string `json:"department"`
+
+	// Human readable description of the line
+	// example: "Salary expense 2024-01-01 - 2024-01-31"
+	Description string `json:"description"`
+
+	// Debit amount
+	// example: 4500000
+	Debit int `json:"debit"`
+
+	// Credit amount
+	// example: 0
+	Credit int `json:"credit"`
+}
+
+// Journal represents a balanced double-entry journal for a processed payroll period
+// swagger:model Journal
+type Journal struct {
+	// Unique identifier of the payroll period
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	PeriodID ulid.ULID `json:"period_id"`
+
+	// Start date of the payroll period
+	// example: "2024-01-01T00:00:00Z"
+	StartDate time.Time `json:"start_date"`
+
+	// End date of the payroll period
+	// example: "2024-01-31T00:00:00Z"
+	EndDate time.Time `json:"end_date"`
+
+	// Posting date of the journal, the time the payroll was processed
+	// example: "2024-01-31T23:59:59Z"
+	PostingDate time.Time `json:"posting_date"`
+
+	// Journal lines
+	Lines []JournalLine `json:"lines"`
+
+	// Sum of all debit lines
+	// example: 5000000
+	TotalDebit int `json:"total_debit"`
+
+	// Sum of all credit lines
+	// example: 5000000
+	TotalCredit int `json:"total_credit"`
+}
+
+// CreateJournalProps represents the properties needed to create a new journal
+// swagger:model CreateJournalProps
+type CreateJournalProps struct {
+	// Processed payroll period
+	PayrollPeriod entity.PayrollPeriod
+	// Payslip totals of the period, stored when it was processed
+	Totals []entity.PayslipTotal
+	// Account mapping used to resolve account codes
+	Accounts JournalAccountMapping
+}
+
+type journalTotals struct {
+	salary        int
+	overtime      int
+	reimbursement int
+	tax           int
+	bpjs          int
+}
+
+func NewJournal(props *CreateJournalProps) *Journal {
+	// aggregate payslip components per department
+	totals := make(map[string]*journalTotals)
+	for _, total := range props.Totals {
+		department := total.GetDepartment()
+		if _, ok := totals[department]; !ok {
+			totals[department] = new(journalTotals)
+		}
+
+		// the payslip carries no statutory deductions yet, so tax and BPJS stay at zero
+		totals[department].salary += total.Salary
+		totals[department].overtime += total.OvertimeAmount
+		totals[department].reimbursement += total.ReimbursementAmount
+	}
+
+	departments := make([]string, 0, len(totals))
+	for department := range totals {
+		departments = append(departments, department)
+	}
+	sort.Strings(departments)
+
+	period := fmt.Sprintf("%s - %s", props.PayrollPeriod.StartDate.Format(time.DateOnly), props.PayrollPeriod.EndDate.Format(time.DateOnly))
+
+	lines := make([]JournalLine, 0)
+	totalDebit := 0
+	totalCredit := 0
+	addLine := func(department string, component JournalComponent, description string, debit, credit int) {
+		if debit == 0 && credit == 0 {
+			return
+		}
+
+		lines = append(lines, JournalLine{
+			AccountCode: props.Accounts.Resolve(department, component),
+			Component:   component,
+			Department:  department,
+			Description: fmt.Sprintf("%s %s", description, period),
+			Debit:       debit,
+			Credit:      credit,
+		})
+		totalDebit += debit
+		totalCredit += credit
+	}
+
+	for _, department := range departments {
+		t := totals[department]
+		gross := t.salary + t.overtime + t.reimbursement
+
+		addLine(department, JournalComponentSalaryExpense, "Salary expense", t.salary, 0)
+		addLine(department, JournalComponentOvertimeExpense, "Overtime expense", t.overtime, 0)
+		addLine(department, JournalComponentReimbursementExpense, "Reimbursement expense", t.reimbursement, 0)
+		addLine(department, JournalComponentTaxPayable, "Income tax payable", 0, t.tax)
+		addLine(department, JournalComponentBpjsPayable, "BPJS payable", 0, t.bpjs)
+		addLine(department, JournalComponentNetSalaryPayable, "Net salary payable", 0, gross-t.tax-t.bpjs)
+	}
+
+	return &Journal{
+		PeriodID:    props.PayrollPeriod.ID,
+		StartDate:   props.PayrollPeriod.StartDate,
+		EndDate:     props.PayrollPeriod.EndDate,
+		PostingDate: *props.PayrollPeriod.ProcessedAt,
+		Lines:       lines,
+		TotalDebit:  totalDebit,
+		TotalCredit: totalCredit,
+	}
+}
+
+// IsBalanced checks if the total debit equals the total credit
+func (j *Journal) IsBalanced() bool {
+	return j.TotalDebit == j.TotalCredit
+}
+
+// WriteCSV writes the journal lines as CSV, one row per line
+func (j *Journal) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"posting_date", "account_code", "component", "department", "description", "debit", "credit"}); err != nil {
+		return err
+	}
+
+	postingDate := j.PostingDate.Format(time.DateOnly)
+	for _, line := range j.Lines {
+		if err := writer.Write([]string{
+			postingDate,
+			line.AccountCode,
+			string(line.Component),
+			line.Department,
+			line.Description,
+			strconv.Itoa(line.Debit),
+			strconv.Itoa(line.Credit),
+		}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,221 @@
+package vm
+
+import (
+	"payslip-generator-service/internal/entity"
+	ulid "payslip-generator-service/pkg/database/gorm"
+	"time"
+)
+
+// OvertimeReconciliationDay compares the overtime claimed on a day with the hours worked beyond the shift
+// swagger:model OvertimeReconciliationDay
+type OvertimeReconciliationDay struct {
+	// Calendar day of the overtime and the attendance
+	// example: "2024-01-15T00:00:00Z"
+	Date time.Time `json:"date"`
+
+	// Attendance of the day, empty without an attendance
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	AttendanceID *ulid.ULID `json:"attendance_id"`
+
+	// Hours between check-in and check-out without the unpaid breaks, zero while the attendance is open
+	// example: 10.5
+	NetHours float64 `json:"net_hours"`
+
+	// Whole hours worked beyond the shift
+	// example: 2
+	ObservedHours int `json:"observed_hours"`
+
+	// Pending or approved overtime claimed for the day, empty without a claim
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	OvertimeID *ulid.ULID `json:"overtime_id"`
+
+	// Approval state of the claim
+	// example: "approved"
+	Status entity.OvertimeStatus `json:"status,omitempty"`
+
+	// Hours claimed, the actual hours for overtime confirmed from a plan
+	// example: 3
+	ClaimedHours int `json:"claimed_hours"`
+
+	// Hours claimed beyond the observed hours
+	// example: 1
+	ExcessHours int `json:"excess_hours"`
+
+	// Observed hours not claimed
+	// example: 0
+	UnclaimedHours int `json:"unclaimed_hours"`
+}
+
+// OvertimeReconciliationTotals represents the claimed and observed overtime hours of a set of days
+// swagger:model OvertimeReconciliationTotals
+type OvertimeReconciliationTotals struct {
+	// Total hours claimed
+	// example: 12
+	ClaimedHours int `json:"claimed_hours"`
+
+	// Total whole hours worked beyond the shift
+	// example: 9
+	ObservedHours int `json:"observed_hours"`
+
+	// Total hours claimed beyond the observed hours
+	// example: 3
+	ExcessHours int `json:"excess_hours"`
+
+	// Total observed hours not claimed
+	// example: 0
+	UnclaimedHours int `json:"unclaimed_hours"`
+
+	// Number of days claiming more hours than were observed
+	// example: 2
+	FlaggedDays int `json:"flagged_days"`
+}
+
+func (t *OvertimeReconciliationTotals) add(day *OvertimeReconciliationDay) {
+	t.ClaimedHours += day.ClaimedHours
+	t.ObservedHours += day.ObservedHours
+	t.ExcessHours += day.ExcessHours
+	t.UnclaimedHours += day.UnclaimedHours
+	if day.ExcessHours > 0 {
+		t.FlaggedDays++
+	}
+}
+
+// OvertimeReconciliationEmployee represents the claimed and observed overtime of an employee
+// swagger:model OvertimeReconciliationEmployee
+type OvertimeReconciliationEmployee struct {
+	// Unique identifier of the employee
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	EmployeeID ulid.ULID `json:"id"`
+
+	// Username of the employee
+	// example: "john.doe"
+	EmployeeUsername string `json:"username"`
+
+	// Department of the employee, empty when unassigned
+	// example: "engineering"
+	Department string `json:"department"`
+
+	OvertimeReconciliationTotals
+
+	// Days with a claim or with hours worked beyond the shift
+	Days []OvertimeReconciliationDay `json:"days"`
+}
+
+// OvertimeReconciliation represents the overtime claimed against the overtime observed from attendance in a period
+// swagger:model OvertimeReconciliation
+type OvertimeReconciliation struct {
+	// Unique identifier of the payroll period
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	PeriodID ulid.ULID `json:"period_id"`
+
+	// First day of the period
+	// example: "2024-01-01T00:00:00Z"
+	StartDate time.Time `json:"start_date"`
+
+	// Last day of the period
+	// example: "2024-01-31T00:00:00Z"
+	EndDate time.Time `json:"end_date"`
+
+	// Company-wide figures over every employee of the filtered set
+	Company OvertimeReconciliationTotals `json:"company"`
+
+	// Figures per employee
+	Employees []OvertimeReconciliationEmployee `json:"employees"`
+}
+
+// CreateOvertimeReconciliationProps represents the properties needed to create a new overtime reconciliation
+// swagger:model CreateOvertimeReconciliationProps
+type CreateOvertimeReconciliationProps struct {
+	// Payroll period to reconcile
+	PayrollPeriod entity.PayrollPeriod
+	// Employees to reconcile
+	Employees []entity.Employee
+	// Work schedule of each employee, keyed by employee ID
+	WorkSchedules map[ulid.ULID]*entity.WorkSchedule
+	// Attendance records of the employees in the period
+	Attendances []entity.Attendance
+	// Pending and approved overtime of the employees in the period
+	Overtimes []entity.Overtime
+	// Unpaid break time per day included in the shift
+	BreakAllowance time.Duration
+}
+
+func NewOvertimeReconciliation(props *CreateOvertimeReconciliationProps) *OvertimeReconciliation {
+	attendances := make(map[ulid.ULID]map[time.Time]entity.Attendance, len(props.Employees))
+	for _, a := range props.Attendances {
+		if _, ok := attendances[a.CreatedBy]; !ok {
+			attendances[a.CreatedBy] = make(map[time.Time]entity.Attendance)
+		}
+		attendances[a.CreatedBy][dateOnly(a.WorkDate)] = a
+	}
+
+	overtimes := make(map[ulid.ULID]map[time.Time]entity.Overtime, len(props.Employees))
+	for _, o := range props.Overtimes {
+		if _, ok := overtimes[o.CreatedBy]; !ok {
+			overtimes[o.CreatedBy] = make(map[time.Time]entity.Overtime)
+		}
+		overtimes[o.CreatedBy][dateOnly(o.Date)] = o
+	}
+
+	startDate := dateOnly(props.PayrollPeriod.StartDate)
+	endDate := dateOnly(props.PayrollPeriod.EndDate)
+
+	company := OvertimeReconciliationTotals{}
+	employees := make([]OvertimeReconciliationEmployee, 0, len(props.Employees))
+	for _, employee := range props.Employees {
+		schedule := props.WorkSchedules[employee.ID]
+		reconciled := OvertimeReconciliationEmployee{
+			EmployeeID:       employee.ID,
+			EmployeeUsername: employee.Username,
+			Department:       employee.GetDepartment(),
+			Days:             make([]OvertimeReconciliationDay, 0),
+		}
+
+		for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+			d := OvertimeReconciliationDay{Date: day}
+
+			if a, ok := attendances[employee.ID][day]; ok {
+				d.AttendanceID = &a.ID
+				d.NetHours = roundTwo(a.GetNetDurationInHours())
+				if schedule != nil {
+					d.ObservedHours = int(a.OvertimeBy(schedule, props.BreakAllowance).Hours())
+				}
+			}
+			if o, ok := overtimes[employee.ID][day]; ok {
+				d.OvertimeID = &o.ID
+				d.Status = o.Status
+				d.ClaimedHours = o.TotalHours
+			}
+			if d.ClaimedHours == 0 && d.ObservedHours == 0 {
+				continue
+			}
+
+			d.ExcessHours = max(d.ClaimedHours-d.ObservedHours, 0)
+			d.UnclaimedHours = max(d.ObservedHours-d.ClaimedHours, 0)
+			reconciled.add(&d)
+			company.add(&d)
+			reconciled.Days = append(reconciled.Days, d)
+		}
+
+		employees = append(employees, reconciled)
+	}
+
+	return &OvertimeReconciliation{
+		PeriodID:  props.PayrollPeriod.ID,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Company:   company,
+		Employees: employees,
+	}
+}
+
+// Paginate returns a copy of the reconciliation holding only the requested page of employees;
+// company-wide figures are kept over the full set
+func (r *OvertimeReconciliation) Paginate(page, pageSize int) *OvertimeReconciliation {
+	start := min((page-1)*pageSize, len(r.Employees))
+	end := min(start+pageSize, len(r.Employees))
+
+	reconciliation := *r
+	reconciliation.Employees = r.Employees[start:end]
+	return &reconciliation
+}
@@ -0,0 +1,224 @@
+package vm
+
+import (
+	"fmt"
+	"payslip-generator-service/internal/entity"
+	"time"
+)
+
+// OvertimeCap names the span of an overtime cap
+type OvertimeCap string
+
+const (
+	OvertimeCapDaily   OvertimeCap = "daily"
+	OvertimeCapWeekly  OvertimeCap = "weekly"
+	OvertimeCapMonthly OvertimeCap = "monthly"
+)
+
+// OvertimeCaps holds the most hours of overtime an employee may work in a day, a week and a month, zero leaves the
+// span uncapped
+type OvertimeCaps struct {
+	Daily   int
+	Weekly  int
+	Monthly int
+}
+
+// OvertimeCapUsage represents the overtime hours used against a cap
+// swagger:model OvertimeCapUsage
+type OvertimeCapUsage struct {
+	// Span of the cap
+	// example: "weekly"
+	Cap OvertimeCap `json:"cap"`
+
+	// First day of the span, weeks start on Monday
+	// example: "2024-01-15T00:00:00Z"
+	StartDate time.Time `json:"start_date"`
+
+	// Last day of the span
+	// example: "2024-01-21T00:00:00Z"
+	EndDate time.Time `json:"end_date"`
+
+	// Most hours of overtime in the span, empty when the span is uncapped
+	// example: 18
+	Limit *int `json:"limit"`
+
+	// Hours of pending and approved overtime and of pending and approved plans in the span
+	// example: 14
+	Used int `json:"used"`
+
+	// Hours left under the cap, empty when the span is uncapped
+	// example: 4
+	Remaining *int `json:"remaining"`
+}
+
+// Allows checks if hours more of overtime stay within the cap
+func (u *OvertimeCapUsage) Allows(hours int) bool {
+	return u.Remaining == nil || hours <= *u.Remaining
+}
+
+// OvertimeCapError reports overtime exceeding a cap along with the hours left under it
+// swagger:model OvertimeCapError
+type OvertimeCapError struct {
+	// Error code
+	// example: "overtime/weekly-cap-exceeded"
+	Code string `json:"code"`
+
+	// Hours of overtime requested
+	// example: 3
+	Requested int `json:"requested"`
+
+	OvertimeCapUsage
+}
+
+func (e *OvertimeCapError) Error() string {
+	return e.Code
+}
+
+// OvertimeUsage represents the overtime hours an employee used against the caps of the day, week and month of a date
+// swagger:model OvertimeUsage
+type OvertimeUsage struct {
+	// Day the usage is reported for
+	// example: "2024-01-17T00:00:00Z"
+	Date time.Time `json:"date"`
+
+	// Usage of the day
+	Daily OvertimeCapUsage `json:"daily"`
+
+	// Usage of the week holding the day
+	Weekly OvertimeCapUsage `json:"weekly"`
+
+	// Usage of the calendar month holding the day
+	Monthly OvertimeCapUsage `json:"monthly"`
+}
+
+// CreateOvertimeUsageProps represents the properties needed to create a new overtime usage
+// swagger:model CreateOvertimeUsageProps
+type CreateOvertimeUsageProps struct {
+	// Day to report the usage for
+	Date time.Time
+	// Caps of the day, week and month
+	Caps OvertimeCaps
+	// Pending and approved overtime of the employee, at least over the week and month of the day
+	Overtimes []entity.Overtime
+	// Pending and approved overtime plans of the employee, at least over the week and month of the day
+	Plans []entity.OvertimePlan
+}
+
+func NewOvertimeUsage(props *CreateOvertimeUsageProps) *OvertimeUsage {
+	day := dateOnly(props.Date)
+	weekStart := bucketStart(day, AnalyticsBucketWeek)
+	monthStart := bucketStart(day, AnalyticsBucketMonth)
+
+	usage := &OvertimeUsage{
+		Date:    day,
+		Daily:   newOvertimeCapUsage(OvertimeCapDaily, day, day, props.Caps.Daily),
+		Weekly:  newOvertimeCapUsage(OvertimeCapWeekly, weekStart, bucketEnd(weekStart, AnalyticsBucketWeek), props.Caps.Weekly),
+		Monthly: newOvertimeCapUsage(OvertimeCapMonthly, monthStart, bucketEnd(monthStart, AnalyticsBucketMonth), props.Caps.Monthly),
+	}
+
+	for _, o := range props.Overtimes {
+		usage.add(dateOnly(o.Date), o.PaidHours())
+	}
+	for _, p := range props.Plans {
+		usage.add(dateOnly(p.Date), p.PlannedHours)
+	}
+
+	for _, u := range usage.caps() {
+		if u.Limit != nil {
+			remaining := max(*u.Limit-u.Used, 0)
+			u.Remaining = &remaining
+		}
+	}
+
+	return usage
+}
+
+// Check returns an error for the first cap, from the day to the month, that hours more of overtime exceed,
+// nil when every cap allows them
+func (u *OvertimeUsage) Check(hours int) *OvertimeCapError {
+	for _, c := range u.caps() {
+		if !c.Allows(hours) {
+			return &OvertimeCapError{
+				Code:             fmt.Sprintf("overtime/%s-cap-exceeded", c.Cap),
+				Requested:        hours,
+				OvertimeCapUsage: *c,
+			}
+		}
+	}
+	return nil
+}
+
+func (u *OvertimeUsage) caps() []*OvertimeCapUsage {
+	return []*OvertimeCapUsage{&u.Daily, &u.Weekly, &u.Monthly}
+}
+
+func (u *OvertimeUsage) add(day time.Time, hours int) {
+	for _, c := range u.caps() {
+		if !day.Before(c.StartDate) && !day.After(c.EndDate) {
+			c.Used += hours
+		}
+	}
+}
+
+func newOvertimeCapUsage(span OvertimeCap, startDate, endDate time.Time, limit int) OvertimeCapUsage {
+	usage := OvertimeCapUsage{Cap: span, StartDate: startDate, EndDate: endDate}
+	if limit > 0 {
+		usage.Limit = &limit
+	}
+	return usage
+}
+
+// overtimeCapTracker counts the overtime hours derived from attendance in each day, week and month, so no more are
+// paid than the caps allow
+type overtimeCapTracker struct {
+	caps    OvertimeCaps
+	daily   map[time.Time]int
+	weekly  map[time.Time]int
+	monthly map[time.Time]int
+}
+
+func newOvertimeCapTracker(caps OvertimeCaps) *overtimeCapTracker {
+	return &overtimeCapTracker{
+		caps:    caps,
+		daily:   make(map[time.Time]int),
+		weekly:  make(map[time.Time]int),
+		monthly: make(map[time.Time]int),
+	}
+}
+
+// take counts hours of overtime on the day and returns the hours the caps allow, along with the cap that cut
+// them short, if any
+func (t *overtimeCapTracker) take(day time.Time, hours int) (int, *OvertimeCap) {
+	day = dateOnly(day)
+	week := bucketStart(day, AnalyticsBucketWeek)
+	month := bucketStart(day, AnalyticsBucketMonth)
+
+	allowed := hours
+	var capped *OvertimeCap
+	limit := func(span OvertimeCap, used, capHours int) {
+		if capHours > 0 && used+allowed > capHours {
+			allowed = max(capHours-used, 0)
+			capped = &span
+		}
+	}
+	limit(OvertimeCapDaily, t.daily[day], t.caps.Daily)
+	limit(OvertimeCapWeekly, t.weekly[week], t.caps.Weekly)
+	limit(OvertimeCapMonthly, t.monthly[month], t.caps.Monthly)
+
+	t.daily[day] += allowed
+	t.weekly[week] += allowed
+	t.monthly[month] += allowed
+	return allowed, capped
+}
+
+// OvertimeCapsStart returns the first day of the week or month holding the day, whichever comes first, the overtime
+// before the day counts against its caps from there
+func OvertimeCapsStart(day time.Time) time.Time {
+	day = dateOnly(day)
+	week := bucketStart(day, AnalyticsBucketWeek)
+	month := bucketStart(day, AnalyticsBucketMonth)
+	if month.Before(week) {
+		return month
+	}
+	return week
+}
@@ -1,8 +1,13 @@
 package vm
 
 import (
+	"fmt"
 	"payslip-generator-service/internal/entity"
 	ulid "payslip-generator-service/pkg/database/gorm"
+	"payslip-generator-service/pkg/timezone"
+	"sort"
+	"strings"
+	"time"
 )
 
 // reimbursementProps represents reimbursement summary data
@@ -31,7 +36,7 @@ type overtimeProps struct {
 	// example: 250000
 	TotalAmount int `json:"total_amount"`
 
-	// Total overtime hours worked
+	// Total overtime hours paid
 	// example: 10
 	TotalHours int `json:"total_hours"`
 
@@ -39,6 +44,108 @@ type overtimeProps struct {
 	Overtimes []entity.Overtime `json:"overtimes"`
 }
 
+// toilProps represents the overtime banked as time off in lieu, a memo not included in the take-home pay
+// swagger:model toilProps
+type toilProps struct {
+	// Total number of banked overtime records
+	// example: 1
+	TotalItem int `json:"total_item"`
+
+	// Total overtime hours banked
+	// example: 2
+	TotalHours int `json:"total_hours"`
+
+	// List of banked overtime records
+	Overtimes []entity.Overtime `json:"overtimes"`
+}
+
+// AttendanceDeduction represents the lateness, early leave and excess break of a paid attendance day
+// swagger:model AttendanceDeduction
+type AttendanceDeduction struct {
+	// Unique identifier of the attendance record
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	AttendanceID ulid.ULID `json:"attendance_id"`
+
+	// Calendar day of the shift in the employee's time zone
+	// example: "2024-01-15T00:00:00Z"
+	WorkDate time.Time `json:"work_date"`
+
+	// Hours between check-in and check-out without the unpaid breaks
+	// example: 7.5
+	WorkedHours float64 `json:"worked_hours"`
+
+	// Minutes between the scheduled shift start and the check-in
+	// example: 30
+	LateMinutes int `json:"late_minutes"`
+
+	// Minutes between the check-out and the scheduled shift end
+	// example: 0
+	EarlyLeaveMinutes int `json:"early_leave_minutes"`
+
+	// Minutes of late arrival deducted, lateness within the grace period is not deducted
+	// example: 30
+	LateDeductedMinutes int `json:"late_deducted_minutes"`
+
+	// Minutes of early leave deducted, early leave within the grace period is not deducted
+	// example: 0
+	EarlyLeaveDeductedMinutes int `json:"early_leave_deducted_minutes"`
+
+	// Minutes of unpaid breaks taken in the day
+	// example: 90
+	UnpaidBreakMinutes int `json:"unpaid_break_minutes"`
+
+	// Minutes of unpaid breaks deducted, breaks within the break allowance are not deducted
+	// example: 30
+	BreakDeductedMinutes int `json:"break_deducted_minutes"`
+
+	// Scheduled length of the shift in minutes
+	// example: 540
+	ShiftMinutes int `json:"shift_minutes"`
+
+	// Salary deducted for the day, the day is paid pro-rata to the minutes of the shift not deducted
+	// example: 8960
+	Amount int `json:"amount"`
+}
+
+// attendanceDeductionProps represents the lateness, early leave, excess break and absence summary of the period
+// swagger:model attendanceDeductionProps
+type attendanceDeductionProps struct {
+	// Number of paid days with a deducted late arrival
+	// example: 2
+	LateDays int `json:"late_days"`
+
+	// Total deducted minutes of late arrival
+	// example: 75
+	LateMinutes int `json:"late_minutes"`
+
+	// Number of paid days with a deducted early leave
+	// example: 1
+	EarlyLeaveDays int `json:"early_leave_days"`
+
+	// Total deducted minutes of early leave
+	// example: 60
+	EarlyLeaveMinutes int `json:"early_leave_minutes"`
+
+	// Number of paid days with unpaid breaks beyond the break allowance
+	// example: 1
+	ExcessBreakDays int `json:"excess_break_days"`
+
+	// Total deducted minutes of unpaid breaks beyond the break allowance
+	// example: 30
+	ExcessBreakMinutes int `json:"excess_break_minutes"`
+
+	// Scheduled work days before the cutoff day without an attendance, absent days are not paid
+	// example: 1
+	AbsentDays int `json:"absent_days"`
+
+	// Total salary deducted for late arrival, early leave and excess breaks
+	// example: 40322
+	TotalAmount int `json:"total_amount"`
+
+	// Paid days with a deduction
+	Days []AttendanceDeduction `json:"days"`
+}
+
 // Payslip represents a comprehensive payslip for an employee
 // swagger:model Payslip
 type Payslip struct {
@@ -52,6 +159,9 @@ type Payslip struct {
 	// Overtime summary and details
 	Overtime overtimeProps `json:"overtime"`
 
+	// Overtime banked as time off in lieu, shown as a memo and not paid
+	Toil toilProps `json:"toil"`
+
 	// Reimbursement summary and details
 	Reimbursement reimbursementProps `json:"reimbursement"`
 
@@ -59,7 +169,10 @@ type Payslip struct {
 	// example: 5000000
 	BasicSalary int `json:"basic_salary"`
 
-	// Calculated salary for the period based on attendance
+	// Late arrival, early leave, excess break and absence breakdown
+	Deduction attendanceDeductionProps `json:"deduction"`
+
+	// Calculated salary for the period based on attendance, after late arrival, early leave and excess break deductions
 	// example: 4500000
 	Salary int `json:"salary"`
 
@@ -83,48 +196,317 @@ type CreatePayslipProps struct {
 	PayrollPeriod entity.PayrollPeriod
 	// Employee's base salary
 	Salary int
+	// Work schedule of the employee, the default schedule when none is assigned; lateness, early leave and overtime
+	// are measured against its shift
+	WorkSchedule *entity.WorkSchedule
+	// Time zone of the employee, the shift hours are taken in it; nil keeps the zone of each attendance
+	Location *time.Location
+	// Rules for deducting late arrival and early leave
+	DeductionPolicy AttendanceDeductionPolicy
+	// Unpaid break time per day included in the shift, longer unpaid breaks are deducted; the rest of the shift
+	// is the net working time an employee must reach before overtime is paid
+	BreakAllowance time.Duration
+	// Rules for paying overtime
+	OvertimePolicy OvertimePolicy
+	// Overtime entitlement history of the employee, overtime on a day the employee is not entitled to is not paid
+	OvertimeEligibility entity.OvertimeEligibilities
+	// Attendance from the start of the week and month holding the first day of the period, before the period; the
+	// overtime derived from it counts against the caps of the weeks and months the period shares
+	PriorAttendance []entity.Attendance
 }
 
+// OvertimePolicy holds the rules for paying overtime, the hours worked beyond the shift are measured against
+// the work schedule
+type OvertimePolicy struct {
+	// Whether overtime is derived from the whole hours worked beyond the shift instead of the claims of the employee
+	FromAttendance bool
+	// Whether claims exceeding the hours worked beyond the shift are not paid, they are paid and flagged otherwise
+	RejectExcessClaims bool
+	// Whether claims are only paid on a paid attendance day whose net worked hours reach the net working time of
+	// the shift
+	RequireNetHours bool
+	// Daily, weekly and monthly caps of the overtime derived from attendance, claims are capped when they are submitted
+	Caps OvertimeCaps
+}
+
+// AttendanceDeductionPolicy holds the rules for deducting late arrival and early leave
+type AttendanceDeductionPolicy struct {
+	// Whether late arrival and early leave are deducted, every attendance day is paid in full otherwise
+	Enabled bool
+	// Lateness up to this duration is not deducted, longer lateness is deducted in full
+	LateGracePeriod time.Duration
+	// Early leave up to this duration is not deducted, longer early leave is deducted in full
+	EarlyLeaveGracePeriod time.Duration
+}
+
+const (
+	// hoursPerDay is the number of working hours in a day used to derive the hourly salary
+	hoursPerDay = 8
+	// overtimeRateMultiplier is the multiplier of the hourly salary paid for overtime
+	overtimeRateMultiplier = 2
+)
+
 func NewPayslip(props *CreatePayslipProps) *Payslip {
+	payslip, _ := NewPayslipWithTrace(props)
+	return payslip
+}
+
+// NewPayslipWithTrace creates a payslip along with the trace of how each figure was derived
+func NewPayslipWithTrace(props *CreatePayslipProps) (*Payslip, *PayslipTrace) {
 	maxSubmittedAt := props.PayrollPeriod.ProcessedAt
+	totalDaysInPeriod := props.PayrollPeriod.GetDurationInDays()
+
+	trace := &PayslipTrace{
+		EmployeeID:      props.EmployeeID,
+		PeriodStartDate: props.PayrollPeriod.StartDate,
+		PeriodEndDate:   props.PayrollPeriod.EndDate,
+		CutoffAt:        *maxSubmittedAt,
+		DaysInPeriod:    totalDaysInPeriod,
+		BasicSalary:     props.Salary,
+		HoursPerDay:     hoursPerDay,
+		OvertimeRate:    overtimeRateMultiplier,
+		Attendances:     make([]AttendanceTrace, 0),
+		Overtimes:       make([]OvertimeTrace, 0),
+		Reimbursements:  make([]ReimbursementTrace, 0),
+	}
 
-	// filter attendance (created_at <= maxSubmitedAt)
+	// filter attendance (created_at <= maxSubmitedAt), a session not checked out by then is not paid
 	attendances := make([]entity.Attendance, 0)
 	for _, a := range props.Attendance {
-		if a.CreatedAt.Before(*maxSubmittedAt) {
+		switch {
+		case !a.CreatedAt.Before(*maxSubmittedAt):
+			trace.Attendances = append(trace.Attendances, newExcludedAttendanceTrace(a, *maxSubmittedAt))
+		case a.IsOpen() || a.EndTime.After(*maxSubmittedAt):
+			trace.Attendances = append(trace.Attendances, newOpenAttendanceTrace(a, *maxSubmittedAt))
+		default:
 			attendances = append(attendances, a)
+			trace.Attendances = append(trace.Attendances, newAttendanceTrace(a))
 		}
 	}
 
-	totalDaysInPeriod := props.PayrollPeriod.GetDurationInDays()
 	totalAttendance := min(len(attendances), totalDaysInPeriod) // get the minimum between the total attendance and the total days in period
 	salaryPerDay := props.Salary / totalDaysInPeriod
-	salaryPerHour := salaryPerDay / 8 // 8 hours per day
-	salaryInPeriod := salaryPerDay * totalAttendance
+	salaryPerHour := salaryPerDay / hoursPerDay
+	salaryForAttendance := salaryPerDay * totalAttendance
+
+	// attendance beyond the number of days in the period is not paid
+	counted := 0
+	for i := range trace.Attendances {
+		if !trace.Attendances[i].Counted {
+			continue
+		}
+		if counted++; counted > totalDaysInPeriod {
+			trace.Attendances[i].Counted = false
+			trace.Attendances[i].Reason = ExclusionReasonExceedsDaysInPeriod
+			trace.Attendances[i].Note = fmt.Sprintf("only %d attendance days can be paid in the period", totalDaysInPeriod)
+		}
+	}
+
+	// deduct late arrival, early leave and excess breaks of the paid days
+	deduction := attendanceDeductionProps{Days: make([]AttendanceDeduction, 0)}
+	for i := range trace.Attendances {
+		if !trace.Attendances[i].Counted {
+			continue
+		}
+
+		d, ok := newAttendanceDeduction(trace.Attendances[i].Attendance, props, salaryPerDay)
+		if !ok {
+			continue
+		}
+		if d.LateDeductedMinutes > 0 {
+			deduction.LateDays++
+			deduction.LateMinutes += d.LateDeductedMinutes
+		}
+		if d.EarlyLeaveDeductedMinutes > 0 {
+			deduction.EarlyLeaveDays++
+			deduction.EarlyLeaveMinutes += d.EarlyLeaveDeductedMinutes
+		}
+		if d.BreakDeductedMinutes > 0 {
+			deduction.ExcessBreakDays++
+			deduction.ExcessBreakMinutes += d.BreakDeductedMinutes
+		}
+		deduction.TotalAmount += d.Amount
+		deduction.Days = append(deduction.Days, d)
+		trace.Attendances[i].Deduction = &d
+	}
+	deduction.AbsentDays = countAbsentDays(props, trace.Attendances)
+	salaryInPeriod := salaryForAttendance - deduction.TotalAmount
+
+	// net hours worked on each paid day, overtime is only paid once the net working time of the shift is reached,
+	// and the whole hours worked beyond it
+	netHours := make(map[string]float64, len(trace.Attendances))
+	observedHours := make(map[string]int, len(trace.Attendances))
+	for _, a := range trace.Attendances {
+		if a.Counted {
+			date := a.Attendance.WorkDate.Format(time.DateOnly)
+			netHours[date] = a.Attendance.GetNetDurationInHours()
+			observedHours[date] = int(a.Attendance.OvertimeBy(props.WorkSchedule, props.BreakAllowance).Hours())
+		}
+	}
+	minNetHours := max(props.WorkSchedule.ShiftDuration()-props.BreakAllowance, 0).Hours()
 
-	// filter overtime (created_at <= maxSubmitedAt)
 	overtimes := make([]entity.Overtime, 0)
 	totalAmountOvertime := 0
 	totalHoursOvertime := 0
-	for _, o := range props.Overtime {
-		if o.CreatedAt.Before(*maxSubmittedAt) {
-			overtimes = append(overtimes, o)
-			totalAmountOvertime += o.TotalHours * (salaryPerHour * 2) // 2x salary per hour
-			totalHoursOvertime += o.TotalHours
+	toil := toilProps{Overtimes: make([]entity.Overtime, 0)}
+	pay := func(o entity.Overtime, t OvertimeTrace) {
+		t.Overtime = o
+		if o.IsBanked() {
+			// banked overtime is taken as time off, it is listed on the payslip without being paid
+			t.Reason = ExclusionReasonBankedAsToil
+			t.BankedHours = o.PaidHours()
+			note := fmt.Sprintf("%d hours banked as time off in lieu", t.BankedHours)
+			if t.Note != "" {
+				note = t.Note + "; " + note
+			}
+			t.Note = note
+			toil.Overtimes = append(toil.Overtimes, o)
+			toil.TotalItem++
+			toil.TotalHours += t.BankedHours
+			trace.Overtimes = append(trace.Overtimes, t)
+			return
 		}
+
+		t.Included = true
+		t.PaidHours = o.PaidHours()
+		t.Amount = o.PaidHours() * (salaryPerHour * overtimeRateMultiplier) // 2x salary per hour
+		overtimes = append(overtimes, o)
+		totalAmountOvertime += t.Amount
+		totalHoursOvertime += t.PaidHours
+		trace.Overtimes = append(trace.Overtimes, t)
 	}
 
-	// filter reimbursement (created_at <= maxSubmitedAt)
+	fromAttendance := props.OvertimePolicy.FromAttendance
+	if fromAttendance {
+		// overtime is derived from the paid days, the claims of the employee are not paid
+		for _, o := range props.Overtime {
+			trace.Overtimes = append(trace.Overtimes, OvertimeTrace{
+				Overtime: o,
+				Reason:   ExclusionReasonDerivedFromAttendance,
+				Note:     "overtime is derived from the hours worked beyond the shift",
+			})
+		}
+		// derived overtime is paid within the daily, weekly and monthly caps, day by day
+		caps := newOvertimeCapTracker(props.OvertimePolicy.Caps)
+		for _, a := range props.PriorAttendance {
+			if !a.IsOpen() && props.OvertimeEligibility.IsEligibleOn(a.WorkDate) {
+				observed := entity.NewObservedOvertime(&a, int(a.OvertimeBy(props.WorkSchedule, props.BreakAllowance).Hours()))
+				caps.take(observed.Date, observed.TotalHours)
+			}
+		}
+
+		days := make([]AttendanceTrace, len(trace.Attendances))
+		copy(days, trace.Attendances)
+		sort.SliceStable(days, func(i, j int) bool {
+			return days[i].Attendance.WorkDate.Before(days[j].Attendance.WorkDate)
+		})
+		for _, a := range days {
+			date := a.Attendance.WorkDate.Format(time.DateOnly)
+			hours := observedHours[date]
+			if !a.Counted || hours == 0 {
+				continue
+			}
+			if !props.OvertimeEligibility.IsEligibleOn(a.Attendance.WorkDate) {
+				trace.Overtimes = append(trace.Overtimes, OvertimeTrace{
+					Overtime:      entity.NewObservedOvertime(&a.Attendance, hours),
+					ObservedHours: &hours,
+					Reason:        ExclusionReasonNotEligible,
+					Note:          notEligibleNote(date),
+				})
+				continue
+			}
+
+			observed := entity.NewObservedOvertime(&a.Attendance, hours)
+			note := fmt.Sprintf("worked %.2f net hours on %s, beyond the %.2f net hours of the shift", netHours[date], date, minNetHours)
+			paid, capped := caps.take(observed.Date, observed.TotalHours)
+			if capped != nil {
+				note = fmt.Sprintf("%s; %d of %d hours within the %s cap", note, paid, observed.TotalHours, *capped)
+			}
+			if paid == 0 {
+				trace.Overtimes = append(trace.Overtimes, OvertimeTrace{
+					Overtime:      observed,
+					ObservedHours: &hours,
+					Reason:        ExclusionReasonExceedsCap,
+					Note:          note,
+				})
+				continue
+			}
+
+			observed.TotalHours = paid
+			pay(observed, OvertimeTrace{ObservedHours: &hours, Note: note})
+		}
+	} else {
+		// filter overtime (created_at <= maxSubmitedAt)
+		for _, o := range props.Overtime {
+			date := o.Date.Format(time.DateOnly)
+			worked, attended := netHours[date]
+			observed := observedHours[date]
+			exceeds := o.TotalHours > observed
+			switch {
+			case !o.CreatedAt.Before(*maxSubmittedAt):
+				trace.Overtimes = append(trace.Overtimes, OvertimeTrace{
+					Overtime: o,
+					Reason:   ExclusionReasonSubmittedAfterCutoff,
+					Note:     submittedAfterCutoffNote(o.CreatedAt, *maxSubmittedAt),
+				})
+			case !props.OvertimeEligibility.IsEligibleOn(o.Date):
+				trace.Overtimes = append(trace.Overtimes, OvertimeTrace{
+					Overtime: o,
+					Reason:   ExclusionReasonNotEligible,
+					Note:     notEligibleNote(date),
+				})
+			case props.OvertimePolicy.RequireNetHours && !attended:
+				trace.Overtimes = append(trace.Overtimes, OvertimeTrace{
+					Overtime: o,
+					Reason:   ExclusionReasonNoAttendance,
+					Note:     fmt.Sprintf("no paid attendance on %s", date),
+				})
+			case props.OvertimePolicy.RequireNetHours && worked < minNetHours:
+				trace.Overtimes = append(trace.Overtimes, OvertimeTrace{
+					Overtime: o,
+					Reason:   ExclusionReasonInsufficientNetHours,
+					Note:     fmt.Sprintf("worked %.2f net hours on %s, overtime is paid from %.2f net hours", worked, date, minNetHours),
+				})
+			case exceeds && props.OvertimePolicy.RejectExcessClaims:
+				trace.Overtimes = append(trace.Overtimes, OvertimeTrace{
+					Overtime:      o,
+					ObservedHours: &observed,
+					Reason:        ExclusionReasonExceedsWorkedTime,
+					Note:          excessClaimNote(o, observed, date),
+				})
+			case exceeds:
+				pay(o, OvertimeTrace{ObservedHours: &observed, Flagged: true, Note: excessClaimNote(o, observed, date)})
+			default:
+				pay(o, OvertimeTrace{ObservedHours: &observed})
+			}
+		}
+	}
+
+	// filter reimbursement (approved_at <= maxSubmitedAt), paying the approved amount
 	reimbursements := make([]entity.Reimbursement, 0)
 	totalAmountReimbursement := 0
 	for _, r := range props.Reimbursement {
-		if r.CreatedAt.Before(*maxSubmittedAt) {
+		switch {
+		case r.ApprovedAt == nil:
+			trace.Reimbursements = append(trace.Reimbursements, ReimbursementTrace{
+				Reimbursement: r,
+				Reason:        ExclusionReasonNotApproved,
+				Note:          fmt.Sprintf("%s at the %s stage", r.Status, r.Stage),
+			})
+		case !r.ApprovedAt.After(*maxSubmittedAt):
 			reimbursements = append(reimbursements, r)
-			totalAmountReimbursement += r.Amount
+			totalAmountReimbursement += r.PaidAmount()
+			trace.Reimbursements = append(trace.Reimbursements, ReimbursementTrace{Reimbursement: r, Included: true, Amount: r.PaidAmount()})
+		default:
+			trace.Reimbursements = append(trace.Reimbursements, ReimbursementTrace{
+				Reimbursement: r,
+				Reason:        ExclusionReasonApprovedAfterCutoff,
+				Note:          approvedAfterCutoffNote(*r.ApprovedAt, *maxSubmittedAt),
+			})
 		}
 	}
 
-	return &Payslip{
+	payslip := &Payslip{
 		EmployeeID:  props.EmployeeID,
 		Attendances: attendances,
 		Overtime: overtimeProps{
@@ -133,13 +515,137 @@ func NewPayslip(props *CreatePayslipProps) *Payslip {
 			TotalHours:  totalHoursOvertime,
 			Overtimes:   overtimes,
 		},
+		Toil: toil,
 		Reimbursement: reimbursementProps{
 			TotalItem:      len(reimbursements),
 			TotalAmount:    totalAmountReimbursement,
 			Reimbursements: reimbursements,
 		},
 		BasicSalary: props.Salary,
+		Deduction:   deduction,
 		Salary:      salaryInPeriod,
-		TakeHomePay: salaryInPeriod - totalAmountReimbursement + totalAmountOvertime,
+		TakeHomePay: salaryInPeriod + totalAmountReimbursement + totalAmountOvertime,
+	}
+
+	trace.AttendanceDaysSubmitted = len(props.Attendance)
+	trace.AttendanceDaysCounted = totalAttendance
+	trace.SalaryPerDay = salaryPerDay
+	trace.SalaryPerHour = salaryPerHour
+	trace.OvertimeRatePerHour = salaryPerHour * overtimeRateMultiplier
+	trace.LateGracePeriodMinutes = int(props.DeductionPolicy.LateGracePeriod.Minutes())
+	trace.EarlyLeaveGracePeriodMinutes = int(props.DeductionPolicy.EarlyLeaveGracePeriod.Minutes())
+	trace.BreakAllowanceMinutes = int(props.BreakAllowance.Minutes())
+	trace.OvertimeMinNetHours = minNetHours
+	trace.OvertimeFromAttendance = fromAttendance
+	trace.DeductionAmount = deduction.TotalAmount
+	trace.Salary = payslip.Salary
+	trace.OvertimeAmount = totalAmountOvertime
+	trace.ReimbursementAmount = totalAmountReimbursement
+	trace.TakeHomePay = payslip.TakeHomePay
+	trace.Steps = []TraceStep{
+		{Name: "salary_per_day", Formula: "basic_salary / days_in_period", Expression: fmt.Sprintf("%d / %d", props.Salary, totalDaysInPeriod), Result: salaryPerDay},
+		{Name: "salary_per_hour", Formula: "salary_per_day / hours_per_day", Expression: fmt.Sprintf("%d / %d", salaryPerDay, hoursPerDay), Result: salaryPerHour},
+		{Name: "salary_for_attendance", Formula: "salary_per_day * min(attendance_days, days_in_period)", Expression: fmt.Sprintf("%d * %d", salaryPerDay, totalAttendance), Result: salaryForAttendance},
+		{Name: "deduction_amount", Formula: "sum(salary_per_day * (late_deducted_minutes + early_leave_deducted_minutes + break_deducted_minutes) / shift_minutes)", Expression: deductionExpression(deduction.Days, salaryPerDay), Result: deduction.TotalAmount},
+		{Name: "salary", Formula: "salary_for_attendance - deduction_amount", Expression: fmt.Sprintf("%d - %d", salaryForAttendance, deduction.TotalAmount), Result: salaryInPeriod},
+		{Name: "overtime_amount", Formula: "overtime_hours * salary_per_hour * overtime_rate", Expression: fmt.Sprintf("%d * %d * %d", totalHoursOvertime, salaryPerHour, overtimeRateMultiplier), Result: totalAmountOvertime},
+		{Name: "take_home_pay", Formula: "salary + reimbursement_amount + overtime_amount", Expression: fmt.Sprintf("%d + %d + %d", salaryInPeriod, totalAmountReimbursement, totalAmountOvertime), Result: payslip.TakeHomePay},
+	}
+
+	return payslip, trace
+}
+
+// excessClaimNote explains an overtime claiming more hours than were worked beyond the shift
+func excessClaimNote(o entity.Overtime, observed int, date string) string {
+	return fmt.Sprintf("claimed %d hours, worked %d whole hours beyond the shift on %s", o.TotalHours, observed, date)
+}
+
+// notEligibleNote explains an overtime on a day the employee is not entitled to overtime
+func notEligibleNote(date string) string {
+	return fmt.Sprintf("not entitled to overtime on %s", date)
+}
+
+// newAttendanceDeduction measures the lateness and early leave of an attendance against the shift of the schedule
+// and its unpaid breaks against the break allowance, ok is false when nothing is deducted from the day
+func newAttendanceDeduction(a entity.Attendance, props *CreatePayslipProps, salaryPerDay int) (d AttendanceDeduction, ok bool) {
+	if !props.DeductionPolicy.Enabled {
+		return d, false
+	}
+	if props.Location != nil {
+		a.In(props.Location)
+	}
+
+	late := a.LateBy(props.WorkSchedule)
+	earlyLeave := a.LeftEarlyBy(props.WorkSchedule)
+	unpaidBreak := a.GetUnpaidBreakDuration()
+	shift := props.WorkSchedule.ShiftDuration()
+
+	d = AttendanceDeduction{
+		AttendanceID:       a.ID,
+		WorkDate:           a.WorkDate,
+		WorkedHours:        a.GetNetDurationInHours(),
+		LateMinutes:        int(late.Minutes()),
+		EarlyLeaveMinutes:  int(earlyLeave.Minutes()),
+		UnpaidBreakMinutes: int(unpaidBreak.Minutes()),
+		ShiftMinutes:       int(shift.Minutes()),
+	}
+	if late > props.DeductionPolicy.LateGracePeriod {
+		d.LateDeductedMinutes = d.LateMinutes
+	}
+	if earlyLeave > props.DeductionPolicy.EarlyLeaveGracePeriod {
+		d.EarlyLeaveDeductedMinutes = d.EarlyLeaveMinutes
+	}
+	if unpaidBreak > props.BreakAllowance {
+		d.BreakDeductedMinutes = int((unpaidBreak - props.BreakAllowance).Minutes())
+	}
+
+	// the day is never deducted below zero
+	deducted := min(d.LateDeductedMinutes+d.EarlyLeaveDeductedMinutes+d.BreakDeductedMinutes, d.ShiftMinutes)
+	if deducted == 0 {
+		return d, false
+	}
+	d.Amount = salaryPerDay * deducted / d.ShiftMinutes
+
+	return d, true
+}
+
+// countAbsentDays counts the work days of the schedule in the period, before the cutoff day, without a paid attendance
+func countAbsentDays(props *CreatePayslipProps, attendances []AttendanceTrace) int {
+	location := props.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	attended := make(map[string]bool, len(attendances))
+	for _, a := range attendances {
+		if a.Counted {
+			attended[a.Attendance.WorkDate.Format(time.DateOnly)] = true
+		}
+	}
+
+	period := props.PayrollPeriod
+	cutoffDay := timezone.DateOf(*period.ProcessedAt, location)
+	start := time.Date(period.StartDate.Year(), period.StartDate.Month(), period.StartDate.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(period.EndDate.Year(), period.EndDate.Month(), period.EndDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	absent := 0
+	for day := start; !day.After(end) && day.Before(cutoffDay); day = day.AddDate(0, 0, 1) {
+		if props.WorkSchedule.IsWorkDay(day) && !attended[day.Format(time.DateOnly)] {
+			absent++
+		}
+	}
+
+	return absent
+}
+
+func deductionExpression(days []AttendanceDeduction, salaryPerDay int) string {
+	if len(days) == 0 {
+		return "0"
+	}
+
+	terms := make([]string, len(days))
+	for i, d := range days {
+		terms[i] = fmt.Sprintf("%d * (%d + %d + %d) / %d", salaryPerDay, d.LateDeductedMinutes, d.EarlyLeaveDeductedMinutes, d.BreakDeductedMinutes, d.ShiftMinutes)
 	}
+	return strings.Join(terms, " + ")
 }
@@ -0,0 +1,119 @@
+package vm
+
+import (
+	"payslip-generator-service/internal/entity"
+	ulid "payslip-generator-service/pkg/database/gorm"
+	"time"
+)
+
+// PayslipEstimate represents a provisional payslip for a payroll period that has not been processed yet
+// swagger:model PayslipEstimate
+type PayslipEstimate struct {
+	// Always true, the figures are provisional and may change until the period is processed
+	// example: true
+	IsEstimate bool `json:"is_estimate"`
+
+	// Explanation of how the estimate was computed
+	// example: "Provisional figures computed from records submitted so far. The final payslip is issued once the period is processed."
+	Notice string `json:"notice"`
+
+	// Time the estimate was computed at
+	// example: "2024-01-15T10:00:00Z"
+	AsOf time.Time `json:"as_of"`
+
+	// Unique identifier of the payroll period
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	PeriodID ulid.ULID `json:"period_id"`
+
+	// Start date of the payroll period
+	// example: "2024-01-01T00:00:00Z"
+	PeriodStartDate time.Time `json:"period_start_date"`
+
+	// End date of the payroll period
+	// example: "2024-01-31T00:00:00Z"
+	PeriodEndDate time.Time `json:"period_end_date"`
+
+	// Earnings to date, computed from the records submitted so far
+	EarningsToDate *Payslip `json:"earnings_to_date"`
+
+	// Remaining work days of the employee's schedule in the period that have no attendance yet
+	// example: 12
+	RemainingWorkingDays int `json:"remaining_working_days"`
+
+	// Attendance days assuming full attendance for the remaining working days
+	// example: 22
+	ProjectedAttendanceDays int `json:"projected_attendance_days"`
+
+	// Projected salary at the end of the period, keeping the deductions made so far
+	// example: 3548387
+	ProjectedSalary int `json:"projected_salary"`
+
+	// Projected take-home pay at the end of the period
+	// example: 3698387
+	ProjectedTakeHomePay int `json:"projected_take_home_pay"`
+}
+
+// CreatePayslipEstimateProps represents the properties needed to create a new payslip estimate
+// swagger:model CreatePayslipEstimateProps
+type CreatePayslipEstimateProps struct {
+	// Properties of the payslip, the payroll period must not be processed; its work schedule is used to count the remaining work days
+	Payslip CreatePayslipProps
+	// Time the estimate is computed at
+	Now time.Time
+}
+
+const payslipEstimateNotice = "Provisional figures computed from records submitted so far. The final payslip is issued once the period is processed."
+
+func NewPayslipEstimate(props *CreatePayslipEstimateProps) *PayslipEstimate {
+	// use the current time as the submission cutoff of the provisional payslip
+	period := props.Payslip.PayrollPeriod
+	period.ProcessedAt = &props.Now
+
+	payslipProps := props.Payslip
+	payslipProps.PayrollPeriod = period
+	payslip, trace := NewPayslipWithTrace(&payslipProps)
+
+	remainingWorkingDays := countRemainingWorkingDays(period, props.Payslip.WorkSchedule, props.Payslip.Attendance, props.Now)
+	projectedAttendanceDays := min(trace.AttendanceDaysCounted+remainingWorkingDays, trace.DaysInPeriod)
+	projectedSalary := trace.SalaryPerDay*projectedAttendanceDays - trace.DeductionAmount
+
+	return &PayslipEstimate{
+		IsEstimate:              true,
+		Notice:                  payslipEstimateNotice,
+		AsOf:                    props.Now,
+		PeriodID:                period.ID,
+		PeriodStartDate:         period.StartDate,
+		PeriodEndDate:           period.EndDate,
+		EarningsToDate:          payslip,
+		RemainingWorkingDays:    remainingWorkingDays,
+		ProjectedAttendanceDays: projectedAttendanceDays,
+		ProjectedSalary:         projectedSalary,
+		ProjectedTakeHomePay:    projectedSalary + payslip.Reimbursement.TotalAmount + payslip.Overtime.TotalAmount,
+	}
+}
+
+// countRemainingWorkingDays counts the work days of the schedule from today until the end of the period,
+// skipping today when the attendance for today has already been checked out, an open session is not paid yet
+func countRemainingWorkingDays(period entity.PayrollPeriod, schedule *entity.WorkSchedule, attendances []entity.Attendance, now time.Time) int {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for _, a := range attendances {
+		if a.IsOnDate(now) && !a.IsOpen() {
+			day = day.AddDate(0, 0, 1)
+			break
+		}
+	}
+
+	if start := time.Date(period.StartDate.Year(), period.StartDate.Month(), period.StartDate.Day(), 0, 0, 0, 0, now.Location()); day.Before(start) {
+		day = start
+	}
+	end := time.Date(period.EndDate.Year(), period.EndDate.Month(), period.EndDate.Day(), 0, 0, 0, 0, now.Location())
+
+	remaining := 0
+	for ; !day.After(end); day = day.AddDate(0, 0, 1) {
+		if schedule.IsWorkDay(day) {
+			remaining++
+		}
+	}
+
+	return remaining
+}
@@ -0,0 +1,107 @@
+package vm
+
+import (
+	"fmt"
+	"payslip-generator-service/internal/entity"
+	"payslip-generator-service/pkg/pdf"
+	"strconv"
+	"time"
+)
+
+// CreatePayslipPDFProps represents the properties needed to render a payslip as PDF
+// swagger:model CreatePayslipPDFProps
+type CreatePayslipPDFProps struct {
+	// Employee the payslip belongs to
+	Employee entity.Employee
+	// Processed payroll period
+	PayrollPeriod entity.PayrollPeriod
+	// Payslip to render
+	Payslip Payslip
+}
+
+// NewPayslipPDF renders a payslip as a PDF document
+func NewPayslipPDF(props *CreatePayslipPDFProps) []byte {
+	payslip := props.Payslip
+	period := props.PayrollPeriod
+
+	doc := pdf.NewDocument()
+	doc.Heading("PAYSLIP", 18)
+	doc.Row("Employee", props.Employee.Username, false)
+	doc.Row("Employee ID", props.Employee.ID.String(), false)
+	doc.Row("Period", fmt.Sprintf("%s - %s", period.StartDate.Format(time.DateOnly), period.EndDate.Format(time.DateOnly)), false)
+	if period.ProcessedAt != nil {
+		doc.Row("Pay date", period.ProcessedAt.Format(time.DateOnly), false)
+	}
+	doc.Space(8)
+
+	doc.Heading("Earnings", 12)
+	doc.Separator()
+	doc.Row("Basic salary", formatAmount(payslip.BasicSalary), false)
+	doc.Row(fmt.Sprintf("Salary (%d attendance days)", len(payslip.Attendances)), formatAmount(payslip.Salary), false)
+	doc.Row(fmt.Sprintf("Overtime (%d hours)", payslip.Overtime.TotalHours), formatAmount(payslip.Overtime.TotalAmount), false)
+	doc.Row(fmt.Sprintf("Reimbursement (%d items)", payslip.Reimbursement.TotalItem), formatAmount(payslip.Reimbursement.TotalAmount), false)
+	doc.Space(8)
+
+	if deduction := payslip.Deduction; deduction.TotalAmount > 0 || deduction.AbsentDays > 0 {
+		doc.Heading("Attendance deductions", 12)
+		doc.Separator()
+		doc.Row(fmt.Sprintf("Late arrival (%d days)", deduction.LateDays), fmt.Sprintf("%d minutes", deduction.LateMinutes), false)
+		doc.Row(fmt.Sprintf("Early leave (%d days)", deduction.EarlyLeaveDays), fmt.Sprintf("%d minutes", deduction.EarlyLeaveMinutes), false)
+		doc.Row(fmt.Sprintf("Excess breaks (%d days)", deduction.ExcessBreakDays), fmt.Sprintf("%d minutes", deduction.ExcessBreakMinutes), false)
+		for _, d := range deduction.Days {
+			doc.Row(d.WorkDate.Format(time.DateOnly), formatAmount(-d.Amount), false)
+		}
+		doc.Row("Absent work days (unpaid)", strconv.Itoa(deduction.AbsentDays), false)
+		doc.Row("Total deducted from salary", formatAmount(-deduction.TotalAmount), false)
+		doc.Space(8)
+	}
+
+	if len(payslip.Overtime.Overtimes) > 0 {
+		doc.Heading("Overtime", 12)
+		doc.Separator()
+		for _, o := range payslip.Overtime.Overtimes {
+			doc.Row(o.Date.Format(time.DateOnly), fmt.Sprintf("%d hours", o.PaidHours()), false)
+		}
+		doc.Space(8)
+	}
+
+	if len(payslip.Toil.Overtimes) > 0 {
+		doc.Heading("Time off in lieu (memo, not paid)", 12)
+		doc.Separator()
+		for _, o := range payslip.Toil.Overtimes {
+			doc.Row(o.Date.Format(time.DateOnly), fmt.Sprintf("%d hours banked", o.PaidHours()), false)
+		}
+		doc.Row("Total banked", fmt.Sprintf("%d hours", payslip.Toil.TotalHours), false)
+		doc.Space(8)
+	}
+
+	if len(payslip.Reimbursement.Reimbursements) > 0 {
+		doc.Heading("Reimbursements", 12)
+		doc.Separator()
+		for _, r := range payslip.Reimbursement.Reimbursements {
+			doc.Row(r.Description, formatAmount(r.PaidAmount()), false)
+		}
+		doc.Space(8)
+	}
+
+	doc.Separator()
+	doc.Row("Take-home pay", formatAmount(payslip.TakeHomePay), true)
+
+	return doc.Bytes()
+}
+
+// formatAmount formats an amount in Rupiah with dot thousand separators
+func formatAmount(amount int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.Itoa(amount)
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "." + digits[i:]
+	}
+
+	return fmt.Sprintf("%sRp %s", sign, digits)
+}
@@ -3,6 +3,7 @@ package vm
 import (
 	"payslip-generator-service/internal/entity"
 	ulid "payslip-generator-service/pkg/database/gorm"
+	"sort"
 )
 
 // PayslipReportEmployee represents employee data in a payslip report
@@ -16,6 +17,10 @@ type PayslipReportEmployee struct {
 	// example: "john.doe"
 	EmployeeUsername string `json:"username"`
 
+	// Department of the employee, empty when unassigned
+	// example: "engineering"
+	Department string `json:"department"`
+
 	// Employee's base salary amount
 	// example: 5000000
 	BasicSalary int `json:"basic_salary"`
@@ -51,30 +56,46 @@ type PayslipReport struct {
 // CreatePayslipReportProps represents the properties needed to create a new payslip report
 // swagger:model CreatePayslipReportProps
 type CreatePayslipReportProps struct {
-	// List of all employees
+	// Employees to report on
 	Employees []entity.Employee
-	// List of all payslips
-	Payslips []Payslip
+	// Payslip totals of the period, an employee without one was not on the payroll and is left out
+	Totals []entity.PayslipTotal
+	// Minimum take-home pay (inclusive) of the employees to include
+	MinTakeHomePay *int
+	// Maximum take-home pay (inclusive) of the employees to include
+	MaxTakeHomePay *int
+	// Column to sort by: username, basic_salary, salary or take_home_pay
+	SortBy string
+	// Whether to sort in descending order
+	SortDesc bool
 }
 
 func NewPayslipReport(props *CreatePayslipReportProps) *PayslipReport {
+	payslips := make(map[ulid.ULID]entity.PayslipTotal, len(props.Totals))
+	for _, t := range props.Totals {
+		payslips[t.EmployeeID] = t
+	}
 
 	employees := make([]PayslipReportEmployee, 0)
 	totalBasicSalary := 0
 	totalSalary := 0
 	totalTakeHomePay := 0
 	for _, employee := range props.Employees {
-		var payslip Payslip
-		for _, p := range props.Payslips {
-			if p.EmployeeID == employee.ID {
-				payslip = p
-				break
-			}
+		payslip, ok := payslips[employee.ID]
+		if !ok {
+			continue
+		}
+
+		if props.MinTakeHomePay != nil && payslip.TakeHomePay < *props.MinTakeHomePay {
+			continue
+		} else if props.MaxTakeHomePay != nil && payslip.TakeHomePay > *props.MaxTakeHomePay {
+			continue
 		}
 
 		employees = append(employees, PayslipReportEmployee{
 			EmployeeID:       employee.ID,
 			EmployeeUsername: employee.Username,
+			Department:       employee.GetDepartment(),
 			BasicSalary:      payslip.BasicSalary,
 			Salary:           payslip.Salary,
 			TakeHomePay:      payslip.TakeHomePay,
@@ -85,6 +106,8 @@ func NewPayslipReport(props *CreatePayslipReportProps) *PayslipReport {
 		totalTakeHomePay += payslip.TakeHomePay
 	}
 
+	sortPayslipReportEmployees(employees, props.SortBy, props.SortDesc)
+
 	return &PayslipReport{
 		Employees:        employees,
 		TotalBasicSalary: totalBasicSalary,
@@ -92,3 +115,36 @@ func NewPayslipReport(props *CreatePayslipReportProps) *PayslipReport {
 		TotalTakeHomePay: totalTakeHomePay,
 	}
 }
+
+// Paginate returns a copy of the report holding only the requested page of employees;
+// grand totals are kept over the full set
+func (r *PayslipReport) Paginate(page, pageSize int) *PayslipReport {
+	start := min((page-1)*pageSize, len(r.Employees))
+	end := min(start+pageSize, len(r.Employees))
+
+	report := *r
+	report.Employees = r.Employees[start:end]
+	return &report
+}
+
+func sortPayslipReportEmployees(employees []PayslipReportEmployee, sortBy string, desc bool) {
+	less := func(a, b PayslipReportEmployee) bool {
+		switch sortBy {
+		case "basic_salary":
+			return a.BasicSalary < b.BasicSalary
+		case "salary":
+			return a.Salary < b.Salary
+		case "take_home_pay":
+			return a.TakeHomePay < b.TakeHomePay
+		default:
+			return a.EmployeeUsername < b.EmployeeUsername
+		}
+	}
+
+	sort.SliceStable(employees, func(i, j int) bool {
+		if desc {
+			return less(employees[j], employees[i])
+		}
+		return less(employees[i], employees[j])
+	})
+}
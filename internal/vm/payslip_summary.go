@@ -0,0 +1,77 @@
+package vm
+
+import (
+	"fmt"
+	"payslip-generator-service/internal/entity"
+	ulid "payslip-generator-service/pkg/database/gorm"
+	"time"
+)
+
+// payslipLinks holds the links to the full versions of a payslip
+// swagger:model payslipLinks
+type payslipLinks struct {
+	// Link to the full payslip as JSON
+	// example: "/v1/payroll/payslip?period_id=01HXYZ123456789ABCDEFGHIJK"
+	JSON string `json:"json"`
+
+	// Link to the payslip as PDF
+	// example: "/v1/payroll/payslip/pdf?period_id=01HXYZ123456789ABCDEFGHIJK"
+	PDF string `json:"pdf"`
+}
+
+// PayslipSummary represents an entry of the employee payslip history
+// swagger:model PayslipSummary
+type PayslipSummary struct {
+	// Unique identifier of the payroll period
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	PeriodID ulid.ULID `json:"period_id"`
+
+	// Start date of the payroll period
+	// example: "2024-01-01T00:00:00Z"
+	StartDate time.Time `json:"start_date"`
+
+	// End date of the payroll period
+	// example: "2024-01-31T00:00:00Z"
+	EndDate time.Time `json:"end_date"`
+
+	// Date the payroll was processed and paid
+	// example: "2024-01-31T23:59:59Z"
+	PayDate time.Time `json:"pay_date"`
+
+	// Gross pay, the salary for the period plus overtime pay
+	// example: 4750000
+	GrossPay int `json:"gross_pay"`
+
+	// Final take-home pay
+	// example: 4700000
+	TakeHomePay int `json:"take_home_pay"`
+
+	// Links to the full payslip
+	Links payslipLinks `json:"links"`
+}
+
+// CreatePayslipSummaryProps represents the properties needed to create a new payslip summary
+// swagger:model CreatePayslipSummaryProps
+type CreatePayslipSummaryProps struct {
+	// Processed payroll period
+	PayrollPeriod entity.PayrollPeriod
+	// Payslip totals of the employee for the period
+	Total entity.PayslipTotal
+}
+
+func NewPayslipSummary(props *CreatePayslipSummaryProps) *PayslipSummary {
+	periodID := props.PayrollPeriod.ID.String()
+
+	return &PayslipSummary{
+		PeriodID:    props.PayrollPeriod.ID,
+		StartDate:   props.PayrollPeriod.StartDate,
+		EndDate:     props.PayrollPeriod.EndDate,
+		PayDate:     *props.PayrollPeriod.ProcessedAt,
+		GrossPay:    props.Total.GrossPay(),
+		TakeHomePay: props.Total.TakeHomePay,
+		Links: payslipLinks{
+			JSON: fmt.Sprintf("/v1/payroll/payslip?period_id=%s", periodID),
+			PDF:  fmt.Sprintf("/v1/payroll/payslip/pdf?period_id=%s", periodID),
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package vm
+
+import (
+	"testing"
+	"time"
+
+	"payslip-generator-service/internal/entity"
+)
+
+// TestNewPayslipTakeHomePay checks reimbursements are paid on top of the salary and overtime, they refund
+// expenses the employee paid for the company
+func TestNewPayslipTakeHomePay(t *testing.T) {
+	location := time.UTC
+	processedAt := time.Date(2025, time.June, 30, 18, 0, 0, 0, location)
+	period := entity.PayrollPeriod{
+		StartDate:   time.Date(2025, time.June, 2, 0, 0, 0, 0, location),
+		EndDate:     time.Date(2025, time.June, 6, 0, 0, 0, 0, location),
+		ProcessedAt: &processedAt,
+	}
+	weekdays, _ := entity.NewWorkDays([]string{"monday", "tuesday", "wednesday", "thursday", "friday"})
+	schedule := &entity.WorkSchedule{WorkDays: weekdays, ShiftStart: "09:00", ShiftEnd: "17:00"}
+
+	attendance := make([]entity.Attendance, 0, 5)
+	for day := period.StartDate; !day.After(period.EndDate); day = day.AddDate(0, 0, 1) {
+		start := day.Add(9 * time.Hour)
+		end := day.Add(17 * time.Hour)
+		attendance = append(attendance, entity.Attendance{StartTime: start, EndTime: &end, WorkDate: day, CreatedAt: start})
+	}
+
+	approvedAmount := 150000
+	approvedAt := time.Date(2025, time.June, 10, 10, 0, 0, 0, location)
+	reimbursement := entity.Reimbursement{
+		Amount:         200000,
+		ApprovedAmount: &approvedAmount,
+		ApprovedAt:     &approvedAt,
+		Status:         entity.ReimbursementStatusApproved,
+		Stage:          entity.ReimbursementStageFinance,
+	}
+
+	overtimeEnd := period.StartDate.Add(19 * time.Hour)
+	attendance[0].EndTime = &overtimeEnd
+	overtime := entity.Overtime{
+		Date:         period.StartDate,
+		TotalHours:   2,
+		Compensation: entity.OvertimeCompensationCash,
+		Status:       entity.OvertimeStatusApproved,
+		CreatedAt:    period.StartDate.Add(20 * time.Hour),
+	}
+
+	payslip, trace := NewPayslipWithTrace(&CreatePayslipProps{
+		Attendance:     attendance,
+		Overtime:       []entity.Overtime{overtime},
+		Reimbursement:  []entity.Reimbursement{reimbursement},
+		PayrollPeriod:  period,
+		Salary:         5000000,
+		WorkSchedule:   schedule,
+		Location:       location,
+		BreakAllowance: time.Hour,
+	})
+
+	if payslip.Salary != 5000000 {
+		t.Fatalf("Salary = %d, want %d", payslip.Salary, 5000000)
+	}
+	// 2 hours at twice the hourly salary of 5000000 / 5 days / 8 hours = 125000
+	if payslip.Overtime.TotalAmount != 500000 {
+		t.Fatalf("Overtime.TotalAmount = %d, want %d", payslip.Overtime.TotalAmount, 500000)
+	}
+	if payslip.Reimbursement.TotalAmount != approvedAmount {
+		t.Fatalf("Reimbursement.TotalAmount = %d, want %d", payslip.Reimbursement.TotalAmount, approvedAmount)
+	}
+
+	want := 5000000 + 500000 + 150000
+	if payslip.TakeHomePay != want {
+		t.Errorf("TakeHomePay = %d, want %d", payslip.TakeHomePay, want)
+	}
+	if step := trace.Steps[len(trace.Steps)-1]; step.Name != "take_home_pay" || step.Result != want {
+		t.Errorf("last trace step = %s %d, want take_home_pay %d", step.Name, step.Result, want)
+	}
+}
@@ -0,0 +1,287 @@
+package vm
+
+import (
+	"fmt"
+	"payslip-generator-service/internal/entity"
+	ulid "payslip-generator-service/pkg/database/gorm"
+	"time"
+)
+
+const (
+	// ExclusionReasonSubmittedAfterCutoff marks a record created after the payroll was processed
+	ExclusionReasonSubmittedAfterCutoff = "submitted-after-cutoff"
+	// ExclusionReasonOpenSession marks an attendance the employee had not checked out of when the payroll was processed
+	ExclusionReasonOpenSession = "open-session"
+	// ExclusionReasonExceedsDaysInPeriod marks an attendance beyond the number of days in the period
+	ExclusionReasonExceedsDaysInPeriod = "exceeds-days-in-period"
+	// ExclusionReasonNoAttendance marks an overtime on a day without a paid attendance
+	ExclusionReasonNoAttendance = "no-attendance"
+	// ExclusionReasonInsufficientNetHours marks an overtime on a day whose net worked hours fall short of the shift
+	ExclusionReasonInsufficientNetHours = "insufficient-net-hours"
+	// ExclusionReasonExceedsWorkedTime marks an overtime claiming more hours than were worked beyond the shift
+	ExclusionReasonExceedsWorkedTime = "exceeds-worked-time"
+	// ExclusionReasonDerivedFromAttendance marks an overtime claim not paid because overtime is derived from attendance
+	ExclusionReasonDerivedFromAttendance = "derived-from-attendance"
+	// ExclusionReasonNotEligible marks an overtime on a day the employee is not entitled to overtime
+	ExclusionReasonNotEligible = "not-eligible"
+	// ExclusionReasonExceedsCap marks an overtime derived from attendance beyond the daily, weekly or monthly cap
+	ExclusionReasonExceedsCap = "exceeds-cap"
+	// ExclusionReasonBankedAsToil marks an overtime banked as time off in lieu instead of being paid
+	ExclusionReasonBankedAsToil = "banked-as-toil"
+	// ExclusionReasonNotApproved marks a reimbursement finance has not approved
+	ExclusionReasonNotApproved = "not-approved"
+	// ExclusionReasonApprovedAfterCutoff marks a reimbursement approved after the payroll was processed
+	ExclusionReasonApprovedAfterCutoff = "approved-after-cutoff"
+)
+
+// AttendanceTrace explains whether an attendance record was counted in the payslip
+// swagger:model AttendanceTrace
+type AttendanceTrace struct {
+	// Attendance record
+	Attendance entity.Attendance `json:"attendance"`
+
+	// Whether the attendance day was counted
+	// example: true
+	Counted bool `json:"counted"`
+
+	// Reason code when the attendance was excluded
+	// example: "submitted-after-cutoff"
+	Reason string `json:"reason,omitempty"`
+
+	// Human readable explanation of the exclusion
+	// example: "created at 2024-02-01T09:00:00Z, after the payroll was processed at 2024-01-31T23:59:59Z"
+	Note string `json:"note,omitempty"`
+
+	// Whether the attendance was created or amended by an admin, see attendance.revisions for the original values
+	// example: true
+	Corrected bool `json:"corrected"`
+
+	// Human readable summary of the latest admin correction
+	// example: "amend at 2024-01-16T08:00:00Z: Employee forgot to check out"
+	Correction string `json:"correction,omitempty"`
+
+	// Late arrival, early leave and excess break deducted from the day, empty when the day is paid in full
+	Deduction *AttendanceDeduction `json:"deduction,omitempty"`
+}
+
+// OvertimeTrace explains whether an overtime record was paid in the payslip
+// swagger:model OvertimeTrace
+type OvertimeTrace struct {
+	// Overtime record
+	Overtime entity.Overtime `json:"overtime"`
+
+	// Whether the overtime was paid
+	// example: true
+	Included bool `json:"included"`
+
+	// Hours paid, the lesser of the planned and actual hours for overtime confirmed from a plan unless overridden
+	// example: 2
+	PaidHours int `json:"paid_hours"`
+
+	// Whole hours worked beyond the shift on the day, empty without a work schedule
+	// example: 2
+	ObservedHours *int `json:"observed_hours,omitempty"`
+
+	// Whether the overtime was paid although it claims more hours than were worked beyond the shift
+	// example: false
+	Flagged bool `json:"flagged"`
+
+	// Hours banked as time off in lieu instead of being paid
+	// example: 0
+	BankedHours int `json:"banked_hours,omitempty"`
+
+	// Amount paid for the overtime
+	// example: 50000
+	Amount int `json:"amount"`
+
+	// Reason code when the overtime was excluded
+	// example: "submitted-after-cutoff"
+	Reason string `json:"reason,omitempty"`
+
+	// Human readable explanation of the exclusion, the flag or the derivation from attendance
+	Note string `json:"note,omitempty"`
+}
+
+// ReimbursementTrace explains whether a reimbursement was included in the payslip
+// swagger:model ReimbursementTrace
+type ReimbursementTrace struct {
+	// Reimbursement record
+	Reimbursement entity.Reimbursement `json:"reimbursement"`
+
+	// Whether the reimbursement was included
+	// example: true
+	Included bool `json:"included"`
+
+	// Amount included for the reimbursement, the amount approved by finance
+	// example: 150000
+	Amount int `json:"amount"`
+
+	// Reason code when the reimbursement was excluded
+	// example: "approved-after-cutoff"
+	Reason string `json:"reason,omitempty"`
+
+	// Human readable explanation of the exclusion
+	Note string `json:"note,omitempty"`
+}
+
+// TraceStep represents a single calculation step of the payslip
+// swagger:model TraceStep
+type TraceStep struct {
+	// Name of the computed figure
+	// example: "salary_per_day"
+	Name string `json:"name"`
+
+	// Formula used to compute the figure
+	// example: "basic_salary / days_in_period"
+	Formula string `json:"formula"`
+
+	// Formula with the actual values substituted
+	// example: "5000000 / 31"
+	Expression string `json:"expression"`
+
+	// Result of the calculation
+	// example: 161290
+	Result int `json:"result"`
+}
+
+// PayslipTrace represents how each figure of a payslip was derived
+// swagger:model PayslipTrace
+type PayslipTrace struct {
+	// Unique identifier of the employee
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	EmployeeID ulid.ULID `json:"employee_id"`
+
+	// Start date of the payroll period
+	// example: "2024-01-01T00:00:00Z"
+	PeriodStartDate time.Time `json:"period_start_date"`
+
+	// End date of the payroll period
+	// example: "2024-01-31T00:00:00Z"
+	PeriodEndDate time.Time `json:"period_end_date"`
+
+	// Records created at or after this time are excluded
+	// example: "2024-01-31T23:59:59Z"
+	CutoffAt time.Time `json:"cutoff_at"`
+
+	// Number of days in the payroll period
+	// example: 31
+	DaysInPeriod int `json:"days_in_period"`
+
+	// Number of attendance records submitted in the period
+	// example: 22
+	AttendanceDaysSubmitted int `json:"attendance_days_submitted"`
+
+	// Number of attendance days paid
+	// example: 21
+	AttendanceDaysCounted int `json:"attendance_days_counted"`
+
+	// Employee's base salary amount
+	// example: 5000000
+	BasicSalary int `json:"basic_salary"`
+
+	// Salary paid per attendance day
+	// example: 161290
+	SalaryPerDay int `json:"salary_per_day"`
+
+	// Number of working hours in a day
+	// example: 8
+	HoursPerDay int `json:"hours_per_day"`
+
+	// Salary per working hour
+	// example: 20161
+	SalaryPerHour int `json:"salary_per_hour"`
+
+	// Multiplier of the hourly salary paid for overtime
+	// example: 2
+	OvertimeRate int `json:"overtime_rate"`
+
+	// Amount paid per overtime hour
+	// example: 40322
+	OvertimeRatePerHour int `json:"overtime_rate_per_hour"`
+
+	// Lateness up to this number of minutes is not deducted
+	// example: 15
+	LateGracePeriodMinutes int `json:"late_grace_period_minutes"`
+
+	// Early leave up to this number of minutes is not deducted
+	// example: 15
+	EarlyLeaveGracePeriodMinutes int `json:"early_leave_grace_period_minutes"`
+
+	// Unpaid break minutes per day included in the shift, longer unpaid breaks are deducted
+	// example: 60
+	BreakAllowanceMinutes int `json:"break_allowance_minutes"`
+
+	// Net hours to work in a day before its overtime is paid, the shift without the break allowance
+	// example: 8
+	OvertimeMinNetHours float64 `json:"overtime_min_net_hours"`
+
+	// Whether overtime was derived from the whole hours worked beyond the shift instead of the claims of the employee
+	// example: false
+	OvertimeFromAttendance bool `json:"overtime_from_attendance"`
+
+	// Salary deducted for late arrival, early leave and excess breaks
+	// example: 40322
+	DeductionAmount int `json:"deduction_amount"`
+
+	// Calculated salary for the period
+	// example: 3387090
+	Salary int `json:"salary"`
+
+	// Total overtime amount
+	// example: 80644
+	OvertimeAmount int `json:"overtime_amount"`
+
+	// Total reimbursement amount
+	// example: 150000
+	ReimbursementAmount int `json:"reimbursement_amount"`
+
+	// Final take-home pay
+	// example: 3317734
+	TakeHomePay int `json:"take_home_pay"`
+
+	// Every attendance record of the period and whether it was counted
+	Attendances []AttendanceTrace `json:"attendances"`
+
+	// Every overtime record of the period and whether it was paid
+	Overtimes []OvertimeTrace `json:"overtimes"`
+
+	// Every reimbursement of the period and whether it was included
+	Reimbursements []ReimbursementTrace `json:"reimbursements"`
+
+	// Calculation steps in evaluation order
+	Steps []TraceStep `json:"steps"`
+}
+
+func newAttendanceTrace(a entity.Attendance) AttendanceTrace {
+	trace := AttendanceTrace{Attendance: a, Counted: true}
+	if a.IsCorrected() {
+		revision := a.Revisions[len(a.Revisions)-1]
+		trace.Corrected = true
+		trace.Correction = fmt.Sprintf("%s at %s: %s", revision.Action, revision.CreatedAt.Format(time.RFC3339), revision.Reason)
+	}
+	return trace
+}
+
+func newExcludedAttendanceTrace(a entity.Attendance, cutoff time.Time) AttendanceTrace {
+	trace := newAttendanceTrace(a)
+	trace.Counted = false
+	trace.Reason = ExclusionReasonSubmittedAfterCutoff
+	trace.Note = submittedAfterCutoffNote(a.CreatedAt, cutoff)
+	return trace
+}
+
+func newOpenAttendanceTrace(a entity.Attendance, cutoff time.Time) AttendanceTrace {
+	trace := newAttendanceTrace(a)
+	trace.Counted = false
+	trace.Reason = ExclusionReasonOpenSession
+	trace.Note = fmt.Sprintf("checked in at %s, not checked out when the payroll was processed at %s", a.StartTime.Format(time.RFC3339), cutoff.Format(time.RFC3339))
+	return trace
+}
+
+func submittedAfterCutoffNote(createdAt, cutoff time.Time) string {
+	return fmt.Sprintf("created at %s, after the payroll was processed at %s", createdAt.Format(time.RFC3339), cutoff.Format(time.RFC3339))
+}
+
+func approvedAfterCutoffNote(approvedAt, cutoff time.Time) string {
+	return fmt.Sprintf("approved at %s, after the payroll was processed at %s", approvedAt.Format(time.RFC3339), cutoff.Format(time.RFC3339))
+}
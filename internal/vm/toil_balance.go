@@ -0,0 +1,105 @@
+package vm
+
+import (
+	"payslip-generator-service/internal/entity"
+	ulid "payslip-generator-service/pkg/database/gorm"
+	"sort"
+	"time"
+)
+
+// ToilExpiring represents banked hours left of an accrual and the last day they can be taken
+// swagger:model ToilExpiring
+type ToilExpiring struct {
+	// Accrual the hours were banked by
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	AccrualID ulid.ULID `json:"accrual_id"`
+
+	// Last day the hours can be taken
+	// example: "2024-04-14T00:00:00Z"
+	ExpiresOn time.Time `json:"expires_on"`
+
+	// Hours left
+	// example: 2
+	Hours int `json:"hours"`
+}
+
+// ToilBalance represents the time-off-in-lieu balance of an employee along with its ledger
+// swagger:model ToilBalance
+type ToilBalance struct {
+	// Unique identifier of the employee
+	// example: "01HXYZ123456789ABCDEFGHIJK"
+	EmployeeID ulid.ULID `json:"employee_id"`
+
+	// Hours banked and not taken or lapsed
+	// example: 6
+	Balance int `json:"balance"`
+
+	// Most hours the balance may hold, empty when unlimited
+	// example: 40
+	MaxBalance *int `json:"max_balance"`
+
+	// Total hours banked from overtime
+	// example: 10
+	Accrued int `json:"accrued"`
+
+	// Total hours taken as time off
+	// example: 3
+	Used int `json:"used"`
+
+	// Total hours lapsed unused
+	// example: 1
+	Expired int `json:"expired"`
+
+	// Hours left that will lapse, the earliest expiry first
+	Expiring []ToilExpiring `json:"expiring"`
+
+	// Ledger entries, in the order they were recorded
+	Entries entity.ToilLedger `json:"entries"`
+}
+
+// CreateToilBalanceProps represents the properties needed to create a new time-off-in-lieu balance
+// swagger:model CreateToilBalanceProps
+type CreateToilBalanceProps struct {
+	// Unique identifier of the employee
+	EmployeeID ulid.ULID
+	// Ledger of the employee, with the lapsed hours already expired
+	Ledger entity.ToilLedger
+	// Most hours the balance may hold, zero for unlimited
+	MaxBalance int
+}
+
+func NewToilBalance(props *CreateToilBalanceProps) *ToilBalance {
+	balance := &ToilBalance{
+		EmployeeID: props.EmployeeID,
+		Balance:    props.Ledger.Balance(),
+		Expiring:   make([]ToilExpiring, 0),
+		Entries:    props.Ledger,
+	}
+	if balance.Entries == nil {
+		balance.Entries = make(entity.ToilLedger, 0)
+	}
+	if props.MaxBalance > 0 {
+		maxBalance := props.MaxBalance
+		balance.MaxBalance = &maxBalance
+	}
+
+	remaining := props.Ledger.Remaining()
+	for _, e := range props.Ledger {
+		switch e.Type {
+		case entity.ToilEntryTypeAccrual:
+			balance.Accrued += e.Hours
+			if hours := remaining[e.ID]; e.ExpiresOn != nil && hours > 0 {
+				balance.Expiring = append(balance.Expiring, ToilExpiring{AccrualID: e.ID, ExpiresOn: *e.ExpiresOn, Hours: hours})
+			}
+		case entity.ToilEntryTypeUsage:
+			balance.Used += e.Hours
+		case entity.ToilEntryTypeExpiry:
+			balance.Expired += e.Hours
+		}
+	}
+	sort.SliceStable(balance.Expiring, func(i, j int) bool {
+		return balance.Expiring[i].ExpiresOn.Before(balance.Expiring[j].ExpiresOn)
+	})
+
+	return balance
+}
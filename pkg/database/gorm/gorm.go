@@ -95,13 +95,19 @@ func (l *CustomGormLogger) Trace(ctx context.Context, begin time.Time, fc func()
 }
 
 func NewGormDB(conf *config.Config, log *logrus.Logger) *GormDB {
-	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s TimeZone=Asia/Jakarta",
+	timeZone := conf.App.TimeZone
+	if timeZone == "" {
+		timeZone = "Asia/Jakarta"
+	}
+
+	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s TimeZone=%s",
 		conf.Postgres.Host,
 		conf.Postgres.Port,
 		conf.Postgres.User,
 		conf.Postgres.Password,
 		conf.Postgres.Dbname,
 		conf.Postgres.SSLMode,
+		timeZone,
 	)
 
 	// Create custom logger for GORM using logrus
@@ -111,6 +117,7 @@ func NewGormDB(conf *config.Config, log *logrus.Logger) *GormDB {
 		SkipDefaultTransaction: true,
 		DryRun:                 conf.Postgres.DryRun,
 		PrepareStmt:            true,
+		TranslateError:         true,
 		Logger:                 gormLogger,
 		NamingStrategy: schema.NamingStrategy{
 			TablePrefix: "public.",
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "employee" ADD COLUMN "department" VARCHAR(100);
+
+CREATE INDEX IF NOT EXISTS idx_employee_department ON employee (department);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_employee_department;
+
+ALTER TABLE "employee" DROP COLUMN IF EXISTS "department";
+-- +goose StatementEnd
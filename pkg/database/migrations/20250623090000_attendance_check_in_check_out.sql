@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "attendance" ALTER COLUMN "end_time" DROP NOT NULL;
+ALTER TABLE "attendance" ADD COLUMN "auto_checked_out" BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_attendance_open_session ON attendance (created_by) WHERE end_time IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_attendance_open_session;
+
+DELETE FROM "attendance" WHERE end_time IS NULL;
+ALTER TABLE "attendance" DROP COLUMN IF EXISTS "auto_checked_out";
+ALTER TABLE "attendance" ALTER COLUMN "end_time" SET NOT NULL;
+-- +goose StatementEnd
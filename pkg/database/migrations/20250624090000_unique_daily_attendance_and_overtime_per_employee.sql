@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- a voided attendance or overtime is kept for the record but no longer occupies its day
+ALTER TABLE "attendance" ADD COLUMN "voided_at" TIMESTAMP WITH TIME ZONE;
+ALTER TABLE "overtime" ADD COLUMN "voided_at" TIMESTAMP WITH TIME ZONE;
+
+-- duplicates recorded by concurrent requests keep the earliest row and void the rest
+UPDATE "attendance" a SET voided_at = CURRENT_TIMESTAMP
+FROM "attendance" b
+WHERE a.created_by = b.created_by
+  AND (a.start_time AT TIME ZONE 'Asia/Jakarta')::date = (b.start_time AT TIME ZONE 'Asia/Jakarta')::date
+  AND (a.created_at, a.id) > (b.created_at, b.id);
+UPDATE "overtime" a SET voided_at = CURRENT_TIMESTAMP
+FROM "overtime" b
+WHERE a.created_by = b.created_by
+  AND a.date = b.date
+  AND (a.created_at, a.id) > (b.created_at, b.id);
+
+-- DATE() of a timestamptz depends on the session time zone and cannot be indexed,
+-- so the day is taken in the same zone the application connects with (Asia/Jakarta)
+CREATE UNIQUE INDEX IF NOT EXISTS uq_attendance_employee_day ON attendance (created_by, ((start_time AT TIME ZONE 'Asia/Jakarta')::date)) WHERE voided_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_overtime_employee_date ON overtime (created_by, date) WHERE voided_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS uq_attendance_employee_day;
+DROP INDEX IF EXISTS uq_overtime_employee_date;
+ALTER TABLE "attendance" DROP COLUMN IF EXISTS "voided_at";
+ALTER TABLE "overtime" DROP COLUMN IF EXISTS "voided_at";
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "attendance" ADD COLUMN "voided_by" ulid;
+ALTER TABLE "attendance" ADD CONSTRAINT "fk_attendance_voided_by" FOREIGN KEY ("voided_by") REFERENCES "employee" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+CREATE TYPE "attendance_revision_action" AS ENUM ('create', 'amend', 'void');
+CREATE TABLE IF NOT EXISTS "attendance_revision" (
+    id ulid PRIMARY KEY,
+    attendance_id ulid NOT NULL,
+    action attendance_revision_action NOT NULL,
+    previous_start_time TIMESTAMP WITH TIME ZONE,
+    previous_end_time TIMESTAMP WITH TIME ZONE,
+    new_start_time TIMESTAMP WITH TIME ZONE,
+    new_end_time TIMESTAMP WITH TIME ZONE,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by ulid NOT NULL
+);
+
+ALTER TABLE "attendance_revision" ADD CONSTRAINT "fk_attendance_revision_attendance_id" FOREIGN KEY ("attendance_id") REFERENCES "attendance" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "attendance_revision" ADD CONSTRAINT "fk_attendance_revision_created_by" FOREIGN KEY ("created_by") REFERENCES "employee" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_attendance_revision_attendance_id ON attendance_revision (attendance_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "attendance_revision";
+DROP TYPE IF EXISTS "attendance_revision_action";
+
+ALTER TABLE "attendance" DROP CONSTRAINT IF EXISTS "fk_attendance_voided_by";
+ALTER TABLE "attendance" DROP COLUMN IF EXISTS "voided_by";
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE "attendance_correction_request_status" AS ENUM ('pending', 'approved', 'rejected');
+CREATE TABLE IF NOT EXISTS "attendance_correction_request" (
+    id ulid PRIMARY KEY,
+    attendance_id ulid,
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason TEXT NOT NULL,
+    status attendance_correction_request_status NOT NULL DEFAULT 'pending',
+    review_comment TEXT,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    reviewed_by ulid,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by ulid NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    updated_by ulid
+);
+
+ALTER TABLE "attendance_correction_request" ADD CONSTRAINT "fk_attendance_correction_request_attendance_id" FOREIGN KEY ("attendance_id") REFERENCES "attendance" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE "attendance_correction_request" ADD CONSTRAINT "fk_attendance_correction_request_reviewed_by" FOREIGN KEY ("reviewed_by") REFERENCES "employee" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE "attendance_correction_request" ADD CONSTRAINT "fk_attendance_correction_request_created_by" FOREIGN KEY ("created_by") REFERENCES "employee" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "attendance_correction_request" ADD CONSTRAINT "fk_attendance_correction_request_updated_by" FOREIGN KEY ("updated_by") REFERENCES "employee" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_attendance_correction_request_status ON attendance_correction_request (status, created_at);
+CREATE INDEX IF NOT EXISTS idx_attendance_correction_request_created_by ON attendance_correction_request (created_by);
+
+ALTER TABLE "attendance_revision" ADD COLUMN "correction_request_id" ulid;
+ALTER TABLE "attendance_revision" ADD CONSTRAINT "fk_attendance_revision_correction_request_id" FOREIGN KEY ("correction_request_id") REFERENCES "attendance_correction_request" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "attendance_revision" DROP CONSTRAINT IF EXISTS "fk_attendance_revision_correction_request_id";
+ALTER TABLE "attendance_revision" DROP COLUMN IF EXISTS "correction_request_id";
+
+DROP TABLE IF EXISTS "attendance_correction_request";
+DROP TYPE IF EXISTS "attendance_correction_request_status";
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "work_schedule" (
+    id ulid PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    work_days SMALLINT NOT NULL, -- bit n set means time.Weekday n (0 = Sunday) is a work day
+    shift_start VARCHAR(5) NOT NULL, -- HH:MM
+    shift_end VARCHAR(5) NOT NULL, -- HH:MM, earlier than shift_start for a cross-midnight shift
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by ulid NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    updated_by ulid
+);
+
+ALTER TABLE "work_schedule" ADD CONSTRAINT "fk_work_schedule_created_by" FOREIGN KEY ("created_by") REFERENCES "employee" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "work_schedule" ADD CONSTRAINT "fk_work_schedule_updated_by" FOREIGN KEY ("updated_by") REFERENCES "employee" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+ALTER TABLE "work_schedule" ADD CONSTRAINT check_work_schedule_work_days CHECK (work_days > 0 AND work_days < 128);
+ALTER TABLE "work_schedule" ADD CONSTRAINT check_work_schedule_shift_start CHECK (shift_start ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$');
+ALTER TABLE "work_schedule" ADD CONSTRAINT check_work_schedule_shift_end CHECK (shift_end ~ '^([01][0-9]|2[0-3]):[0-5][0-9]$');
+ALTER TABLE "work_schedule" ADD CONSTRAINT check_work_schedule_shift_length CHECK (shift_start <> shift_end);
+
+-- employees without a schedule follow the default Monday to Friday day shift
+ALTER TABLE "employee" ADD COLUMN "work_schedule_id" ulid;
+ALTER TABLE "employee" ADD CONSTRAINT "fk_employee_work_schedule_id" FOREIGN KEY ("work_schedule_id") REFERENCES "work_schedule" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- weekend and cross-midnight rules now come from the employee's work schedule
+ALTER TABLE "attendance" DROP CONSTRAINT IF EXISTS check_attendance_same_day;
+ALTER TABLE "attendance" DROP CONSTRAINT IF EXISTS check_attendance_no_weekend;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "attendance" ADD CONSTRAINT check_attendance_no_weekend CHECK (EXTRACT(DOW FROM start_time) NOT IN (0, 6) AND EXTRACT(DOW FROM end_time) NOT IN (0, 6)) NOT VALID;
+ALTER TABLE "attendance" ADD CONSTRAINT check_attendance_same_day CHECK (DATE(end_time) = DATE(start_time)) NOT VALID;
+
+ALTER TABLE "employee" DROP CONSTRAINT IF EXISTS "fk_employee_work_schedule_id";
+ALTER TABLE "employee" DROP COLUMN IF EXISTS "work_schedule_id";
+
+DROP TABLE IF EXISTS "work_schedule";
+-- +goose StatementEnd
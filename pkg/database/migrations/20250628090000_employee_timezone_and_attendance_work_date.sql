@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- IANA time zone name, empty means the configured App.TimeZone
+ALTER TABLE "employee" ADD COLUMN "timezone" VARCHAR(64);
+
+-- the calendar day of the shift in the employee's time zone, existing rows were recorded in Asia/Jakarta
+ALTER TABLE "attendance" ADD COLUMN "work_date" DATE;
+UPDATE "attendance" SET work_date = (start_time AT TIME ZONE 'Asia/Jakarta')::date;
+ALTER TABLE "attendance" ALTER COLUMN "work_date" SET NOT NULL;
+
+DROP INDEX IF EXISTS uq_attendance_employee_day;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_attendance_employee_work_date ON attendance (created_by, work_date) WHERE voided_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_attendance_work_date ON attendance (work_date);
+
+ALTER TABLE "attendance_correction_request" ADD COLUMN "work_date" DATE;
+UPDATE "attendance_correction_request" SET work_date = (start_time AT TIME ZONE 'Asia/Jakarta')::date;
+ALTER TABLE "attendance_correction_request" ALTER COLUMN "work_date" SET NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "attendance_correction_request" DROP COLUMN IF EXISTS "work_date";
+
+DROP INDEX IF EXISTS idx_attendance_work_date;
+DROP INDEX IF EXISTS uq_attendance_employee_work_date;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_attendance_employee_day ON attendance (created_by, ((start_time AT TIME ZONE 'Asia/Jakarta')::date)) WHERE voided_at IS NULL;
+ALTER TABLE "attendance" DROP COLUMN IF EXISTS "work_date";
+
+ALTER TABLE "employee" DROP COLUMN IF EXISTS "timezone";
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- user ID the employee is enrolled with on biometric or timeclock terminals, used to match imported punches
+ALTER TABLE "employee" ADD COLUMN "device_user_id" VARCHAR(64);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_employee_device_user_id ON employee (device_user_id) WHERE device_user_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS uq_employee_device_user_id;
+ALTER TABLE "employee" DROP COLUMN IF EXISTS "device_user_id";
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE attendance_location_status AS ENUM ('unverified', 'office', 'remote');
+
+-- where the attendance was recorded from, existing rows were never checked
+ALTER TABLE "attendance" ADD COLUMN "latitude" DOUBLE PRECISION;
+ALTER TABLE "attendance" ADD COLUMN "longitude" DOUBLE PRECISION;
+ALTER TABLE "attendance" ADD COLUMN "ip_address" VARCHAR(45);
+ALTER TABLE "attendance" ADD COLUMN "location_status" attendance_location_status NOT NULL DEFAULT 'unverified';
+ALTER TABLE "attendance" ADD COLUMN "office" VARCHAR(100);
+
+ALTER TABLE "attendance" ADD CONSTRAINT check_attendance_coordinates
+    CHECK ((latitude IS NULL) = (longitude IS NULL) AND latitude BETWEEN -90 AND 90 AND longitude BETWEEN -180 AND 180);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "attendance" DROP CONSTRAINT IF EXISTS check_attendance_coordinates;
+ALTER TABLE "attendance" DROP COLUMN IF EXISTS "office";
+ALTER TABLE "attendance" DROP COLUMN IF EXISTS "location_status";
+ALTER TABLE "attendance" DROP COLUMN IF EXISTS "ip_address";
+ALTER TABLE "attendance" DROP COLUMN IF EXISTS "longitude";
+ALTER TABLE "attendance" DROP COLUMN IF EXISTS "latitude";
+
+DROP TYPE IF EXISTS attendance_location_status;
+-- +goose StatementEnd
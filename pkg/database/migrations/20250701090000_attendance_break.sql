@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "attendance_break" (
+    id ulid PRIMARY KEY,
+    attendance_id ulid NOT NULL,
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE,
+    paid BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by ulid NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    updated_by ulid
+);
+
+ALTER TABLE "attendance_break" ADD CONSTRAINT "fk_attendance_break_attendance_id" FOREIGN KEY ("attendance_id") REFERENCES "attendance" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "attendance_break" ADD CONSTRAINT "fk_attendance_break_created_by" FOREIGN KEY ("created_by") REFERENCES "employee" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "attendance_break" ADD CONSTRAINT "fk_attendance_break_updated_by" FOREIGN KEY ("updated_by") REFERENCES "employee" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE "attendance_break" ADD CONSTRAINT check_attendance_break_time_order CHECK (end_time IS NULL OR end_time >= start_time);
+
+CREATE INDEX IF NOT EXISTS idx_attendance_break_attendance_id ON attendance_break (attendance_id);
+-- an attendance has at most one break in progress
+CREATE UNIQUE INDEX IF NOT EXISTS uq_attendance_break_open ON attendance_break (attendance_id) WHERE end_time IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "attendance_break";
+-- +goose StatementEnd
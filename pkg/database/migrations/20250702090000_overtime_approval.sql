@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+-- manager of the employee, allowed to approve or reject the employee's overtime
+ALTER TABLE "employee" ADD COLUMN "manager_id" ulid;
+ALTER TABLE "employee" ADD CONSTRAINT "fk_employee_manager_id" FOREIGN KEY ("manager_id") REFERENCES "employee" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE "employee" ADD CONSTRAINT check_employee_manager CHECK (manager_id IS NULL OR manager_id <> id);
+CREATE INDEX IF NOT EXISTS idx_employee_manager_id ON employee (manager_id);
+
+CREATE TYPE "overtime_status" AS ENUM ('pending', 'approved', 'rejected', 'cancelled');
+
+-- overtime submitted so far was paid automatically, keep it approved
+ALTER TABLE "overtime" ADD COLUMN "status" overtime_status NOT NULL DEFAULT 'approved';
+ALTER TABLE "overtime" ALTER COLUMN "status" SET DEFAULT 'pending';
+ALTER TABLE "overtime" ADD COLUMN "review_comment" TEXT;
+ALTER TABLE "overtime" ADD COLUMN "reviewed_at" TIMESTAMP WITH TIME ZONE;
+ALTER TABLE "overtime" ADD COLUMN "reviewed_by" ulid;
+ALTER TABLE "overtime" ADD CONSTRAINT "fk_overtime_reviewed_by" FOREIGN KEY ("reviewed_by") REFERENCES "employee" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+-- a rejected or cancelled overtime no longer occupies its day, a voided one becomes cancelled
+UPDATE "overtime" SET status = 'cancelled' WHERE voided_at IS NOT NULL;
+DROP INDEX IF EXISTS uq_overtime_employee_date;
+ALTER TABLE "overtime" DROP COLUMN IF EXISTS "voided_at";
+CREATE UNIQUE INDEX IF NOT EXISTS uq_overtime_employee_date ON overtime (created_by, date) WHERE status IN ('pending', 'approved');
+CREATE INDEX IF NOT EXISTS idx_overtime_status ON overtime (status, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_overtime_status;
+DROP INDEX IF EXISTS uq_overtime_employee_date;
+ALTER TABLE "overtime" ADD COLUMN "voided_at" TIMESTAMP WITH TIME ZONE;
+UPDATE "overtime" SET voided_at = COALESCE(reviewed_at, CURRENT_TIMESTAMP) WHERE status IN ('rejected', 'cancelled');
+CREATE UNIQUE INDEX IF NOT EXISTS uq_overtime_employee_date ON overtime (created_by, date) WHERE voided_at IS NULL;
+
+ALTER TABLE "overtime" DROP CONSTRAINT IF EXISTS "fk_overtime_reviewed_by";
+ALTER TABLE "overtime" DROP COLUMN IF EXISTS "reviewed_by";
+ALTER TABLE "overtime" DROP COLUMN IF EXISTS "reviewed_at";
+ALTER TABLE "overtime" DROP COLUMN IF EXISTS "review_comment";
+ALTER TABLE "overtime" DROP COLUMN IF EXISTS "status";
+DROP TYPE IF EXISTS "overtime_status";
+
+DROP INDEX IF EXISTS idx_employee_manager_id;
+ALTER TABLE "employee" DROP CONSTRAINT IF EXISTS check_employee_manager;
+ALTER TABLE "employee" DROP CONSTRAINT IF EXISTS "fk_employee_manager_id";
+ALTER TABLE "employee" DROP COLUMN IF EXISTS "manager_id";
+-- +goose StatementEnd
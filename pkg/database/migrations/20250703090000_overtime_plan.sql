@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE "overtime_plan_status" AS ENUM ('pending', 'approved', 'rejected', 'cancelled', 'confirmed');
+CREATE TABLE IF NOT EXISTS "overtime_plan" (
+    id ulid PRIMARY KEY,
+    date DATE NOT NULL,
+    planned_hours INTEGER NOT NULL,
+    reason TEXT NOT NULL,
+    status overtime_plan_status NOT NULL DEFAULT 'pending',
+    review_comment TEXT,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    reviewed_by ulid,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by ulid NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE,
+    updated_by ulid
+);
+
+ALTER TABLE "overtime_plan" ADD CONSTRAINT "fk_overtime_plan_reviewed_by" FOREIGN KEY ("reviewed_by") REFERENCES "employee" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE "overtime_plan" ADD CONSTRAINT "fk_overtime_plan_created_by" FOREIGN KEY ("created_by") REFERENCES "employee" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "overtime_plan" ADD CONSTRAINT "fk_overtime_plan_updated_by" FOREIGN KEY ("updated_by") REFERENCES "employee" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE "overtime_plan" ADD CONSTRAINT check_overtime_plan_duration CHECK (planned_hours >= 1 AND planned_hours <= 3);
+
+-- a rejected or cancelled plan no longer occupies its day
+CREATE UNIQUE INDEX IF NOT EXISTS uq_overtime_plan_employee_date ON overtime_plan (created_by, date) WHERE status IN ('pending', 'approved', 'confirmed');
+CREATE INDEX IF NOT EXISTS idx_overtime_plan_status ON overtime_plan (status, created_at);
+
+-- overtime confirmed from a plan is paid for the lesser of the planned and actual hours, unless an admin overrides it
+ALTER TABLE "overtime" ADD COLUMN "plan_id" ulid;
+ALTER TABLE "overtime" ADD COLUMN "planned_hours" INTEGER;
+ALTER TABLE "overtime" ADD COLUMN "override_hours" INTEGER;
+ALTER TABLE "overtime" ADD COLUMN "override_comment" TEXT;
+ALTER TABLE "overtime" ADD COLUMN "overridden_at" TIMESTAMP WITH TIME ZONE;
+ALTER TABLE "overtime" ADD COLUMN "overridden_by" ulid;
+ALTER TABLE "overtime" ADD CONSTRAINT "fk_overtime_plan_id" FOREIGN KEY ("plan_id") REFERENCES "overtime_plan" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE "overtime" ADD CONSTRAINT "fk_overtime_overridden_by" FOREIGN KEY ("overridden_by") REFERENCES "employee" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE "overtime" ADD CONSTRAINT check_overtime_override_hours CHECK (override_hours IS NULL OR (override_hours >= 0 AND override_hours <= total_hours));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "overtime" DROP CONSTRAINT IF EXISTS check_overtime_override_hours;
+ALTER TABLE "overtime" DROP CONSTRAINT IF EXISTS "fk_overtime_overridden_by";
+ALTER TABLE "overtime" DROP CONSTRAINT IF EXISTS "fk_overtime_plan_id";
+ALTER TABLE "overtime" DROP COLUMN IF EXISTS "overridden_by";
+ALTER TABLE "overtime" DROP COLUMN IF EXISTS "overridden_at";
+ALTER TABLE "overtime" DROP COLUMN IF EXISTS "override_comment";
+ALTER TABLE "overtime" DROP COLUMN IF EXISTS "override_hours";
+ALTER TABLE "overtime" DROP COLUMN IF EXISTS "planned_hours";
+ALTER TABLE "overtime" DROP COLUMN IF EXISTS "plan_id";
+
+DROP TABLE IF EXISTS "overtime_plan";
+DROP TYPE IF EXISTS "overtime_plan_status";
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- history of the overtime entitlement of each employee, an employee without an entry is entitled to overtime pay
+CREATE TABLE IF NOT EXISTS "overtime_eligibility" (
+    id ulid PRIMARY KEY,
+    employee_id ulid NOT NULL,
+    eligible BOOLEAN NOT NULL,
+    effective_date DATE NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by ulid NOT NULL
+);
+
+ALTER TABLE "overtime_eligibility" ADD CONSTRAINT "fk_overtime_eligibility_employee_id" FOREIGN KEY ("employee_id") REFERENCES "employee" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "overtime_eligibility" ADD CONSTRAINT "fk_overtime_eligibility_created_by" FOREIGN KEY ("created_by") REFERENCES "employee" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_overtime_eligibility_employee_date ON overtime_eligibility (employee_id, effective_date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "overtime_eligibility";
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+-- overtime is either paid or banked as time off in lieu
+CREATE TYPE "overtime_compensation" AS ENUM ('cash', 'toil');
+ALTER TABLE "overtime" ADD COLUMN "compensation" overtime_compensation NOT NULL DEFAULT 'cash';
+
+CREATE TYPE "toil_entry_type" AS ENUM ('accrual', 'usage', 'expiry');
+CREATE TABLE IF NOT EXISTS "toil_entry" (
+    id ulid PRIMARY KEY,
+    employee_id ulid NOT NULL,
+    type toil_entry_type NOT NULL,
+    hours INTEGER NOT NULL,
+    date DATE NOT NULL,
+    expires_on DATE,
+    overtime_id ulid,
+    accrual_id ulid,
+    reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by ulid
+);
+
+ALTER TABLE "toil_entry" ADD CONSTRAINT "fk_toil_entry_employee_id" FOREIGN KEY ("employee_id") REFERENCES "employee" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "toil_entry" ADD CONSTRAINT "fk_toil_entry_overtime_id" FOREIGN KEY ("overtime_id") REFERENCES "overtime" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "toil_entry" ADD CONSTRAINT "fk_toil_entry_accrual_id" FOREIGN KEY ("accrual_id") REFERENCES "toil_entry" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "toil_entry" ADD CONSTRAINT "fk_toil_entry_created_by" FOREIGN KEY ("created_by") REFERENCES "employee" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE "toil_entry" ADD CONSTRAINT check_toil_entry_hours CHECK (hours >= 0);
+
+-- an overtime is banked once
+CREATE UNIQUE INDEX IF NOT EXISTS uq_toil_entry_overtime_id ON toil_entry (overtime_id) WHERE type = 'accrual';
+CREATE INDEX IF NOT EXISTS idx_toil_entry_employee_id ON toil_entry (employee_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "toil_entry";
+DROP TYPE IF EXISTS "toil_entry_type";
+
+ALTER TABLE "overtime" DROP COLUMN IF EXISTS "compensation";
+DROP TYPE IF EXISTS "overtime_compensation";
+-- +goose StatementEnd
@@ -0,0 +1,62 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE "reimbursement_status" AS ENUM ('pending', 'approved', 'rejected');
+CREATE TYPE "reimbursement_stage" AS ENUM ('manager', 'finance');
+
+-- reimbursements submitted so far were paid automatically, keep them approved for their full amount
+ALTER TABLE "reimbursement" ADD COLUMN "status" reimbursement_status NOT NULL DEFAULT 'approved';
+ALTER TABLE "reimbursement" ALTER COLUMN "status" SET DEFAULT 'pending';
+ALTER TABLE "reimbursement" ADD COLUMN "stage" reimbursement_stage NOT NULL DEFAULT 'finance';
+ALTER TABLE "reimbursement" ALTER COLUMN "stage" SET DEFAULT 'manager';
+ALTER TABLE "reimbursement" ADD COLUMN "approved_amount" INTEGER;
+ALTER TABLE "reimbursement" ADD COLUMN "approved_at" TIMESTAMP WITH TIME ZONE;
+UPDATE "reimbursement" SET approved_amount = amount, approved_at = created_at;
+ALTER TABLE "reimbursement" ADD COLUMN "rejection_reason" TEXT;
+ALTER TABLE "reimbursement" ADD COLUMN "submission" INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE "reimbursement" ADD COLUMN "reviewed_at" TIMESTAMP WITH TIME ZONE;
+ALTER TABLE "reimbursement" ADD COLUMN "reviewed_by" ulid;
+ALTER TABLE "reimbursement" ADD CONSTRAINT "fk_reimbursement_reviewed_by" FOREIGN KEY ("reviewed_by") REFERENCES "employee" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+ALTER TABLE "reimbursement" ADD CONSTRAINT "check_reimbursement_approved_amount" CHECK (approved_amount IS NULL OR approved_amount BETWEEN 1 AND amount);
+
+CREATE INDEX IF NOT EXISTS idx_reimbursement_status ON reimbursement (status, stage, created_at);
+CREATE INDEX IF NOT EXISTS idx_reimbursement_approved_at ON reimbursement (created_by, approved_at) WHERE status = 'approved';
+
+CREATE TYPE "reimbursement_decision" AS ENUM ('approved', 'rejected');
+CREATE TABLE IF NOT EXISTS "reimbursement_review" (
+    id ulid PRIMARY KEY,
+    reimbursement_id ulid NOT NULL,
+    submission INTEGER NOT NULL,
+    stage reimbursement_stage NOT NULL,
+    decision reimbursement_decision NOT NULL,
+    amount INTEGER,
+    comment TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by ulid NOT NULL
+);
+
+ALTER TABLE "reimbursement_review" ADD CONSTRAINT "fk_reimbursement_review_reimbursement_id" FOREIGN KEY ("reimbursement_id") REFERENCES "reimbursement" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "reimbursement_review" ADD CONSTRAINT "fk_reimbursement_review_created_by" FOREIGN KEY ("created_by") REFERENCES "employee" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_reimbursement_review_reimbursement_id ON reimbursement_review (reimbursement_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "reimbursement_review";
+DROP TYPE IF EXISTS "reimbursement_decision";
+
+DROP INDEX IF EXISTS idx_reimbursement_approved_at;
+DROP INDEX IF EXISTS idx_reimbursement_status;
+ALTER TABLE "reimbursement" DROP CONSTRAINT IF EXISTS "check_reimbursement_approved_amount";
+ALTER TABLE "reimbursement" DROP CONSTRAINT IF EXISTS "fk_reimbursement_reviewed_by";
+ALTER TABLE "reimbursement" DROP COLUMN IF EXISTS "reviewed_by";
+ALTER TABLE "reimbursement" DROP COLUMN IF EXISTS "reviewed_at";
+ALTER TABLE "reimbursement" DROP COLUMN IF EXISTS "submission";
+ALTER TABLE "reimbursement" DROP COLUMN IF EXISTS "rejection_reason";
+ALTER TABLE "reimbursement" DROP COLUMN IF EXISTS "approved_at";
+ALTER TABLE "reimbursement" DROP COLUMN IF EXISTS "approved_amount";
+ALTER TABLE "reimbursement" DROP COLUMN IF EXISTS "stage";
+ALTER TABLE "reimbursement" DROP COLUMN IF EXISTS "status";
+DROP TYPE IF EXISTS "reimbursement_stage";
+DROP TYPE IF EXISTS "reimbursement_status";
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "reimbursement_receipt" (
+    id ulid PRIMARY KEY,
+    reimbursement_id ulid NOT NULL,
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size INTEGER NOT NULL,
+    storage_key TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_by ulid NOT NULL
+);
+
+ALTER TABLE "reimbursement_receipt" ADD CONSTRAINT "fk_reimbursement_receipt_reimbursement_id" FOREIGN KEY ("reimbursement_id") REFERENCES "reimbursement" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "reimbursement_receipt" ADD CONSTRAINT "fk_reimbursement_receipt_created_by" FOREIGN KEY ("created_by") REFERENCES "employee" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "reimbursement_receipt" ADD CONSTRAINT "check_reimbursement_receipt_size" CHECK (size > 0);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_reimbursement_receipt_storage_key ON reimbursement_receipt (storage_key);
+CREATE INDEX IF NOT EXISTS idx_reimbursement_receipt_reimbursement_id ON reimbursement_receipt (reimbursement_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "reimbursement_receipt";
+-- +goose StatementEnd
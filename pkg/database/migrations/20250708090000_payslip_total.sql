@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "payslip_total" (
+    id ulid PRIMARY KEY,
+    payroll_period_id ulid NOT NULL,
+    employee_id ulid NOT NULL,
+    salary INTEGER NOT NULL,
+    overtime_amount INTEGER NOT NULL,
+    reimbursement_amount INTEGER NOT NULL,
+    take_home_pay INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE "payslip_total" ADD CONSTRAINT "fk_payslip_total_payroll_period_id" FOREIGN KEY ("payroll_period_id") REFERENCES "payroll_period" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "payslip_total" ADD CONSTRAINT "fk_payslip_total_employee_id" FOREIGN KEY ("employee_id") REFERENCES "employee" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payslip_total_employee_period ON payslip_total (employee_id, payroll_period_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "payslip_total";
+-- +goose StatementEnd
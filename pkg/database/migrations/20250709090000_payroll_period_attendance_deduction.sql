@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- periods processed so far paid every attendance day in full, they keep doing so when their payslips are regenerated
+ALTER TABLE "payroll_period" ADD COLUMN "attendance_deduction" BOOLEAN NOT NULL DEFAULT false;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "payroll_period" DROP COLUMN IF EXISTS "attendance_deduction";
+-- +goose StatementEnd
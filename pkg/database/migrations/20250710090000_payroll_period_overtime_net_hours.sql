@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- periods processed so far paid overtime without checking the net worked hours, they keep doing so when their
+-- payslips are regenerated
+ALTER TABLE "payroll_period" ADD COLUMN "overtime_net_hours" BOOLEAN NOT NULL DEFAULT false;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "payroll_period" DROP COLUMN IF EXISTS "overtime_net_hours";
+-- +goose StatementEnd
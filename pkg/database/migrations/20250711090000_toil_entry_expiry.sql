@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the expiry of an accrual is recorded by whichever request reads the ledger first, once
+DELETE FROM "toil_entry" t
+USING "toil_entry" d
+WHERE t.type = 'expiry' AND d.type = 'expiry' AND t.accrual_id = d.accrual_id AND t.id > d.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_toil_entry_accrual_id ON toil_entry (accrual_id) WHERE type = 'expiry';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS uq_toil_entry_accrual_id;
+-- +goose StatementEnd
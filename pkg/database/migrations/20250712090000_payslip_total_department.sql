@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the journal of a processed period books each payslip to the department of the employee when it was processed,
+-- totals stored so far take the department the employee has now
+ALTER TABLE "payslip_total" ADD COLUMN "department" VARCHAR(100);
+UPDATE "payslip_total" t SET department = e.department FROM "employee" e WHERE e.id = t.employee_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "payslip_total" DROP COLUMN IF EXISTS "department";
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the payslip report of a processed period shows the basic salary the period was paid on, totals stored so far
+-- take the salary the employee has now
+ALTER TABLE "payslip_total" ADD COLUMN "basic_salary" INTEGER;
+UPDATE "payslip_total" t SET basic_salary = e.salary FROM "employee" e WHERE e.id = t.employee_id;
+ALTER TABLE "payslip_total" ALTER COLUMN "basic_salary" SET NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "payslip_total" DROP COLUMN IF EXISTS "basic_salary";
+-- +goose StatementEnd
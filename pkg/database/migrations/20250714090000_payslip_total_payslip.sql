@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the payslip of a processed period is served as it was issued, totals stored so far carry none and their payslip
+-- is regenerated
+ALTER TABLE "payslip_total" ADD COLUMN "payslip" JSONB;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "payslip_total" DROP COLUMN IF EXISTS "payslip";
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- periods processed so far paid the reimbursements approved from their start date until the day they were processed,
+-- they keep doing so when their payslips are regenerated
+ALTER TABLE "payroll_period" ADD COLUMN "reimbursement_window" BOOLEAN NOT NULL DEFAULT false;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "payroll_period" DROP COLUMN IF EXISTS "reimbursement_window";
+-- +goose StatementEnd
@@ -24,8 +24,14 @@ func NewFiber(config *config.Config, log *logrus.Logger) *fiber.App {
 		DisableStartupMessage: true,
 		ErrorHandler:          errorHandler(contextLogger),
 		Prefork:               config.App.Prefork,
-		JSONEncoder:           sonic.Marshal,
-		JSONDecoder:           sonic.Unmarshal,
+		BodyLimit:             config.App.BodyLimit,
+		// the proxy header is only trusted on requests from the configured proxies, c.IP() is the peer otherwise
+		ProxyHeader:             config.App.ProxyHeader,
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          config.App.TrustedProxies,
+		EnableIPValidation:      true,
+		JSONEncoder:             sonic.Marshal,
+		JSONDecoder:             sonic.Unmarshal,
 	})
 
 	return app
@@ -0,0 +1,64 @@
+package geofence
+
+import (
+	"math"
+	"net/netip"
+)
+
+// earthRadius is the mean radius of the earth in meters
+const earthRadius = 6371000.0
+
+// Site is a circular geofence with the networks its devices connect from
+type Site struct {
+	Name      string
+	Latitude  float64
+	Longitude float64
+	// Radius of the geofence in meters, zero disables the geofence
+	Radius   float64
+	Networks []netip.Prefix
+}
+
+// NewSite builds a site, skipping the CIDRs that cannot be parsed and returning them as invalid
+func NewSite(name string, latitude, longitude, radius float64, cidrs []string) (site Site, invalid []string) {
+	site = Site{Name: name, Latitude: latitude, Longitude: longitude, Radius: radius}
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			invalid = append(invalid, cidr)
+			continue
+		}
+		site.Networks = append(site.Networks, prefix.Masked())
+	}
+	return site, invalid
+}
+
+// Contains checks if the coordinates lie within the geofence of the site
+func (s Site) Contains(latitude, longitude float64) bool {
+	return s.Radius > 0 && Distance(s.Latitude, s.Longitude, latitude, longitude) <= s.Radius
+}
+
+// Allows checks if the IP address belongs to one of the networks of the site
+func (s Site) Allows(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, network := range s.Networks {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Distance returns the great-circle distance in meters between two coordinates
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
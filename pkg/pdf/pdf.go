@@ -0,0 +1,164 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	pageWidth    = 595.28 // A4 width in points
+	pageHeight   = 841.89 // A4 height in points
+	marginX      = 56.0
+	marginTop    = 64.0
+	marginBottom = 64.0
+)
+
+// Font is one of the standard PDF fonts available without embedding
+type Font string
+
+const (
+	FontRegular Font = "F1"
+	FontBold    Font = "F2"
+)
+
+// Document is a minimal text-only PDF writer with automatic page breaks
+type Document struct {
+	pages   []*bytes.Buffer
+	cursorY float64
+}
+
+func NewDocument() *Document {
+	d := &Document{}
+	d.AddPage()
+	return d
+}
+
+// AddPage starts a new page and moves the cursor to its top margin
+func (d *Document) AddPage() {
+	d.pages = append(d.pages, new(bytes.Buffer))
+	d.cursorY = pageHeight - marginTop
+}
+
+// Heading writes a bold line of text
+func (d *Document) Heading(text string, size float64) {
+	d.writeLine(FontBold, size, marginX, text)
+	d.cursorY -= size * 0.6
+}
+
+// Line writes a regular line of text
+func (d *Document) Line(text string) {
+	d.writeLine(FontRegular, 10, marginX, text)
+}
+
+// Row writes a label on the left margin and a value aligned to the right margin
+func (d *Document) Row(label, value string, bold bool) {
+	font := FontRegular
+	if bold {
+		font = FontBold
+	}
+
+	d.ensureSpace(10)
+	d.text(font, 10, marginX, d.cursorY, label)
+	d.text(font, 10, pageWidth-marginX-textWidth(value, 10), d.cursorY, value)
+	d.cursorY -= 10 * 1.5
+}
+
+// Space moves the cursor down by the given number of points
+func (d *Document) Space(points float64) {
+	d.cursorY -= points
+}
+
+// Separator draws a horizontal rule across the text area
+func (d *Document) Separator() {
+	d.ensureSpace(8)
+	fmt.Fprintf(d.page(), "0.5 w %.2f %.2f m %.2f %.2f l S\n", marginX, d.cursorY+4, pageWidth-marginX, d.cursorY+4)
+	d.cursorY -= 8
+}
+
+// Bytes renders the document
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	offsets := make([]int, 0)
+
+	writeObject := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	// objects 1-4 are the catalog, the page tree and both fonts; each page then takes two objects
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+
+	writeObject("<< /Type /Catalog /Pages 2 0 R >>")
+	writeObject(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	writeObject("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range d.pages {
+		writeObject(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 6+i*2,
+		))
+		writeObject(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+func (d *Document) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+func (d *Document) ensureSpace(size float64) {
+	if d.cursorY-size < marginBottom {
+		d.AddPage()
+	}
+}
+
+func (d *Document) writeLine(font Font, size, x float64, text string) {
+	d.ensureSpace(size)
+	d.text(font, size, x, d.cursorY, text)
+	d.cursorY -= size * 1.5
+}
+
+func (d *Document) text(font Font, size, x, y float64, text string) {
+	fmt.Fprintf(d.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(text))
+}
+
+// escape converts the text to a PDF literal string, replacing characters outside Latin-1
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32:
+			b.WriteByte(' ')
+		case r > 255:
+			b.WriteByte('?')
+		case r > 126:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// textWidth approximates the width of Helvetica text, used to right-align values
+func textWidth(text string, size float64) float64 {
+	return float64(len([]rune(text))) * size * 0.5
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalRejectsKeysLeavingRoot(t *testing.T) {
+	parent := t.TempDir()
+	root := filepath.Join(parent, "root")
+	local := NewLocal(root)
+
+	keys := []string{"", ".", "..", "../x", "/abs", "/etc/passwd", "a/../../b", "a/../..", "../root/x"}
+	for _, key := range keys {
+		t.Run(key, func(t *testing.T) {
+			if err := local.Put(t.Context(), key, []byte("data"), "text/plain"); !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("Put(%q) error = %v, want %v", key, err, ErrInvalidKey)
+			}
+			if _, err := local.Get(t.Context(), key); !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("Get(%q) error = %v, want %v", key, err, ErrInvalidKey)
+			}
+			if err := local.Delete(t.Context(), key); !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("Delete(%q) error = %v, want %v", key, err, ErrInvalidKey)
+			}
+		})
+	}
+
+	// nothing was written next to the root
+	entries, err := os.ReadDir(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("entries next to the root = %d, want 0", len(entries))
+	}
+}
+
+func TestLocalRoundTrip(t *testing.T) {
+	root := t.TempDir()
+	local := NewLocal(root)
+	ctx := t.Context()
+
+	// a key cleaned back under the root stays valid
+	for _, key := range []string{"receipts/01/a.pdf", "receipts/x/../01/b.pdf"} {
+		if err := local.Put(ctx, key, []byte("receipt"), "application/pdf"); err != nil {
+			t.Fatalf("Put(%q) error = %v", key, err)
+		}
+
+		file, err := local.Get(ctx, key)
+		if err != nil {
+			t.Fatalf("Get(%q) error = %v", key, err)
+		}
+		data, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "receipt" {
+			t.Errorf("Get(%q) = %q, want %q", key, data, "receipt")
+		}
+
+		if err := local.Delete(ctx, key); err != nil {
+			t.Fatalf("Delete(%q) error = %v", key, err)
+		}
+		if _, err := local.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get(%q) after Delete error = %v, want %v", key, err, ErrNotFound)
+		}
+		if err := local.Delete(ctx, key); err != nil {
+			t.Errorf("Delete(%q) of a missing object error = %v, want nil", key, err)
+		}
+	}
+
+	// no temporary upload file is left behind
+	entries, err := os.ReadDir(filepath.Join(root, "receipts", "01"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("entries left = %d, want 0", len(entries))
+	}
+}
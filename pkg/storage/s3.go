@@ -0,0 +1,252 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// emptyPayloadHash is the SHA-256 of an empty request body
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// defaultTimeout bounds a request to the service when no timeout is configured
+const defaultTimeout = 30 * time.Second
+
+// S3Options holds the connection settings of an S3-compatible object storage
+type S3Options struct {
+	// Endpoint is the base URL of the service, e.g. https://s3.ap-southeast-1.amazonaws.com
+	Endpoint string
+	// Region the bucket lives in, used to sign the requests
+	Region string
+	// Bucket objects are stored in
+	Bucket string
+	// AccessKey and SecretKey are the credentials requests are signed with
+	AccessKey string
+	SecretKey string
+	// PathStyle addresses the bucket in the path instead of the host name, as most self-hosted services expect
+	PathStyle bool
+	// Timeout bounds each request, including reading the response body, 30 seconds when zero
+	Timeout time.Duration
+	// Client sends the requests, a client with the timeout when nil
+	Client *http.Client
+}
+
+// S3 stores objects in a bucket of an S3-compatible object storage, signing the requests with AWS Signature
+// Version 4
+type S3 struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+	client    *http.Client
+}
+
+func NewS3(options *S3Options) (*S3, error) {
+	endpoint, err := url.Parse(options.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("endpoint must be an absolute URL: %q", options.Endpoint)
+	}
+	if options.Bucket == "" || options.Region == "" {
+		return nil, fmt.Errorf("bucket and region are required")
+	}
+
+	client := options.Client
+	if client == nil {
+		timeout := options.Timeout
+		if timeout <= 0 {
+			timeout = defaultTimeout
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+
+	return &S3{
+		endpoint:  endpoint,
+		region:    options.Region,
+		bucket:    options.Bucket,
+		accessKey: options.AccessKey,
+		secretKey: options.SecretKey,
+		pathStyle: options.PathStyle,
+		client:    client,
+	}, nil
+}
+
+func (s *S3) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	request, err := s.newRequest(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	s.sign(request, hashHex(data), time.Now())
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return responseError(response)
+	}
+	return nil
+}
+
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	request, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(request, emptyPayloadHash, time.Now())
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return response.Body, nil
+	case http.StatusNotFound:
+		response.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer response.Body.Close()
+		return nil, responseError(response)
+	}
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	request, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	s.sign(request, emptyPayloadHash, time.Now())
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return responseError(response)
+	}
+}
+
+// newRequest builds a request on the object stored under the key
+func (s *S3) newRequest(ctx context.Context, method, key string, data []byte) (*http.Request, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return nil, ErrInvalidKey
+	}
+
+	target := *s.endpoint
+	path := strings.TrimSuffix(target.Path, "/")
+	if s.pathStyle {
+		path += "/" + s.bucket
+	} else {
+		target.Host = s.bucket + "." + target.Host
+	}
+	target.Path = path + "/" + key
+	target.RawPath = encodePath(path) + "/" + encodePath(key)
+
+	var body io.Reader
+	if data != nil {
+		body = bytes.NewReader(data)
+	}
+	return http.NewRequestWithContext(ctx, method, target.String(), body)
+}
+
+// sign adds the AWS Signature Version 4 authorization of the request, made at the given time
+func (s *S3) sign(request *http.Request, payloadHash string, at time.Time) {
+	at = at.UTC()
+	amzDate := at.Format("20060102T150405Z")
+	scope := at.Format("20060102") + "/" + s.region + "/s3/aws4_request"
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	// headers are listed in lowercase and sorted by name
+	headers := []string{"host:" + request.URL.Host}
+	signedHeaders := []string{"host"}
+	if contentType := request.Header.Get("Content-Type"); contentType != "" {
+		headers = append([]string{"content-type:" + strings.TrimSpace(contentType)}, headers...)
+		signedHeaders = append([]string{"content-type"}, signedHeaders...)
+	}
+	headers = append(headers, "x-amz-content-sha256:"+payloadHash, "x-amz-date:"+amzDate)
+	signedHeaders = append(signedHeaders, "x-amz-content-sha256", "x-amz-date")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		strings.Join(headers, "\n") + "\n",
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretKey), at.Format("20060102"))
+	signingKey = hmacSHA256(signingKey, s.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+
+	request.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, strings.Join(signedHeaders, ";"), hex.EncodeToString(hmacSHA256(signingKey, stringToSign)),
+	))
+}
+
+// encodePath percent-encodes every byte of the path but the unreserved characters and the slashes, as the
+// canonical request expects
+func encodePath(path string) string {
+	var builder strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-._~/", c) >= 0 {
+			builder.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&builder, "%%%02X", c)
+	}
+	return builder.String()
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// responseError reports an unexpected response along with the start of its body, which holds the error code
+func responseError(response *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+	return fmt.Errorf("storage: unexpected status %s: %s", response.Status, strings.TrimSpace(string(body)))
+}
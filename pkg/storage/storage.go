@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"payslip-generator-service/config"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrNotFound is returned when no object is stored under the key
+	ErrNotFound = errors.New("storage: object not found")
+	// ErrInvalidKey is returned for a key that would escape the storage root
+	ErrInvalidKey = errors.New("storage: invalid key")
+)
+
+// Storage stores uploaded files as objects addressed by a slash separated key
+type Storage interface {
+	// Put stores data under the key, replacing any object already stored there
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Get opens the object stored under the key, the caller closes it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object stored under the key, deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// NewStorage builds the storage backend selected by the configured driver, the local filesystem by default
+func NewStorage(conf *config.Config, log *logrus.Logger) Storage {
+	switch conf.Storage.Driver {
+	case "", "local":
+		root := conf.Storage.Local.Root
+		if root == "" {
+			root = "storage"
+		}
+		log.Infof("using local storage, root: %s", root)
+		return NewLocal(root)
+	case "s3":
+		s3, err := NewS3(&S3Options{
+			Endpoint:  conf.Storage.S3.Endpoint,
+			Region:    conf.Storage.S3.Region,
+			Bucket:    conf.Storage.S3.Bucket,
+			AccessKey: conf.Storage.S3.AccessKey,
+			SecretKey: conf.Storage.S3.SecretKey,
+			PathStyle: conf.Storage.S3.PathStyle,
+			Timeout:   time.Duration(conf.Storage.S3.Timeout) * time.Second,
+		})
+		if err != nil {
+			panic(fmt.Errorf("failed configure s3 storage: %v", err))
+		}
+		log.Infof("using s3 storage, endpoint: %s, bucket: %s", conf.Storage.S3.Endpoint, conf.Storage.S3.Bucket)
+		return s3
+	default:
+		panic(fmt.Errorf("unknown storage driver: %s", conf.Storage.Driver))
+	}
+}
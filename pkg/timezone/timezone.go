@@ -0,0 +1,42 @@
+package timezone
+
+import (
+	"sync"
+	"time"
+)
+
+var locations sync.Map
+
+// Load returns the location of an IANA time zone name, caching loaded locations
+func Load(name string) (*time.Location, error) {
+	if location, ok := locations.Load(name); ok {
+		return location.(*time.Location), nil
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+
+	locations.Store(name, location)
+	return location, nil
+}
+
+// LoadOrDefault returns the location of the first valid name, falling back to UTC
+func LoadOrDefault(names ...string) *time.Location {
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if location, err := Load(name); err == nil {
+			return location
+		}
+	}
+	return time.UTC
+}
+
+// DateOf returns the calendar date of t in the location as midnight UTC, the form DATE columns are read back in
+func DateOf(t time.Time, location *time.Location) time.Time {
+	t = t.In(location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}